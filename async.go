@@ -0,0 +1,168 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type (
+	// AsyncLoad is the handle of loading started by Config.LoadAsync.
+	AsyncLoad struct {
+		progress chan LoadProgress
+		done     chan struct{}
+		err      error
+	}
+
+	// LoadProgress is the event emitted by AsyncLoad.Progress for each loader.
+	LoadProgress struct {
+		Loader Loader
+		// Done reports whether the loader has completed loading.
+		// It's false if the loading is cancelled before the loader completes.
+		Done bool
+		// Err is the error when loading, or the error of context if the loading is cancelled.
+		Err error
+		// Duration is the time spent on loading.
+		Duration time.Duration
+	}
+)
+
+// Progress returns the channel which receives the event when each loader completes,
+// in the order of completion. The channel is closed after all loaders complete or the loading is cancelled.
+// The channel is buffered for all loaders, so it never blocks the loading even if it's not drained.
+func (a *AsyncLoad) Progress() <-chan LoadProgress {
+	return a.progress
+}
+
+// Wait blocks until all loaders complete or the loading is cancelled,
+// and returns the joined errors of all loaders.
+func (a *AsyncLoad) Wait() error {
+	<-a.done
+
+	return a.err
+}
+
+var errNilConfig = errors.New("nil Config")
+
+// failedLoad returns the AsyncLoad which has completed with the given error without any loader.
+func failedLoad(err error) *AsyncLoad {
+	load := &AsyncLoad{
+		progress: make(chan LoadProgress),
+		done:     make(chan struct{}),
+		err:      err,
+	}
+	close(load.progress)
+	close(load.done)
+
+	return load
+}
+
+// LoadAsync loads configuration from the given loaders concurrently,
+// and returns the handle for reporting progress and waiting for completion.
+//
+// The precedence of loaders is the same as calling Config.Load with loaders one by one,
+// regardless of the order of completion: the values of each loader are buffered
+// until all loaders before it have completed, and then applied in the given order.
+// The failed loaders are skipped, including the loaders violating konf.WithMutuallyExclusive.
+//
+// If the ctx is cancelled, the values which have not been applied yet are discarded,
+// and it reports the loaders which have not completed with the error of ctx.
+// The Loader.Load is not interruptable, so the pending loaders run to completion in background.
+//
+// This method is concurrent-safe.
+func (c *Config) LoadAsync(ctx context.Context, loaders ...Loader) *AsyncLoad {
+	if c == nil { // To support nil
+		return failedLoad(fmt.Errorf("load configuration: %w", errNilConfig))
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return failedLoad(fmt.Errorf("load configuration: %w", errSubView))
+	}
+
+	validLoaders := make([]Loader, 0, len(loaders))
+	for _, loader := range loaders {
+		if loader != nil {
+			validLoaders = append(validLoaders, loader)
+		}
+	}
+
+	type result struct {
+		index    int
+		provider *provider
+		values   map[string]any
+		err      error
+		duration time.Duration
+	}
+	results := make(chan result, len(validLoaders))
+	for index, loader := range validLoaders {
+		go func() {
+			start := c.timeSource().Now()
+			if err := c.checkLifecycle(loader); err != nil {
+				results <- result{index: index, err: err}
+
+				return
+			}
+			provider := c.newProvider(loader)
+			values, err := loader.Load()
+			if err != nil {
+				err = fmt.Errorf("load configuration from %v: %w", loader, err)
+			}
+			provider.duration = c.timeSource().Now().Sub(start)
+			results <- result{
+				index: index, provider: provider, values: values, err: err,
+				duration: provider.duration,
+			}
+		}()
+	}
+
+	load := &AsyncLoad{
+		progress: make(chan LoadProgress, len(validLoaders)),
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(load.done)
+		defer close(load.progress)
+
+		var (
+			errs      []error
+			completed = make([]*result, len(validLoaders))
+			next      int // The index of next loader to apply.
+		)
+		for range validLoaders {
+			select {
+			case <-ctx.Done():
+				for index, loader := range validLoaders {
+					if completed[index] == nil {
+						load.progress <- LoadProgress{Loader: loader, Err: ctx.Err()}
+					}
+				}
+				load.err = errors.Join(append(errs, ctx.Err())...)
+
+				return
+			case res := <-results:
+				completed[res.index] = &res
+				load.progress <- LoadProgress{
+					Loader: validLoaders[res.index], Done: true, Err: res.err, Duration: res.duration,
+				}
+				if res.err != nil {
+					errs = append(errs, res.err)
+				}
+				// Apply values in the order of loaders.
+				for ; next < len(completed) && completed[next] != nil; next++ {
+					if completed[next].err == nil {
+						if err := c.apply(completed[next].provider, completed[next].values); err != nil {
+							errs = append(errs, fmt.Errorf("load configuration from %v: %w", validLoaders[next], err))
+						}
+					}
+				}
+			}
+		}
+		load.err = errors.Join(errs...)
+	}()
+
+	return load
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestConfig_LoadAsync(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	slow := &blockingLoader{name: "slow", values: map[string]any{"host": "slow", "port": 80}, release: make(chan struct{})}
+	fast := &blockingLoader{name: "fast", values: map[string]any{"host": "fast"}}
+	failed := &blockingLoader{name: "failed", err: errors.New("load error"), release: make(chan struct{})}
+
+	load := config.LoadAsync(context.Background(), slow, nil, fast, failed)
+	assert.Equal[konf.Loader](t, fast, (<-load.Progress()).Loader)
+	close(failed.release)
+	assert.Equal[konf.Loader](t, failed, (<-load.Progress()).Loader)
+	// Values of fast is buffered until slow completes.
+	var host string
+	assert.NoError(t, config.Unmarshal("host", &host))
+	assert.Equal(t, "", host)
+
+	close(slow.release)
+	progress := <-load.Progress()
+	assert.Equal[konf.Loader](t, slow, progress.Loader)
+	assert.True(t, progress.Done)
+	assert.NoError(t, progress.Err)
+	_, ok := <-load.Progress()
+	assert.True(t, !ok)
+
+	assert.EqualError(t, load.Wait(), "load configuration from failed: load error")
+	var values map[string]any
+	assert.NoError(t, config.Unmarshal("", &values))
+	assert.Equal(t, map[string]any{"host": "fast", "port": 80}, values)
+}
+
+func TestConfig_LoadAsync_cancel(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithClock(clock.NewFake(time.Time{})))
+	first := &blockingLoader{name: "first", values: map[string]any{"first": true}, release: make(chan struct{})}
+	defer close(first.release)
+	second := &blockingLoader{name: "second", values: map[string]any{"second": true}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	load := config.LoadAsync(ctx, first, second)
+	assert.Equal(t, konf.LoadProgress{Loader: second, Done: true}, <-load.Progress())
+	cancel()
+
+	assert.Equal(t, konf.LoadProgress{Loader: first, Err: context.Canceled}, <-load.Progress())
+	assert.True(t, errors.Is(load.Wait(), context.Canceled))
+	var values map[string]any
+	assert.NoError(t, config.Unmarshal("", &values))
+	assert.Equal(t, nil, values)
+}
+
+func TestConfig_LoadAsync_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	load := config.LoadAsync(context.Background(), mapLoader{})
+	_, ok := <-load.Progress()
+	assert.True(t, !ok)
+	assert.EqualError(t, load.Wait(), "load configuration: nil Config")
+}
+
+type blockingLoader struct {
+	name    string
+	values  map[string]any
+	err     error
+	release chan struct{}
+}
+
+func (b *blockingLoader) Load() (map[string]any, error) {
+	if b.release != nil {
+		<-b.release
+	}
+
+	return b.values, b.err
+}
+
+func (b *blockingLoader) String() string {
+	return b.name
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+type (
+	// atomicGroup is the group of keys provided by konf.WithAtomicGroup, which change together.
+	atomicGroup struct {
+		paths []string
+		quiet time.Duration
+
+		pending []atomicChange // The held changes in order of arrival.
+		touched []string       // The paths changed by the held changes.
+		cancel  chan struct{}  // Closed once the held changes are applied, nil if no change is held.
+		mutex   sync.Mutex
+	}
+	atomicChange struct {
+		provider *provider
+		values   map[string]any
+	}
+)
+
+// holdAtomic holds the change of the watcher if it changes any key of the groups provided by konf.WithAtomicGroup,
+// or the watcher has held change, and reports whether the change is held.
+// The held changes are applied together once all keys of the group have changed, or after the quiet period.
+func (c *Config) holdAtomic(ctx context.Context, provider *provider, values map[string]any) bool {
+	for _, group := range c.atomicGroups {
+		group.mutex.Lock()
+		var current map[string]any
+		if stored := provider.values.Load(); stored != nil {
+			current = *stored
+		}
+		var touched []string
+		for _, path := range group.paths {
+			keys := c.splitPath(path)
+			if !maps.Equal(maps.Sub(current, keys), maps.Sub(values, keys)) && !slices.Contains(group.touched, path) {
+				touched = append(touched, path)
+			}
+		}
+		index := slices.IndexFunc(group.pending, func(change atomicChange) bool { return change.provider == provider })
+		if len(touched) == 0 && index < 0 {
+			group.mutex.Unlock()
+
+			continue
+		}
+
+		if index < 0 {
+			group.pending = append(group.pending, atomicChange{provider: provider, values: values})
+		} else {
+			group.pending[index].values = values // The latest change of the watcher replaces the held one.
+		}
+		group.touched = append(group.touched, touched...)
+		if len(group.touched) == len(group.paths) {
+			pending := group.release()
+			group.mutex.Unlock()
+			c.applyAtomic(ctx, pending)
+
+			return true
+		}
+		if group.cancel == nil {
+			group.cancel = make(chan struct{})
+			go c.awaitAtomic(ctx, group, group.cancel)
+		}
+		group.mutex.Unlock()
+
+		c.log(ctx, slog.LevelDebug,
+			"Configuration change is held for the atomic group.",
+			slog.Any("loader", provider.loader),
+			slog.Any("group", group.paths),
+		)
+
+		return true
+	}
+
+	return false
+}
+
+// awaitAtomic applies the held changes of the group after the quiet period
+// if not all keys of the group have changed.
+func (c *Config) awaitAtomic(ctx context.Context, group *atomicGroup, cancel chan struct{}) {
+	timer, stop := c.timeSource().NewTimer(group.quiet)
+	defer stop()
+	select {
+	case <-timer:
+	case <-cancel:
+		return
+	case <-ctx.Done():
+		return
+	}
+
+	group.mutex.Lock()
+	if group.cancel != cancel {
+		group.mutex.Unlock()
+
+		return // The held changes have been applied.
+	}
+	var missing []string
+	for _, path := range group.paths {
+		if !slices.Contains(group.touched, path) {
+			missing = append(missing, path)
+		}
+	}
+	pending := group.release()
+	group.mutex.Unlock()
+
+	c.log(ctx, slog.LevelWarn,
+		"Not all keys of the atomic group have changed in the quiet period, apply the partial change.",
+		slog.Any("group", group.paths),
+		slog.Any("missing", missing),
+		slog.Duration("quiet", group.quiet),
+	)
+	c.applyAtomic(ctx, pending)
+}
+
+// release returns the held changes and resets the group. It must be called with the mutex locked.
+func (g *atomicGroup) release() []atomicChange {
+	pending := g.pending
+	if g.cancel != nil {
+		close(g.cancel)
+	}
+	g.pending, g.touched, g.cancel = nil, nil, nil
+
+	return pending
+}
+
+// applyAtomic stores the held changes, and dispatches them as one change.
+// The configuration with all held changes applied is validated the same as the change of a watcher,
+// and all held changes are rejected if it's invalid.
+func (c *Config) applyAtomic(ctx context.Context, pending []atomicChange) {
+	pending = slices.DeleteFunc(pending, func(change atomicChange) bool {
+		// Ignore the changes after the loader is unloaded or disabled.
+		return change.provider.unloaded.Load() || change.provider.disabled.Load()
+	})
+	type stored struct {
+		provider *provider
+		values   map[string]any
+		replaced *[][]string
+	}
+	var (
+		applied   []stored
+		onChanges []*subscription
+	)
+	// Hold the lock across the validation and the stores, see Config.validateAndStore.
+	c.applyMutex.Lock()
+	for index, change := range pending {
+		provider := change.provider
+		replaced := provider.replaced.Load()
+		c.extractReplaceMarkers(provider, change.values)
+		// Validate the last change against the configuration with the other held changes applied.
+		if index == len(pending)-1 {
+			if err := c.validateChange(provider, change.values); err != nil {
+				provider.replaced.Store(replaced)
+				for i := len(applied) - 1; i >= 0; i-- {
+					applied[i].provider.replaced.Store(applied[i].replaced)
+					c.store(applied[i].provider, applied[i].values)
+				}
+				c.applyMutex.Unlock()
+				provider.status(err)
+				c.log(ctx, slog.LevelWarn,
+					"Configuration change of the atomic group is rejected since it's invalid, keep the last good values.",
+					slog.Any("loader", provider.loader),
+					slog.Any("error", err),
+				)
+				if c.onStatus != nil {
+					c.onStatus(provider.loader, false, err)
+				}
+
+				return
+			}
+		}
+		oldValues, newValues := c.store(provider, change.values)
+		applied = append(applied, stored{provider: provider, values: oldValues, replaced: replaced})
+		for _, sub := range c.changedOnChanges(oldValues, newValues) {
+			if !slices.Contains(onChanges, sub) {
+				onChanges = append(onChanges, sub)
+			}
+		}
+	}
+	c.applyMutex.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	loader := pending[len(pending)-1].provider.loader
+	if watch := c.watched.Load(); watch != nil {
+		watch.notify(loader, onChanges)
+	}
+	if !c.quietChanges {
+		for _, change := range pending {
+			c.log(ctx, slog.LevelInfo, "Configuration has been changed.", slog.Any("loader", change.provider.loader))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestWithAtomicGroup(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		changeKey   bool
+		expected    []string
+		log         string
+	}{
+		{
+			description: "all keys changed",
+			changeKey:   true,
+			expected:    []string{"new-cert", "new-key"},
+		},
+		{
+			description: "quiet period elapsed",
+			expected:    []string{"new-cert", "old-key"},
+			log: `level=WARN msg="Not all keys of the atomic group have changed in the quiet period,` +
+				` apply the partial change." group="[tls.cert tls.key]" missing=[tls.key] quiet=5s`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &buffer{}
+			fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			config := konf.New(
+				konf.WithAtomicGroup(5*time.Second, "tls.key", "tls.cert"),
+				konf.WithClock(fake),
+				konf.WithLogHandler(logHandler(buf)),
+			)
+			cert := mapWatcher{values: map[string]any{"tls": map[string]any{"cert": "old-cert"}}, change: make(chan map[string]any)}
+			key := mapWatcher{values: map[string]any{"tls": map[string]any{"key": "old-key"}}, change: make(chan map[string]any)}
+			assert.NoError(t, config.Load(cert))
+			assert.NoError(t, config.Load(key))
+
+			pairs := make(chan []string, 2)
+			config.OnChange(func(config *konf.Config) {
+				pairs <- []string{config.GetString("tls.cert"), config.GetString("tls.key")}
+			}, "tls")
+
+			stopped := make(chan struct{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer func() {
+				cancel()
+				<-stopped
+			}()
+			go func() {
+				defer close(stopped)
+				assert.NoError(t, config.Watch(ctx))
+			}()
+
+			// The watcher receives the change once Config.Watch has started.
+			cert.change <- map[string]any{"tls": map[string]any{"cert": "new-cert"}}
+			fake.BlockUntil(1) // Wait for the change to be held.
+			assert.Equal(t, "old-cert", config.GetString("tls.cert"))
+			fake.Advance(time.Second)
+			if testcase.changeKey {
+				key.change <- map[string]any{"tls": map[string]any{"key": "new-key"}}
+			} else {
+				fake.Advance(4 * time.Second)
+			}
+
+			assert.Equal(t, testcase.expected, <-pairs)
+			assert.Equal(t, 0, len(pairs))
+			assert.Equal(t, testcase.expected[0], config.GetString("tls.cert"))
+			assert.Equal(t, testcase.expected[1], config.GetString("tls.key"))
+			if testcase.log != "" {
+				assert.True(t, strings.Contains(buf.String(), testcase.log))
+			} else {
+				assert.Equal(t, []string{"tls.cert", "tls.key"}, config.LastChange().Keys)
+			}
+		})
+	}
+}
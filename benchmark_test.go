@@ -6,6 +6,7 @@ package konf_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/nil-go/konf"
 	"github.com/nil-go/konf/internal/assert"
@@ -59,6 +60,61 @@ func BenchmarkUnmarshal(b *testing.B) {
 	})
 }
 
+func BenchmarkAccessor(b *testing.B) {
+	config := konf.New()
+	assert.NoError(b, config.Load(mapLoader{"timeout": "1s"}))
+	timeout, err := konf.Accessor[time.Duration](config, "timeout")
+	assert.NoError(b, err)
+	assert.Equal(b, time.Second, timeout())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = timeout()
+		}
+	})
+}
+
+func BenchmarkUnmarshal_snapshot(b *testing.B) {
+	config := konf.New(konf.WithSnapshotStats())
+	assert.NoError(b, config.Load(mapLoader{"k": "v"}))
+	before := config.DebugState().Snapshot
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var value string
+		for pb.Next() {
+			_ = config.Unmarshal("k", &value)
+		}
+	})
+
+	b.StopTimer()
+	after := config.DebugState().Snapshot
+	hits, rebuilds := after.Hits-before.Hits, after.Rebuilds-before.Rebuilds
+	// The snapshot should never be rebuilt in steady state.
+	assert.Equal(b, uint64(0), rebuilds)
+	b.ReportMetric(float64(hits)/float64(hits+rebuilds), "hit-ratio")
+}
+
 type Value struct {
 	User string
 }
+
+func BenchmarkNamespace(b *testing.B) {
+	config := konf.New()
+	assert.NoError(b, config.Load(mapLoader{"defaults": map[string]any{"limit": 10}}))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var limit int
+			_ = konf.Namespace(config, "tenants.acme", konf.FallbackTo("defaults")).Unmarshal("limit", &limit)
+		}
+	})
+}
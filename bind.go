@@ -0,0 +1,96 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Bind reads configuration under the given path from the Config into a new T,
+// and stores it into the given target. It returns error if the initial decoding fails.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+// The empty path binds the whole configuration.
+//
+// After binding, it re-reads the configuration and atomically stores the new T into the target
+// whenever the value of the path changes, so readers get the latest value via target.Load() without lock.
+// It requires Config.Watch has been called.
+// If decoding fails on change, the target keeps the previous value,
+// and the error is logged and reported to the callback provided by konf.WithOnStatus.
+//
+// This method is concurrent-safe.
+func Bind[T any](config *Config, target *atomic.Pointer[T], path string) error {
+	_, err := bind(config, target, path, 3) //nolint:mnd
+
+	return err
+}
+
+// Accessor returns the function reading configuration under the given path from the Config as T,
+// for the keys read on the hot path, e.g. rate limits and timeouts. It returns error if the initial decoding fails.
+//
+// The configuration is decoded only when the value of the path changes, the same as Bind,
+// so that the returned function is a single atomic load without allocation.
+// If decoding fails on change, it keeps returning the previous value.
+//
+// This function is concurrent-safe, and so is the returned function.
+func Accessor[T any](config *Config, path string) (func() T, error) {
+	target := new(atomic.Pointer[T])
+	if _, err := bind(config, target, path, 3); err != nil { //nolint:mnd
+		return nil, err
+	}
+
+	return func() T {
+		return *target.Load()
+	}, nil
+}
+
+// bind implements Bind, and returns the subscription of changes, or nil if there is no subscription.
+// The skip is the number of stack frames to skip for reporting the caller of registration.
+func bind[T any](config *Config, target *atomic.Pointer[T], path string, skip int) (*subscription, error) {
+	if target == nil {
+		return nil, errNilTarget
+	}
+	if config == nil {
+		target.Store(new(T))
+
+		return nil, nil
+	}
+
+	value := new(T)
+	if err := config.Unmarshal(path, value); err != nil {
+		return nil, fmt.Errorf("bind %s: %w", path, err)
+	}
+	target.Store(value)
+
+	var paths []string
+	if path != "" {
+		paths = []string{path}
+	}
+	sub := config.registerOnChange(func(config *Config) {
+		value := new(T)
+		if err := config.Unmarshal(path, value); err != nil {
+			err = fmt.Errorf("bind %s: %w", path, err)
+			loader := config.LastChange().Loader
+			config.log(context.Background(), slog.LevelWarn,
+				"Error when binding configuration, keep the previous value.",
+				slog.String("path", path),
+				slog.Any("loader", loader),
+				slog.Any("error", err),
+			)
+			if config.onStatus != nil {
+				config.onStatus(loader, true, err)
+			}
+
+			return
+		}
+		target.Store(value)
+	}, paths, skip)
+
+	return sub, nil
+}
+
+var errNilTarget = errors.New("nil target")
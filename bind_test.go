@@ -0,0 +1,140 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host string
+		Port int
+	}
+
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithLogHandler(logHandler(&buffer{})),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) { statuses <- err }),
+	)
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"host": "localhost", "port": 8080}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	var target atomic.Pointer[server]
+	assert.NoError(t, konf.Bind(config, &target, "server"))
+	assert.Equal(t, server{Host: "localhost", Port: 8080}, *target.Load())
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { changed <- struct{}{} }, "server")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"server": map[string]any{"host": "example.com", "port": 8443}}
+	<-changed
+	assert.Equal(t, server{Host: "example.com", Port: 8443}, *target.Load())
+
+	watcher.change <- map[string]any{"server": map[string]any{"host": "example.com", "port": "invalid"}}
+	<-changed
+	assert.EqualError(t, <-statuses, "bind server: decode: cannot parse 'server.Port' as int: "+
+		`strconv.ParseInt: parsing "invalid": invalid syntax`)
+	assert.Equal(t, server{Host: "example.com", Port: 8443}, *target.Load())
+}
+
+func TestBind_error(t *testing.T) {
+	t.Parallel()
+
+	var config konf.Config
+	assert.NoError(t, config.Load(mapLoader{"port": "invalid"}))
+
+	var target atomic.Pointer[int]
+	assert.EqualError(t, konf.Bind(&config, &target, "port"),
+		`bind port: decode: cannot parse 'port' as int: strconv.ParseInt: parsing "invalid": invalid syntax`)
+	assert.Equal(t, nil, target.Load())
+	assert.EqualError(t, konf.Bind[int](&config, nil, "port"), "nil target")
+
+	assert.NoError(t, konf.Bind(nil, &target, "port"))
+	assert.Equal(t, 0, *target.Load())
+}
+
+func TestAccessor(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	watcher := mapWatcher{values: map[string]any{"timeout": "1s"}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	timeout, err := konf.Accessor[time.Duration](config, "timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, timeout())
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { changed <- struct{}{} }, "timeout")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"timeout": "2s"}
+	<-changed
+	assert.Equal(t, 2*time.Second, timeout())
+
+	watcher.change <- map[string]any{"timeout": "invalid"}
+	<-changed
+	assert.Equal(t, 2*time.Second, timeout())
+}
+
+//nolint:paralleltest // AllocsPerRun panics in parallel tests.
+func TestAccessor_allocs(t *testing.T) {
+	var config konf.Config
+	assert.NoError(t, config.Load(mapLoader{"timeout": "1s"}))
+	timeout, err := konf.Accessor[time.Duration](&config, "timeout")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { _ = timeout() }))
+}
+
+func TestAccessor_error(t *testing.T) {
+	t.Parallel()
+
+	var config konf.Config
+	assert.NoError(t, config.Load(mapLoader{"port": "invalid"}))
+
+	port, err := konf.Accessor[int](&config, "port")
+	assert.EqualError(t, err,
+		`bind port: decode: cannot parse 'port' as int: strconv.ParseInt: parsing "invalid": invalid syntax`)
+	assert.True(t, port == nil)
+
+	port, err = konf.Accessor[int](nil, "port")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, port())
+}
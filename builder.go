@@ -0,0 +1,214 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/nil-go/konf/provider/env"
+	kflag "github.com/nil-go/konf/provider/flag"
+	kfs "github.com/nil-go/konf/provider/fs"
+)
+
+// Builder assembles a Config from the conventional layers of configuration:
+// files, environment variables and command-line flags.
+//
+// Regardless of the order of method calls, the layers are loaded
+// with the precedence flags > env > loaders > files,
+// and layers of the same kind take precedence in the order of method calls.
+// For other precedence, use Config.Load directly.
+//
+// Unlike konf.New, it validates the combination of Option(s) in Builder.Build,
+// so that the contradictory or incomplete options fail early instead of at runtime.
+//
+// To create a new Builder, call [NewBuilder].
+type Builder struct {
+	opts      []Option
+	files     []func() Loader
+	loaders   []Loader
+	envs      []func() Loader
+	flags     []func(*Config) Loader
+	onChanges []onChangeRegistration
+}
+
+type onChangeRegistration struct {
+	onChange func(*Config)
+	opts     []OnChangeOption
+}
+
+// ErrInvalidOptions is the error returned by Builder.Build
+// if the combination of Option(s) is contradictory or incomplete.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// NewBuilder creates a new Builder with the given Option(s) for the built Config.
+func NewBuilder(opts ...Option) *Builder {
+	return &Builder{opts: opts}
+}
+
+// With adds the given Option(s) for the built Config, after the ones provided by NewBuilder.
+func (b *Builder) With(opts ...Option) *Builder {
+	b.opts = append(b.opts, opts...)
+
+	return b
+}
+
+// OnChange registers the callback the same as Config.OnChange once the Config is built,
+// so that Builder.Build returns the Config ready for Config.Watch.
+func (b *Builder) OnChange(onChange func(*Config), paths ...string) *Builder {
+	return b.OnChangeWith(onChange, Keys(paths...))
+}
+
+// OnChangeWith registers the callback the same as Config.OnChangeWith once the Config is built.
+func (b *Builder) OnChangeWith(onChange func(*Config), opts ...OnChangeOption) *Builder {
+	b.onChanges = append(b.onChanges, onChangeRegistration{onChange: onChange, opts: opts})
+
+	return b
+}
+
+// File adds the file at the given path with the given Option(s) of [kfs.New].
+// By default, the file is unmarshalled as JSON.
+func (b *Builder) File(path string, opts ...kfs.Option) *Builder {
+	b.files = append(b.files, func() Loader {
+		return kfs.New(os.DirFS(filepath.Dir(path)), filepath.Base(path), opts...)
+	})
+
+	return b
+}
+
+// Env adds the environment variables whose names start with the given prefix.
+// The empty prefix loads all environment variables.
+func (b *Builder) Env(prefix string) *Builder {
+	b.envs = append(b.envs, func() Loader {
+		return env.New(env.WithPrefix(prefix))
+	})
+
+	return b
+}
+
+// Flags adds the flags defined in the given [flag.FlagSet].
+// The nil set loads flags defined in [flag.CommandLine].
+//
+// The unchanged flags with zero default value are skipped,
+// and default flag values are only merged for the paths not set by other layers.
+func (b *Builder) Flags(set *flag.FlagSet) *Builder {
+	b.flags = append(b.flags, func(config *Config) Loader {
+		if set == nil {
+			return kflag.New(config)
+		}
+
+		return kflag.New(config, kflag.WithFlagSet(set))
+	})
+
+	return b
+}
+
+// Loader adds the given loader(s), e.g. provider of remote configuration,
+// which takes precedence over files but is overridden by env and flags.
+func (b *Builder) Loader(loaders ...Loader) *Builder {
+	b.loaders = append(b.loaders, loaders...)
+
+	return b
+}
+
+// Build creates a new Config, loads all layers into it and registers the callbacks.
+//
+// It returns nil Config and the error wrapping ErrInvalidOptions for each contradictory
+// or incomplete combination of Option(s), e.g. konf.WithValidator without konf.WithTagValidation,
+// instead of ignoring them as konf.New does.
+// Otherwise, it loads all layers even if some of them fail,
+// and returns the Config with the joined errors of loading.
+func (b *Builder) Build() (*Config, error) {
+	option := &options{}
+	for _, opt := range b.opts {
+		opt(option)
+	}
+	errs := b.validate(option)
+
+	loaders := make([]Loader, 0, len(b.files)+len(b.loaders)+len(b.envs)+len(b.flags))
+	for _, file := range b.files {
+		loaders = append(loaders, file())
+	}
+	loaders = append(loaders, b.loaders...)
+	for _, env := range b.envs {
+		loaders = append(loaders, env())
+	}
+	// The loaders of flags are created with the Config, so they are never provided to konf.WithAutoReload.
+	for _, reload := range option.autoReloads {
+		for _, loader := range reload.loaders {
+			if !slices.ContainsFunc(loaders, func(l Loader) bool { return sameLoader(l, loader) }) {
+				errs = append(errs, fmt.Errorf("konf.WithAutoReload has loader %v which is not added to Builder: %w",
+					loader, ErrInvalidOptions))
+			}
+		}
+	}
+
+	config, err := newConfig(b.opts)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, flag := range b.flags {
+		loaders = append(loaders, flag(config))
+	}
+
+	for _, loader := range loaders {
+		if err := config.Load(loader); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, registration := range b.onChanges {
+		config.OnChangeWith(registration.onChange, registration.opts...)
+	}
+
+	return config, errors.Join(errs...)
+}
+
+// validate returns the errors for the contradictory or incomplete combinations of the given options.
+func (b *Builder) validate(option *options) []error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format+": %w", append(args, ErrInvalidOptions)...))
+	}
+
+	if option.caseSensitive && option.mapKeyCaseSensitive {
+		invalid("konf.WithMapKeyCaseSensitive has no effect with konf.WithCaseSensitive")
+	}
+	if len(option.validators) > 0 && !option.tagValidation {
+		names := make([]string, 0, len(option.validators))
+		for name := range option.validators {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		invalid("konf.WithValidator(%v) requires konf.WithTagValidation", names)
+	}
+	groups := make([]string, 0, len(option.groupPolicies))
+	for name := range option.groupPolicies {
+		groups = append(groups, name)
+	}
+	slices.Sort(groups)
+	for _, name := range groups {
+		if option.groupPolicies[name].conflicting {
+			invalid("konf.WithGroupPolicy(%s) has both konf.Sequential and konf.Concurrent", name)
+		}
+	}
+	for _, pattern := range option.restartRequired {
+		if pattern == "" {
+			invalid("konf.WithRestartRequired has empty pattern")
+		}
+	}
+	for index, registration := range b.onChanges {
+		if registration.onChange == nil {
+			invalid("onChange #%d registered by Builder is nil", index)
+		}
+	}
+
+	return errs
+}
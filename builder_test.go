@@ -0,0 +1,185 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Setenv("BUILDER_SERVER_HOST", "env.example.com")
+	t.Setenv("BUILDER_SERVER_PORT", "8080")
+
+	set := flag.NewFlagSet("builder", flag.ContinueOnError)
+	set.String("builder.server.port", "", "port")
+	set.String("builder.server.name", "flag", "name")
+	_ = set.Parse([]string{"-builder.server.port=9090"})
+
+	config, err := konf.NewBuilder().
+		Flags(set).
+		Env("BUILDER").
+		Loader(mapLoader{"builder": map[string]any{"server": map[string]any{"host": "map.example.com", "tls": true}}}).
+		File("testdata/config.json").
+		Build()
+	assert.NoError(t, err)
+
+	var server struct {
+		Host string
+		Port int
+		Name string
+		TLS  bool
+	}
+	assert.NoError(t, config.Unmarshal("builder.server", &server))
+	assert.Equal(t, "env.example.com", server.Host)
+	assert.Equal(t, 9090, server.Port)
+	assert.Equal(t, "flag", server.Name)
+	assert.True(t, server.TLS)
+
+	var host string
+	assert.NoError(t, config.Unmarshal("server.host", &host))
+	assert.Equal(t, "example.com", host)
+}
+
+func TestBuilder_error(t *testing.T) {
+	t.Parallel()
+
+	config, err := konf.NewBuilder().
+		File("testdata/not_found.json").
+		Loader(mapLoader{"config": "string"}).
+		Build()
+	assert.EqualError(t, err, "load configuration: read file: open not_found.json: no such file or directory")
+
+	var value string
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, "string", value)
+}
+
+func TestBuilder_invalid(t *testing.T) {
+	t.Parallel()
+
+	compute := func(*konf.Config) (any, error) { return 1, nil }
+	testcases := []struct {
+		description string
+		builder     *konf.Builder
+		err         string
+	}{
+		{
+			description: "case sensitive with map key case sensitive",
+			builder:     konf.NewBuilder(konf.WithCaseSensitive()).With(konf.WithMapKeyCaseSensitive()),
+			err:         "konf.WithMapKeyCaseSensitive has no effect with konf.WithCaseSensitive: invalid options",
+		},
+		{
+			description: "validator without tag validation",
+			builder: konf.NewBuilder(
+				konf.WithValidator("port", func(any, string) error { return nil }),
+				konf.WithValidator("host", func(any, string) error { return nil }),
+			),
+			err: "konf.WithValidator([host port]) requires konf.WithTagValidation: invalid options",
+		},
+		{
+			description: "sequential with concurrent",
+			builder:     konf.NewBuilder(konf.WithGroupPolicy("heavy", konf.Concurrent(), konf.Sequential())),
+			err:         "konf.WithGroupPolicy(heavy) has both konf.Sequential and konf.Concurrent: invalid options",
+		},
+		{
+			description: "empty restart pattern",
+			builder:     konf.NewBuilder(konf.WithRestartRequired("")),
+			err:         "konf.WithRestartRequired has empty pattern: invalid options",
+		},
+		{
+			description: "cyclic computed keys",
+			builder: konf.NewBuilder(
+				konf.WithComputed("a", []string{"b"}, compute),
+				konf.WithComputed("b", []string{"a"}, compute),
+			),
+			err: "konf.WithComputed(b) has cyclic dependencies, empty path or nil function: invalid options",
+		},
+		{
+			description: "mutually exclusive defaults",
+			builder: konf.NewBuilder(
+				konf.WithMutuallyExclusive("a", "b"),
+				konf.WithDefaults(map[string]any{"a": 1, "b": 2}),
+			),
+			err: "konf.WithDefaults has invalid values: load configuration: " +
+				"mutually exclusive keys are set together: a, b: invalid options",
+		},
+		{
+			description: "auto reload loader not added",
+			builder:     konf.NewBuilder(konf.WithAutoReload(time.Minute, mapLoader{})),
+			err:         "konf.WithAutoReload has loader map which is not added to Builder: invalid options",
+		},
+		{
+			description: "nil onChange",
+			builder:     konf.NewBuilder().OnChange(nil, "config"),
+			err:         "onChange #0 registered by Builder is nil: invalid options",
+		},
+		{
+			description: "multiple errors",
+			builder: konf.NewBuilder(
+				konf.WithCaseSensitive(),
+				konf.WithMapKeyCaseSensitive(),
+				konf.WithRestartRequired(""),
+			),
+			err: "konf.WithMapKeyCaseSensitive has no effect with konf.WithCaseSensitive: invalid options\n" +
+				"konf.WithRestartRequired has empty pattern: invalid options",
+		},
+		{
+			description: "auto reload loader not added with other errors",
+			builder: konf.NewBuilder(
+				konf.WithRestartRequired(""),
+				konf.WithAutoReload(time.Minute, mapLoader{}),
+			),
+			err: "konf.WithRestartRequired has empty pattern: invalid options\n" +
+				"konf.WithAutoReload has loader map which is not added to Builder: invalid options",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := testcase.builder.Build()
+			assert.EqualError(t, err, testcase.err)
+			assert.True(t, errors.Is(err, konf.ErrInvalidOptions))
+			assert.True(t, config == nil)
+		})
+	}
+}
+
+func TestBuilder_onChange(t *testing.T) {
+	t.Parallel()
+
+	watcher := mapWatcher{values: map[string]any{"config": "string"}, change: make(chan map[string]any)}
+	changes := make(chan string, 1)
+	config, err := konf.NewBuilder().
+		With(konf.WithGroupPolicy("heavy", konf.Concurrent())).
+		Loader(watcher).
+		OnChangeWith(func(config *konf.Config) {
+			changes <- configValue(t, config)
+		}, konf.Keys("config"), konf.Group("heavy")).
+		Build()
+	assert.NoError(t, err)
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"config": "changed"}
+	assert.Equal(t, "changed", <-changes)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import "strings"
+
+// Capabilities is the optional interfaces implemented by a Loader, which Config discovers dynamically.
+// It's returned by konf.CapabilitiesOf.
+type Capabilities struct {
+	Watcher          bool // The loader is a Watcher, which is watched by Config.Watch.
+	Statuser         bool // The loader is a Statuser, which reports its status via konf.WithOnStatus.
+	SourceIdentifier bool // The loader is a SourceIdentifier, which shares the watch of the same source.
+	SecretLoader     bool // The loader is a SecretLoader, whose values may be blurred regardless of their keys.
+}
+
+// CapabilitiesOf returns the optional interfaces implemented by the given loader, as Config discovers them.
+// Config.Load logs them at debug level for the loader which implements any of them.
+// It helps the test of provider, e.g. to assert the provider is seen as a Watcher:
+//
+//	if !konf.CapabilitiesOf(loader).Watcher {
+//		t.Error("provider is not a Watcher")
+//	}
+//
+// Prefer the compile-time assertion if the provider implements the interfaces statically:
+//
+//	var _ konf.Watcher = (*Provider)(nil)
+func CapabilitiesOf(loader Loader) Capabilities {
+	var capabilities Capabilities
+	_, capabilities.Watcher = loader.(Watcher)
+	_, capabilities.Statuser = loader.(Statuser)
+	_, capabilities.SourceIdentifier = loader.(SourceIdentifier)
+	_, capabilities.SecretLoader = loader.(SecretLoader)
+
+	return capabilities
+}
+
+// String returns the names of the implemented interfaces separated by `,`, or `none` if none is implemented.
+func (c Capabilities) String() string {
+	var names []string
+	if c.Watcher {
+		names = append(names, "Watcher")
+	}
+	if c.Statuser {
+		names = append(names, "Statuser")
+	}
+	if c.SourceIdentifier {
+		names = append(names, "SourceIdentifier")
+	}
+	if c.SecretLoader {
+		names = append(names, "SecretLoader")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+var (
+	_ konf.Watcher          = mapWatcher{}
+	_ konf.Statuser         = (*statusWatcher)(nil)
+	_ konf.SourceIdentifier = (*sourceWatcher)(nil)
+)
+
+func TestCapabilitiesOf(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		loader      konf.Loader
+		expected    konf.Capabilities
+		str         string
+	}{
+		{
+			description: "loader",
+			loader:      mapLoader{},
+			str:         "none",
+		},
+		{
+			description: "watcher",
+			loader:      mapWatcher{},
+			expected:    konf.Capabilities{Watcher: true},
+			str:         "Watcher",
+		},
+		{
+			description: "statuser",
+			loader:      &statusWatcher{},
+			expected:    konf.Capabilities{Watcher: true, Statuser: true},
+			str:         "Watcher,Statuser",
+		},
+		{
+			description: "source identifier",
+			loader:      &sourceWatcher{},
+			expected:    konf.Capabilities{Watcher: true, SourceIdentifier: true},
+			str:         "Watcher,SourceIdentifier",
+		},
+		{
+			description: "secret loader",
+			loader:      secretLoader{},
+			expected:    konf.Capabilities{SecretLoader: true},
+			str:         "SecretLoader",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			capabilities := konf.CapabilitiesOf(testcase.loader)
+			assert.Equal(t, testcase.expected, capabilities)
+			assert.Equal(t, testcase.str, capabilities.String())
+		})
+	}
+}
+
+func TestConfig_Load_capabilities(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithLogHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	assert.NoError(t, config.Load(mapLoader{}))
+	assert.NoError(t, config.Load(&statusWatcher{}))
+	assert.True(t, strings.Contains(buf.String(),
+		`level=DEBUG msg="Loader has optional capabilities." loader=status capabilities=Watcher,Statuser`,
+	))
+	assert.True(t, !strings.Contains(buf.String(), "loader=map"))
+}
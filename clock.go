@@ -0,0 +1,35 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"time"
+
+	"github.com/nil-go/konf/internal/clock"
+)
+
+// Clock is the interface that provides the time for time-based behaviors,
+// e.g. time of ChangeEvent and warning of slow onChange callbacks.
+//
+// Now returns the current time.
+// NewTimer creates a timer which sends the time on the returned channel once after the given duration,
+// with the function stops the timer and reports whether the timer was stopped before it fired.
+// NewTicker creates a ticker which sends the time on the returned channel after each interval,
+// with the function stops the ticker.
+//
+// It only uses built-in types so that the same implementation can be provided
+// to the providers which poll configuration, e.g. s3.WithClock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+func (c *Config) timeSource() Clock {
+	if c.clock == nil { // To support zero Config
+		return clock.Real{}
+	}
+
+	return c.clock
+}
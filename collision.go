@@ -0,0 +1,160 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Collision is a path whose value is provided by more than one loader.
+// It never contains any configuration value.
+type Collision struct {
+	Path string
+	// Winner is the loader whose value takes effect.
+	Winner Loader
+	// Shadowed are the loaders whose values are overridden, from the higher to the lower precedence.
+	Shadowed []Loader
+}
+
+// Collisions returns all paths whose values are provided by more than one loader, sorted by path.
+// The paths under the prefixes allowed by konf.WithCollisionReport are excluded.
+//
+// This method is concurrent-safe.
+func (c *Config) Collisions() []Collision {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Collisions()
+	}
+
+	var collisions []Collision
+	values, _ := c.providers.sub(nil).(map[string]any)
+	c.walk("", values, func(path string) {
+		if c.collisionAllowed(path) {
+			return
+		}
+		loaders := c.provenance(path)
+		if len(loaders) <= 1 {
+			return
+		}
+		collision := Collision{Path: path, Winner: loaders[0].loader}
+		for _, loader := range loaders[1:] {
+			collision.Shadowed = append(collision.Shadowed, loader.loader)
+		}
+		collisions = append(collisions, collision)
+	})
+	slices.SortFunc(collisions, func(a, b Collision) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return collisions
+}
+
+func (c *Config) collisionAllowed(path string) bool {
+	for _, prefix := range c.collisionAllowPrefixes {
+		if !c.caseSensitive {
+			prefix = defaultKeyMap(prefix)
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+c.delim()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reportCollisions reports collisions via the callback provided by konf.WithCollisionReport.
+func (c *Config) reportCollisions(ctx context.Context) {
+	if !c.collisionReport {
+		return
+	}
+
+	collisions := c.Collisions()
+	if c.onCollisions != nil {
+		c.onCollisions(collisions)
+
+		return
+	}
+	for _, collision := range collisions {
+		c.log(ctx, slog.LevelWarn,
+			"Configuration is provided by more than one loader.",
+			slog.String("path", collision.Path),
+			slog.Any("loader", collision.Winner),
+			slog.Any("shadowed", collision.Shadowed),
+		)
+	}
+}
+
+// warnShadows logs each path whose value from lower precedence loader is overridden by a different value
+// while konf.WithShadowWarnings is set.
+func (c *Config) warnShadows(ctx context.Context) {
+	if !c.shadowWarnings {
+		return
+	}
+	if logger := c.logger; logger != nil && !logger.Enabled(ctx, slog.LevelDebug) {
+		return // Skip traversing all values if the log is not enabled.
+	}
+
+	values, _ := c.providers.sub(nil).(map[string]any)
+	c.walk("", values, func(path string) {
+		loaders := c.provenance(path)
+		if len(loaders) <= 1 {
+			return
+		}
+		winner := loaders[0]
+		for _, loader := range loaders[1:] {
+			if reflect.DeepEqual(winner.value, loader.value) {
+				continue
+			}
+			c.log(ctx, slog.LevelDebug,
+				"Configuration is shadowed by the loader with higher precedence.",
+				slog.String("path", path),
+				slog.Any("loader", winner.loader),
+				slog.String("value", c.blur(path, winner.value)),
+				slog.Any("shadowed", loader.loader),
+				slog.String("shadowed_value", c.blur(path, loader.value)),
+			)
+		}
+	})
+}
+
+// explainResolved explains the value at the given path which is resolved by the resolver
+// provided by konf.WithConflictResolver, so it's synthesized from the values of loaders.
+func (c *Config) explainResolved(
+	explanation *strings.Builder, path string, value any, loaders []loaderValue,
+	writeValue func(string, loaderValue),
+) {
+	explanation.WriteString(path)
+	explanation.WriteString(" has value[")
+	explanation.WriteString(c.blur(path, value))
+	explanation.WriteString("] that is resolved from ")
+	for index, loader := range loaders {
+		switch {
+		case index == 0:
+		case index == len(loaders)-1:
+			explanation.WriteString(" and ")
+		default:
+			explanation.WriteString(", ")
+		}
+		explanation.WriteString("loader[")
+		explanation.WriteString(fmt.Sprintf("%v", loader.loader))
+		explanation.WriteString("]")
+	}
+	explanation.WriteString(".\nHere are the value(loader)s it's resolved from:\n")
+	for _, loader := range loaders {
+		explanation.WriteString("  - ")
+		writeValue(path, loader)
+		explanation.WriteString("(")
+		explanation.WriteString(fmt.Sprintf("%v", loader.loader))
+		explanation.WriteString(")\n")
+	}
+	explanation.WriteString("\n")
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Collisions(t *testing.T) {
+	t.Parallel()
+
+	first := mapLoader{"server": map[string]any{"host": "first", "port": 8080}, "feature": map[string]any{"a": true}}
+	second := mapLoader{"server": map[string]any{"host": "second"}, "Feature": map[string]any{"a": false}}
+	third := mapLoader{"server": map[string]any{"host": "third", "tls": true}}
+
+	testcases := []struct {
+		description   string
+		allowPrefixes []string
+		expected      []konf.Collision
+	}{
+		{
+			description: "no allow prefixes",
+			expected: []konf.Collision{
+				{Path: "feature.a", Winner: second, Shadowed: []konf.Loader{first}},
+				{Path: "server.host", Winner: third, Shadowed: []konf.Loader{second, first}},
+			},
+		},
+		{
+			description:   "with allow prefixes",
+			allowPrefixes: []string{"FEATURE", "serv"},
+			expected: []konf.Collision{
+				{Path: "server.host", Winner: third, Shadowed: []konf.Loader{second, first}},
+			},
+		},
+		{
+			description:   "all allowed",
+			allowPrefixes: []string{"feature", "server.host"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(konf.WithCollisionReport(nil, testcase.allowPrefixes...))
+			assert.NoError(t, config.Load(first))
+			assert.NoError(t, config.Load(second))
+			assert.NoError(t, config.Load(third))
+			assert.Equal(t, testcase.expected, config.Collisions())
+		})
+	}
+}
+
+func TestConfig_Collisions_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, nil, config.Collisions())
+}
+
+func TestConfig_Watch_collision_report(t *testing.T) {
+	t.Parallel()
+
+	first, second := mapLoader{"config": "first"}, mapLoader{"config": "second"}
+	testcases := []struct {
+		description string
+		report      func(chan<- []konf.Collision) func([]konf.Collision)
+		expected    string
+	}{
+		{
+			description: "callback",
+			report: func(reported chan<- []konf.Collision) func([]konf.Collision) {
+				return func(collisions []konf.Collision) { reported <- collisions }
+			},
+		},
+		{
+			description: "log",
+			report:      func(chan<- []konf.Collision) func([]konf.Collision) { return nil },
+			expected: `level=WARN msg="Configuration is provided by more than one loader."` +
+				` path=config loader=map shadowed=[map]` + "\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &buffer{}
+			reported := make(chan []konf.Collision, 1)
+			config := konf.New(
+				konf.WithCollisionReport(testcase.report(reported)),
+				konf.WithLogHandler(logHandler(buf)),
+			)
+			assert.NoError(t, config.Load(first))
+			assert.NoError(t, config.Load(second))
+
+			stopped := make(chan struct{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer func() {
+				cancel()
+				<-stopped
+			}()
+			go func() {
+				defer close(stopped)
+				assert.NoError(t, config.Watch(ctx))
+			}()
+			waitWatch(t, config)
+
+			if testcase.expected == "" {
+				assert.Equal(t, []konf.Collision{{Path: "config", Winner: second, Shadowed: []konf.Loader{first}}}, <-reported)
+			}
+			assert.Equal(t, testcase.expected, buf.String())
+		})
+	}
+}
+
+func TestConfig_shadow_warnings(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(
+		konf.WithShadowWarnings(),
+		konf.WithLogHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return attr
+			},
+		})),
+	)
+	assert.NoError(t, config.Load(mapLoader{"host": "first", "port": 8080, "password": "first"}))
+	assert.NoError(t, config.Load(mapLoader{"port": 8080}))
+	assert.Equal(t, "", buf.String())
+	assert.NoError(t, config.Load(mapLoader{"host": "second", "password": "second"}))
+
+	expected := `level=DEBUG msg="Configuration is shadowed by the loader with higher precedence."` +
+		` path=host loader=map value=second shadowed=map shadowed_value=first` + "\n" +
+		`level=DEBUG msg="Configuration is shadowed by the loader with higher precedence."` +
+		` path=password loader=map value=****** shadowed=map shadowed_value=******` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestConfig_ConflictResolver(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex     sync.Mutex
+		conflicts []string
+	)
+	config := konf.New(konf.WithConflictResolver(
+		func(path string, lower, higher any, lowerLoader, higherLoader string) any {
+			mutex.Lock()
+			conflicts = append(conflicts, path+":"+lowerLoader+"<"+higherLoader)
+			mutex.Unlock()
+
+			switch path {
+			case "limit.rate":
+				return max(lower.(int), higher.(int))
+			case "allowlist":
+				return append(slices.Clip(lower.([]any)), higher.([]any)...)
+			default:
+				return higher
+			}
+		},
+	))
+	assert.NoError(t, config.Load(mapLoader{
+		"limit":     map[string]any{"rate": 10},
+		"allowlist": []any{"a"},
+		"name":      "map",
+	}))
+	watcher := mapWatcher{
+		values: map[string]any{
+			"limit":     map[string]any{"rate": 5},
+			"allowlist": []any{"b"},
+			"name":      "watcher",
+		},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+	assert.Equal(t, []string{"allowlist:map<map", "limit.rate:map<map", "name:map<map"}, sorted(conflicts))
+
+	type Config struct {
+		Limit     struct{ Rate int }
+		Allowlist []string
+		Name      string
+	}
+	var value Config
+	assert.NoError(t, config.Unmarshal("", &value))
+	assert.Equal(t, 10, value.Limit.Rate)
+	assert.Equal(t, []string{"a", "b"}, value.Allowlist)
+	assert.Equal(t, "watcher", value.Name)
+	assert.Equal(t, `limit.rate has value[10] that is resolved from loader[map] and loader[map].
+Here are the value(loader)s it's resolved from:
+  - 5(map)
+  - 10(map)
+
+`, config.Explain("limit.rate"))
+	assert.Equal(t, `name has value[watcher] that is loaded by loader[map].
+Here are other value(loader)s:
+  - map(map)
+
+`, config.Explain("name"))
+
+	changes := make(chan Config)
+	config.OnChange(func(config *konf.Config) {
+		var value Config
+		assert.NoError(t, config.Unmarshal("", &value))
+		changes <- value
+	})
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	// The changes are resolved the same as loading.
+	watcher.change <- map[string]any{"limit": map[string]any{"rate": 20}, "allowlist": []any{"c"}}
+	value = <-changes
+	assert.Equal(t, 20, value.Limit.Rate)
+	assert.Equal(t, []string{"a", "c"}, value.Allowlist)
+	assert.Equal(t, "map", value.Name)
+}
+
+func sorted(values []string) []string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+
+	return values
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+type (
+	// ComputedOption configures konf.WithComputed with specific options.
+	ComputedOption func(*computed)
+
+	computed struct {
+		path        string
+		deps        []string
+		compute     func(*Config) (any, error)
+		overridable bool
+
+		keys    []string
+		depKeys [][]string
+		cache   atomic.Pointer[computedValue]
+	}
+	computedValue struct {
+		deps  []any // The values of dependencies when the value is computed.
+		value any
+	}
+)
+
+// Overridable allows the value of the computed key to be overridden by the value loaded by loaders.
+// Otherwise, the computed value takes precedence, and the loaded value is ignored with warning.
+func Overridable() ComputedOption {
+	return func(computed *computed) {
+		computed.overridable = true
+	}
+}
+
+// computedLoader is the Loader shown in Config.Explain for the values of computed keys.
+type computedLoader struct{}
+
+func (computedLoader) Load() (map[string]any, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (computedLoader) String() string {
+	return "computed"
+}
+
+// registerComputeds splits the paths of computed keys provided by konf.WithComputed,
+// and returns the error wrapping ErrInvalidOptions for each computed key
+// which has cyclic dependencies, empty path or nil function.
+func (c *Config) registerComputeds() error {
+	computeds := c.computeds
+	c.computeds = make([]*computed, 0, len(computeds))
+	var errs []error
+	for _, computed := range computeds {
+		invalid := computed.compute == nil || strings.Trim(computed.path, c.delim()) == ""
+		if !invalid {
+			computed.keys = c.splitPath(computed.path)
+			computed.depKeys = make([][]string, 0, len(computed.deps))
+			for _, dep := range computed.deps {
+				computed.depKeys = append(computed.depKeys, c.splitPath(dep))
+			}
+			invalid = c.dependsOn(computed, computed, nil)
+		}
+		if invalid {
+			errs = append(errs, fmt.Errorf("konf.WithComputed(%s) has cyclic dependencies, empty path or nil function: %w",
+				computed.path, ErrInvalidOptions))
+
+			continue
+		}
+		c.computeds = append(c.computeds, computed)
+	}
+
+	return errors.Join(errs...)
+}
+
+// dependsOn reports whether the given computed key depends on the target, directly or via other computed keys.
+func (c *Config) dependsOn(computed, target *computed, visited []*computed) bool {
+	for _, dep := range computed.depKeys {
+		if overlaps(dep, target.keys) {
+			return true
+		}
+		for _, next := range c.computeds {
+			if overlaps(dep, next.keys) && !slices.Contains(visited, next) &&
+				c.dependsOn(next, target, append(visited, next)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sub returns the value for the given path, including the values of computed keys.
+func (c *Config) sub(keys []string) (any, error) {
+	return c.subIn(c.providers.snapshot(), keys)
+}
+
+// subIn returns the value for the given path in the given snapshot, including the values of computed keys,
+// whose dependencies are also read from the snapshot.
+func (c *Config) subIn(snapshot map[string]any, keys []string) (any, error) {
+	var value any
+	if snapshot != nil { // To support zero Config
+		value = maps.Sub(snapshot, keys)
+	}
+	for _, computed := range c.computeds {
+		if !overlaps(keys, computed.keys) {
+			continue
+		}
+		if computed.overridable && maps.Sub(snapshot, computed.keys) != nil {
+			continue
+		}
+		computedValue, err := c.computedValue(snapshot, computed)
+		if err != nil {
+			return nil, err
+		}
+		if computedValue == nil {
+			continue
+		}
+		if len(keys) >= len(computed.keys) {
+			// The path is the computed key or under it.
+			if len(keys) == len(computed.keys) {
+				return computedValue, nil
+			}
+			values, _ := computedValue.(map[string]any)
+
+			return maps.Sub(values, keys[len(computed.keys):]), nil
+		}
+		// The computed key is under the path.
+		value = withValue(value, computed.keys[len(keys):], computedValue)
+	}
+
+	return value, nil
+}
+
+// computedValue returns the value of the computed key, which is cached until its dependencies change.
+func (c *Config) computedValue(snapshot map[string]any, computed *computed) (any, error) {
+	deps := make([]any, 0, len(computed.depKeys))
+	for _, dep := range computed.depKeys {
+		value, err := c.subIn(snapshot, dep)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, value)
+	}
+	if cached := computed.cache.Load(); cached != nil && slices.EqualFunc(cached.deps, deps, maps.Equal) {
+		return cached.value, nil
+	}
+
+	value, err := computed.compute(c)
+	if err != nil {
+		return nil, fmt.Errorf("compute %s: %w", computed.path, err)
+	}
+	if values, ok := value.(map[string]any); ok {
+		c.transformKeys(values)
+	}
+	if !computed.overridable && maps.Sub(snapshot, computed.keys) != nil {
+		c.log(context.Background(), slog.LevelWarn,
+			"Computed key is also loaded by loader, the loaded value has been ignored.",
+			slog.String("path", computed.path),
+		)
+	}
+	computed.cache.Store(&computedValue{deps: deps, value: value})
+
+	return value, nil
+}
+
+// computedAt returns the value of the computed key for the given path, if the computed key takes effect.
+func (c *Config) computedAt(keys []string) (any, bool) {
+	for _, computed := range c.computeds {
+		if len(keys) < len(computed.keys) || !overlaps(keys, computed.keys) {
+			continue
+		}
+		if computed.overridable && c.providers.sub(computed.keys) != nil {
+			return nil, false
+		}
+		value, err := c.sub(keys)
+		if err != nil || value == nil {
+			return nil, false
+		}
+
+		return value, true
+	}
+
+	return nil, false
+}
+
+// changedComputeds returns the paths of computed keys whose dependencies have changed between the given values.
+func (c *Config) changedComputeds(oldValues, newValues map[string]any) [][]string {
+	var changed [][]string
+	for {
+		found := false
+		for _, computed := range c.computeds {
+			if slices.ContainsFunc(changed, func(keys []string) bool { return slices.Equal(keys, computed.keys) }) {
+				continue
+			}
+			for _, dep := range computed.depKeys {
+				if !maps.Equal(maps.Sub(oldValues, dep), maps.Sub(newValues, dep)) ||
+					slices.ContainsFunc(changed, func(keys []string) bool { return overlaps(keys, dep) }) {
+					changed = append(changed, computed.keys)
+					found = true
+
+					break
+				}
+			}
+		}
+		if !found {
+			return changed
+		}
+	}
+}
+
+// overlaps reports whether one of the given paths is the prefix of the other.
+func overlaps(a, b []string) bool {
+	n := min(len(a), len(b))
+
+	return slices.Equal(a[:n], b[:n])
+}
+
+// withValue returns the copy of the given value with the value set at the given keys.
+// The given value is immutable, so it only copies the maps along the keys.
+func withValue(base any, keys []string, value any) any {
+	if len(keys) == 0 {
+		return value
+	}
+
+	_, base = maps.Unpack(base)
+	values, _ := base.(map[string]any)
+	copied := make(map[string]any, len(values)+1)
+	for key, val := range values {
+		copied[key] = val
+	}
+	copied[keys[0]] = withValue(copied[keys[0]], keys[1:], value)
+
+	return copied
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestWithComputed(t *testing.T) {
+	t.Parallel()
+
+	var computes atomic.Int32
+	config := konf.New(konf.WithComputed("worker.count", []string{"worker.factor"}, func(config *konf.Config) (any, error) {
+		computes.Add(1)
+		var factor int
+		if err := config.Unmarshal("worker.factor", &factor); err != nil {
+			return nil, err
+		}
+
+		return 4 * factor, nil
+	}))
+	assert.NoError(t, config.Load(mapLoader{"worker": map[string]any{"factor": 2, "name": "worker"}}))
+	assert.Equal(t, int32(0), computes.Load()) // Computed on demand.
+
+	var count int
+	assert.NoError(t, config.Unmarshal("worker.count", &count))
+	assert.Equal(t, 8, count)
+	var worker map[string]any
+	assert.NoError(t, config.Unmarshal("worker", &worker))
+	assert.Equal(t, map[string]any{"factor": 2, "name": "worker", "count": 8}, worker)
+	assert.True(t, config.Exists([]string{"worker", "count"}))
+	assert.Equal(t, int32(1), computes.Load()) // Cached.
+
+	expected := `worker.count has value[8] that is loaded by loader[computed].
+
+`
+	assert.Equal(t, expected, config.Explain("worker.count"))
+
+	// The change of dependency invalidates the cache.
+	assert.NoError(t, config.Load(mapLoader{"worker": map[string]any{"factor": 3}}))
+	assert.NoError(t, config.Unmarshal("worker.count", &count))
+	assert.Equal(t, 12, count)
+	assert.Equal(t, int32(2), computes.Load())
+	// The change of other keys does not.
+	assert.NoError(t, config.Load(mapLoader{"worker": map[string]any{"name": "other"}}))
+	assert.NoError(t, config.Unmarshal("worker.count", &count))
+	assert.Equal(t, int32(2), computes.Load())
+}
+
+func TestWithComputed_readers(t *testing.T) {
+	t.Parallel()
+
+	constant := func(value any) func(*konf.Config) (any, error) {
+		return func(*konf.Config) (any, error) { return value, nil }
+	}
+	config := konf.New(
+		konf.WithComputed("server.port", nil, constant(8080)),
+		konf.WithComputed("tenants.acme.server.port", nil, constant(9090)),
+		konf.WithComputed("servers", nil, constant(map[string]any{"a": map[string]any{"port": 1}})),
+		konf.WithComputed("db.password", nil, constant("computed")),
+	)
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "localhost"}}))
+
+	t.Run("UnmarshalFirst", func(t *testing.T) {
+		t.Parallel()
+
+		var port int
+		path, err := config.UnmarshalFirst([]string{"http.port", "server.port"}, &port)
+		assert.NoError(t, err)
+		assert.Equal(t, "server.port", path)
+		assert.Equal(t, 8080, port)
+		path, ok := config.ExistsAny("http.port", "server.port")
+		assert.True(t, ok)
+		assert.Equal(t, "server.port", path)
+	})
+	t.Run("UnmarshalFor", func(t *testing.T) {
+		t.Parallel()
+
+		var server map[string]any
+		assert.NoError(t, config.UnmarshalFor("acme", "server", &server))
+		assert.Equal(t, map[string]any{"host": "localhost", "port": 9090}, server)
+	})
+	t.Run("Namespace", func(t *testing.T) {
+		t.Parallel()
+
+		var server map[string]any
+		namespace := konf.Namespace(config, "tenants.acme", konf.FallbackTo(""))
+		assert.NoError(t, namespace.Unmarshal("server", &server))
+		assert.Equal(t, map[string]any{"host": "localhost", "port": 9090}, server)
+		level, ok := namespace.Level("server.port")
+		assert.True(t, ok)
+		assert.Equal(t, "tenants.acme", level)
+	})
+	t.Run("UnmarshalKeyed", func(t *testing.T) {
+		t.Parallel()
+
+		var servers []keyedServer
+		assert.NoError(t, config.UnmarshalKeyed("servers", &servers))
+		assert.Equal(t, []keyedServer{{Name: "a", Port: 1}}, servers)
+	})
+	t.Run("RevealSecret", func(t *testing.T) {
+		t.Parallel()
+
+		password, err := config.RevealSecret("db.password", "test")
+		assert.NoError(t, err)
+		assert.Equal(t, "computed", password)
+	})
+	t.Run("KeyInfo", func(t *testing.T) {
+		t.Parallel()
+
+		info := config.KeyInfo("server.port")
+		assert.Equal(t, 8080, info.Value)
+		assert.Equal(t, "computed", fmt.Sprint(info.Loader))
+	})
+}
+
+func TestWithComputed_override(t *testing.T) {
+	t.Parallel()
+
+	compute := func(*konf.Config) (any, error) { return 8, nil }
+	testcases := []struct {
+		description string
+		opts        []konf.ComputedOption
+		expected    int
+		explanation string
+		log         string
+	}{
+		{
+			description: "not overridable",
+			expected:    8,
+			explanation: `worker.count has value[8] that is loaded by loader[computed].
+Here are other value(loader)s:
+  - 2(map)
+
+`,
+			log: `level=WARN msg="Computed key is also loaded by loader, the loaded value has been ignored." path=worker.count`,
+		},
+		{
+			description: "overridable",
+			opts:        []konf.ComputedOption{konf.Overridable()},
+			expected:    2,
+			explanation: `worker.count has value[2] that is loaded by loader[map].
+
+`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &buffer{}
+			config := konf.New(
+				konf.WithLogHandler(logHandler(buf)),
+				konf.WithComputed("worker.count", nil, compute, testcase.opts...),
+			)
+			assert.NoError(t, config.Load(mapLoader{"worker": map[string]any{"count": 2}}))
+
+			var count int
+			assert.NoError(t, config.Unmarshal("worker.count", &count))
+			assert.Equal(t, testcase.expected, count)
+			assert.Equal(t, testcase.explanation, config.Explain("worker"))
+			if testcase.log != "" {
+				assert.True(t, strings.Contains(buf.String(), testcase.log))
+			}
+		})
+	}
+}
+
+func TestWithComputed_error(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithComputed("worker.count", nil, func(*konf.Config) (any, error) {
+		return nil, errors.New("compute error")
+	}))
+
+	var count int
+	assert.EqualError(t, config.Unmarshal("worker", &count), "compute worker.count: compute error")
+	assert.True(t, !config.Exists([]string{"worker", "count"}))
+}
+
+func TestWithComputed_cycle(t *testing.T) {
+	t.Parallel()
+
+	compute := func(value int) func(*konf.Config) (any, error) {
+		return func(*konf.Config) (any, error) { return value, nil }
+	}
+	opts := []konf.Option{
+		konf.WithComputed("a", []string{"b"}, compute(1)),
+		konf.WithComputed("b", []string{"c"}, compute(2)),
+		konf.WithComputed("c", []string{"a"}, compute(3)),
+		konf.WithComputed("d", []string{"d.e"}, compute(4)),
+	}
+	expected := "konf.WithComputed(c) has cyclic dependencies, empty path or nil function: invalid options\n" +
+		"konf.WithComputed(d) has cyclic dependencies, empty path or nil function: invalid options"
+
+	// konf.New ignores the invalid computed keys with a warning log.
+	buf := &buffer{}
+	config := konf.New(append(opts, konf.WithLogHandler(logHandler(buf)))...)
+	assert.True(t, strings.HasPrefix(buf.String(), `level=WARN msg="Invalid options have been ignored."`))
+	assert.True(t, strings.Contains(buf.String(), "konf.WithComputed(d) has cyclic dependencies"))
+	assert.True(t, !config.Exists([]string{"c"}))
+	assert.True(t, !config.Exists([]string{"d"}))
+	var a int
+	assert.NoError(t, config.Unmarshal("a", &a))
+	assert.Equal(t, 1, a)
+
+	_, err := konf.NewBuilder(opts...).Build()
+	assert.EqualError(t, err, expected)
+	assert.True(t, errors.Is(err, konf.ErrInvalidOptions))
+}
+
+func TestWithComputed_onChange(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithComputed("worker.count", []string{"worker.factor"}, func(config *konf.Config) (any, error) {
+		var factor int
+		if err := config.Unmarshal("worker.factor", &factor); err != nil {
+			return nil, err
+		}
+
+		return 4 * factor, nil
+	}))
+	watcher := mapWatcher{values: map[string]any{"worker": map[string]any{"factor": 1}}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	counts := make(chan int)
+	config.OnChange(func(config *konf.Config) {
+		var count int
+		assert.NoError(t, config.Unmarshal("worker.count", &count))
+		counts <- count
+	}, "worker.count")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"worker": map[string]any{"factor": 2}}
+	assert.Equal(t, 8, <-counts)
+}
@@ -6,9 +6,17 @@ package konf
 import (
 	"context"
 	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"net/url"
+	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -16,7 +24,6 @@ import (
 
 	"github.com/nil-go/konf/internal"
 	"github.com/nil-go/konf/internal/convert"
-	"github.com/nil-go/konf/internal/credential"
 	"github.com/nil-go/konf/internal/maps"
 )
 
@@ -31,25 +38,89 @@ type Config struct {
 	mapKeyCaseSensitive bool
 	delimiter           string
 	logger              *slog.Logger
+	auditLogger         *slog.Logger
 	onStatus            func(loader Loader, changed bool, err error)
 	converter           *convert.Converter
+	strictLifecycle     bool
+	captureCaller       bool
+	quietChanges        bool
+	clock               Clock
+	tagValidation       bool
+	strictUnmarshal     bool
+	acceptEmpty         bool
+	validators          map[string]func(value any, param string) error
+	exclusives          [][]string
+	groupPolicies       map[string]groupPolicy
+	changeQueueSize     int
+	maxWatchers         int
+	startupSummary      bool
+	keyInfo             bool
+	autoReloads         []autoReload
+	flapDetection       *flapOptions
+	atomicGroups        []*atomicGroup
+	hooks               *Hooks
+	finalSnapshot       *finalSnapshot
+	computeds           []*computed
+	conflictResolver    func(path string, lower, higher any, lowerLoader, higherLoader string) any
 
-	providers providers
-	onChanges onChanges
-	watched   atomic.Pointer[func(*provider)]
+	collisionReport        bool
+	onCollisions           func([]Collision)
+	collisionAllowPrefixes []string
+	orderAudit             bool
+	onOrderDependencies    func([]OrderDependency)
+	shadowWarnings         bool
+	replaceMarker          string
+	normalizers            []func(path string, value any) any
+
+	providers  providers
+	onChanges  onChanges
+	watched    atomic.Pointer[watching]
+	version    atomic.Uint64
+	lastChange atomic.Pointer[ChangeEvent]
+	keyChanges keyChanges // Only for konf.WithKeyInfo.
+	flaps      flaps      // Only for konf.WithFlapDetection.
+	watchers   watchers
+	sources    sources
+	// Serializes the validation of changes and storing them, see Config.validateAndStore.
+	applyMutex sync.Mutex
+	// The paths reported by konf.WithOrderAudit.
+	orderReports orderReports
+
+	temporaries temporaries
+
+	restartRequired []string
+	restart         restart
+
+	schema schema
+
+	// Only for the view created by Config.Sub.
+	parent *Config
+	prefix string
 }
 
 // New creates a new Config with the given Option(s).
+//
+// For compatibility, the invalid Option(s) are ignored with a warning log, e.g. konf.WithComputed
+// with cyclic dependencies, or konf.WithDefaults violating konf.WithMutuallyExclusive.
+// Use konf.Builder to get the error instead.
 func New(opts ...Option) *Config {
+	config, err := newConfig(opts)
+	if err != nil {
+		config.log(context.Background(), slog.LevelWarn, "Invalid options have been ignored.", slog.Any("error", err))
+	}
+
+	return config
+}
+
+func newConfig(opts []Option) (*Config, error) {
 	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
 
 	// Build converter from options.
-	if len(option.convertOpts) == 0 {
-		option.convertOpts = defaultHooks
-	}
+	// The hooks provided by konf.WithDecodeHook take precedence over the default hooks.
+	option.convertOpts = append(option.convertOpts, defaultHooks...)
 	if option.tagName == "" {
 		option.tagName = defaultTagName
 	}
@@ -57,24 +128,117 @@ func New(opts ...Option) *Config {
 	if !option.caseSensitive {
 		option.convertOpts = append(option.convertOpts, convert.WithKeyMapper(defaultKeyMap))
 	}
+	option.convertOpts = append(option.convertOpts, convert.WithImplementation(option.implement))
 	option.converter = convert.New(option.convertOpts...)
 
-	return &(option.Config)
+	for _, key := range option.replaceKeys {
+		option.providers.replaceKeys = append(option.providers.replaceKeys, option.splitPath(key))
+	}
+	if option.hooks != nil && option.hooks.OnSnapshotRebuild != nil {
+		config := &option.Config
+		option.providers.onRebuild = func(stats SnapshotStats) {
+			config.callHook(context.Background(), "OnSnapshotRebuild", func(hooks Hooks) { hooks.OnSnapshotRebuild(stats) })
+		}
+	}
+	if resolver := option.conflictResolver; resolver != nil {
+		delim := option.delim()
+		option.providers.resolve = func(path []string, lower, higher any, lowerLoader, higherLoader Loader) any {
+			return resolver(strings.Join(path, delim), lower, higher, fmt.Sprint(lowerLoader), fmt.Sprint(higherLoader))
+		}
+	}
+	if !option.caseSensitive {
+		option.replaceMarker = defaultKeyMap(option.replaceMarker)
+	}
+	var errs []error
+	if err := option.registerComputeds(); err != nil {
+		errs = append(errs, err) // The invalid computed keys are dropped.
+	}
+	for _, values := range option.defaults {
+		// The defaults loader always succeeds, but the values may violate konf.WithMutuallyExclusive,
+		// and they are dropped then.
+		if err := option.Load(Defaults(values)); err != nil {
+			errs = append(errs, fmt.Errorf("konf.WithDefaults has invalid values: %w: %w", err, ErrInvalidOptions))
+		}
+	}
+
+	return &(option.Config), errors.Join(errs...)
 }
 
 // Load loads configuration from the given loader.
-// Each loader takes precedence over the loaders before it.
+// Each loader takes precedence over the loaders before it,
+// except the loader returned by konf.Defaults which has the lowest precedence.
+//
+// If Config.Watch is running, the values of the loader are merged immediately,
+// the callbacks registered by Config.OnChange are executed for the paths whose value has been changed,
+// and the loader is watched if it's a Watcher.
+//
+// If konf.Optional is set, the failure of the loader is logged and reported via Config.Status
+// instead of returned, see konf.Optional.
 //
 // This method is concurrent-safe.
-func (c *Config) Load(loader Loader) error {
+func (c *Config) Load(loader Loader, opts ...LoadOption) error {
 	if loader == nil {
 		return nil
 	}
 	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("load configuration: %w", errSubView)
+	}
+
+	if err := c.checkLifecycle(loader); err != nil {
+		return err
+	}
+
+	option := &loadOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	provider := c.newProvider(loader)
+	provider.optional = option.optional
+	if capabilities := CapabilitiesOf(loader); capabilities != (Capabilities{}) {
+		c.log(context.Background(), slog.LevelDebug,
+			"Loader has optional capabilities.",
+			slog.Any("loader", loader),
+			slog.Any("capabilities", capabilities),
+		)
+	}
+	// Load values into a new provider.
+	start := c.timeSource().Now()
+	values, err := loader.Load()
+	provider.duration = c.timeSource().Now().Sub(start)
+	if err == nil {
+		err = c.apply(provider, values)
+	}
+	if err != nil {
+		err = fmt.Errorf("load configuration: %w", err)
+		if !provider.optional {
+			return err
+		}
+		c.degrade(provider, err)
+
+		return nil
+	}
+	c.reportOrderDependencies(context.Background())
+
+	return nil
+}
+
+func (c *Config) checkLifecycle(loader Loader) error {
+	if c.strictLifecycle {
+		if watch := c.watched.Load(); watch != nil {
+			return LifecycleError{Loader: loader, WatchedAt: watch.caller}
+		}
+	}
+
+	return nil
+}
 
+func (c *Config) newProvider(loader Loader) *provider {
+	provider := &provider{loader: loader}
 	// Register status callback if the loader is a Statuser.
 	if statuser, ok := loader.(Statuser); ok {
 		statuser.Status(func(changed bool, err error) {
+			provider.status(err)
 			if err != nil {
 				c.log(context.Background(),
 					slog.LevelWarn,
@@ -89,19 +253,43 @@ func (c *Config) Load(loader Loader) error {
 		})
 	}
 
-	// Load values into a new provider.
-	values, err := loader.Load()
-	if err != nil {
-		return fmt.Errorf("load configuration: %w", err)
-	}
+	return provider
+}
+
+// apply stores the loaded values into the provider and adds it into the Config,
+// or returns error without applying if the values violate konf.WithMutuallyExclusive.
+func (c *Config) apply(provider *provider, values map[string]any) error {
 	c.transformKeys(values)
-	provider := c.providers.append(loader, values)
+	c.extractReplaceMarkers(provider, values)
+	c.applyMutex.Lock()
+	defer c.applyMutex.Unlock()
+	if len(c.exclusives) > 0 {
+		merged := values
+		if len(c.normalizers) > 0 {
+			merged = c.normalizeMap("", values)
+		}
+		if err := c.checkExclusive(c.providers.merged(provider, merged)); err != nil {
+			return err
+		}
+	}
+	if _, ok := provider.loader.(Watcher); ok {
+		if err := c.acquireWatcher(provider); err != nil {
+			return err
+		}
+	}
+	oldValues, newValues := c.store(provider, values)
+	if imported := c.providers.attach(provider); imported != nil {
+		oldValues = *imported.values.Load() // Diff against the imported layer it replaces.
+	}
+	c.warnShadows(context.Background())
 
-	if _, ok := loader.(Watcher); ok {
-		// Register watch callback if the loader is a Watcher and the watch is started.
-		// While Config.Watch is called, c.watched is set for registering the watch callback.
-		if watch := c.watched.Load(); watch != nil {
-			(*watch)(provider)
+	// While Config.Watch is called, c.watched is set for dispatching the change
+	// and registering the watch callback.
+	if watch := c.watched.Load(); watch != nil {
+		// Dispatch the change before watching so that it's ahead of the changes from the loader.
+		watch.notify(provider.loader, c.changedOnChanges(oldValues, newValues))
+		if _, ok := provider.loader.(Watcher); ok {
+			watch.provider(provider)
 		}
 	}
 
@@ -109,31 +297,71 @@ func (c *Config) Load(loader Loader) error {
 }
 
 // Unmarshal reads configuration under the given path from the Config
-// and decodes it into the given object pointed to by target.
+// and decodes it into the given object pointed to by target with the given UnmarshalOption(s),
+// e.g. konf.Fields to decode only some fields of the struct, or konf.Strict to reject unknown keys.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
-func (c *Config) Unmarshal(path string, target any) error {
+func (c *Config) Unmarshal(path string, target any, opts ...UnmarshalOption) error {
 	if c == nil { // To support nil
 		return nil
 	}
 	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Unmarshal(c.parentPath(path), target, opts...)
+	}
 
-	value := c.providers.sub(c.splitPath(path))
-	if value == nil {
-		return nil
+	option := &unmarshalOptions{strict: c.strictUnmarshal}
+	for _, opt := range opts {
+		opt(option)
+	}
+	value, err := c.sub(c.splitPath(path))
+	if err != nil {
+		return err
+	}
+	if len(option.fields) > 0 {
+		mask, e := c.fieldMask(target, option.fields)
+		if e != nil {
+			return e
+		}
+		value = mask.masked(value)
+	}
+	if option.strict {
+		if e := c.checkUnknownKeys(path, value, target); e != nil {
+			return fmt.Errorf("decode: %w", e)
+		}
 	}
 
-	converter := c.converter
-	if converter == nil { // To support zero Config
-		converter = defaultConverter
+	return c.decode(path, value, target)
+}
+
+// decode decodes the value into the given object pointed to by target,
+// and validates it if konf.WithTagValidation is set.
+func (c *Config) decode(path string, value any, target any) error {
+	if value != nil {
+		if err := c.decoder().ConvertAt(path, value, target); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
 	}
-	if err := converter.Convert(value, target); err != nil {
-		return fmt.Errorf("decode: %w", err)
+	if err := c.validateTags(path, target); err != nil {
+		return fmt.Errorf("validate: %w", err)
 	}
 
 	return nil
 }
 
+func (c *Config) decoder() *convert.Converter {
+	if c.converter == nil { // To support zero Config
+		return defaultConverter
+	}
+
+	return c.converter
+}
+
 func (c *Config) log(ctx context.Context, level slog.Level, message string, attrs ...slog.Attr) {
+	if c.parent != nil {
+		c.parent.log(ctx, level, message, attrs...)
+
+		return
+	}
 	logger := c.logger
 	if c.logger == nil { // To support zero Config
 		logger = slog.Default()
@@ -169,97 +397,261 @@ func (c *Config) transformKeys(m map[string]any) {
 // Explain provides information about how Config resolve each value
 // from loaders for the given path. It blur sensitive information.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
-func (c *Config) Explain(path string) string {
+//
+// The values are normalized by konf.WithValueNormalizer,
+// and the option RawValue also shows the raw values if they are different.
+// If the path is described as time.Duration or konf.ByteSize by Config.Describe,
+// it also shows the human-readable form of the values, e.g. `300000000000 (as time.Duration: 5m0s)`.
+func (c *Config) Explain(path string, opts ...ExplainOption) string {
 	if c == nil { // To support nil
 		return path + " has no configuration.\n\n"
 	}
 	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Explain(c.parentPath(path), opts...)
+	}
 
-	value := c.providers.sub(c.splitPath(path))
+	option := &explainOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	value, _ := c.sub(c.splitPath(path))
 	if value == nil {
 		return path + " has no configuration.\n\n"
 	}
 	explanation := &strings.Builder{}
-	c.explain(explanation, path, value)
+	c.explain(explanation, path, value, option.raw)
 
 	return explanation.String()
 }
 
-func (c *Config) explain(explanation *strings.Builder, path string, value any) {
+func (c *Config) explain(explanation *strings.Builder, path string, value any, raw bool) {
+	writeValue := func(path string, loader loaderValue) {
+		explanation.WriteString(c.blur(path, loader.value))
+		if typ, human := c.humanize(path, loader.value); human != "" {
+			explanation.WriteString(" (as " + typ + ": " + human + ")")
+		}
+		if raw && !reflect.DeepEqual(loader.value, loader.raw) {
+			explanation.WriteString(" (raw: ")
+			explanation.WriteString(c.blur(path, loader.raw))
+			explanation.WriteString(")")
+		}
+	}
+	c.walk(path, value, func(path string) {
+		loaders := c.provenance(path)
+		if len(loaders) == 0 {
+			explanation.WriteString(path)
+			explanation.WriteString(" has no configuration.\n\n")
+
+			return
+		}
+		if c.conflictResolver != nil && len(loaders) > 1 {
+			if value, _ := c.sub(c.splitPath(path)); !maps.Equal(value, loaders[0].value) {
+				c.explainResolved(explanation, path, value, loaders, writeValue)
+
+				return
+			}
+		}
+		explanation.WriteString(path)
+		explanation.WriteString(" has value[")
+		writeValue(path, loaders[0])
+		explanation.WriteString("] that is loaded by loader[")
+		explanation.WriteString(fmt.Sprintf("%v", loaders[0].loader))
+		explanation.WriteString("].\n")
+		if len(loaders) > 1 {
+			explanation.WriteString("Here are other value(loader)s:\n")
+			for _, loader := range loaders[1:] {
+				explanation.WriteString("  - ")
+				writeValue(path, loader)
+				explanation.WriteString("(")
+				explanation.WriteString(fmt.Sprintf("%v", loader.loader))
+				explanation.WriteString(")\n")
+			}
+		}
+		explanation.WriteString("\n")
+	})
+}
+
+type (
+	// ExplainOption configures Config.Explain with specific options.
+	ExplainOption  func(*explainOptions)
+	explainOptions struct {
+		raw bool
+	}
+)
+
+// RawValue shows the raw values before normalization by konf.WithValueNormalizer in Config.Explain,
+// if they are different from the normalized values.
+func RawValue() ExplainOption {
+	return func(options *explainOptions) {
+		options.raw = true
+	}
+}
+
+// walk calls the given function for the path of each leaf value in the given value, sorted by key.
+func (c *Config) walk(path string, value any, leaf func(path string)) {
+	_, value = maps.Unpack(value)
 	if values, ok := value.(map[string]any); ok {
-		for key, val := range values {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
 			newPath := path
 			if newPath != "" {
 				newPath += c.delim()
 			}
 			newPath += key
-			c.explain(explanation, newPath, val)
+			c.walk(newPath, values[key], leaf)
 		}
 
 		return
 	}
 
-	type loaderValue struct {
-		loader Loader
-		value  any
-	}
+	leaf(path)
+}
+
+type loaderValue struct {
+	loader Loader
+	value  any
+	raw    any // The value before normalization.
+}
+
+// provenance returns the loaders which provide value for the given path,
+// from the highest to the lowest precedence.
+func (c *Config) provenance(path string) []loaderValue {
 	var loaders []loaderValue
+	keys := c.splitPath(path)
 	c.providers.traverse(func(provider *provider) {
-		if v := maps.Sub(*provider.values.Load(), c.splitPath(path)); v != nil {
-			loaders = append(loaders, loaderValue{provider.loader, v})
+		if provider.disabled.Load() {
+			return
+		}
+		if c.providers.replacedAt(provider, keys) {
+			loaders = loaders[:0] // Values from lower precedence providers are replaced.
+		}
+		if v := maps.Sub(*provider.values.Load(), keys); v != nil {
+			raw := v
+			if values := provider.raw.Load(); values != nil {
+				raw = maps.Sub(*values, keys)
+			}
+			loaders = append(loaders, loaderValue{provider.loader, v, raw})
 		}
 	})
 	slices.Reverse(loaders)
-
-	if len(loaders) == 0 {
-		explanation.WriteString(path)
-		explanation.WriteString(" has no configuration.\n\n")
-
-		return
+	if value := c.providers.overridden(keys); value != nil {
+		loaders = append([]loaderValue{{c.temporaries.loaderAt(keys), value, value}}, loaders...)
 	}
-	explanation.WriteString(path)
-	explanation.WriteString(" has value[")
-	explanation.WriteString(credential.Blur(path, loaders[0].value))
-	explanation.WriteString("] that is loaded by loader[")
-	explanation.WriteString(fmt.Sprintf("%v", loaders[0].loader))
-	explanation.WriteString("].\n")
-	if len(loaders) > 1 {
-		explanation.WriteString("Here are other value(loader)s:\n")
-		for _, loader := range loaders[1:] {
-			explanation.WriteString("  - ")
-			explanation.WriteString(credential.Blur(path, loader.value))
-			explanation.WriteString("(")
-			explanation.WriteString(fmt.Sprintf("%v", loader.loader))
-			explanation.WriteString(")\n")
-		}
+	if value, ok := c.computedAt(keys); ok {
+		loaders = append([]loaderValue{{computedLoader{}, value, value}}, loaders...)
 	}
-	explanation.WriteString("\n")
+
+	return loaders
+}
+
+// LifecycleError is returned by Config.Load if the Config has been watched
+// while konf.WithStrictLifecycle is set.
+type LifecycleError struct {
+	Loader Loader
+	// WatchedAt is the location (file:line) where Config.Watch has been called.
+	WatchedAt string
+}
+
+func (e LifecycleError) Error() string {
+	return fmt.Sprintf("load configuration from %v after Config.Watch has been started at %s", e.Loader, e.WatchedAt)
 }
 
 type (
 	providers struct {
-		providers []*provider
-		values    atomic.Pointer[map[string]any]
-		mutex     sync.RWMutex
+		providers   []*provider
+		values      atomic.Pointer[map[string]any]
+		mutex       sync.RWMutex
+		replaceKeys [][]string     // Only for konf.WithReplaceKeys.
+		overrides   map[string]any // Only for Config.SetTemporary.
+		// Only for konf.WithConflictResolver.
+		resolve func(path []string, lower, higher any, lowerLoader, higherLoader Loader) any
+
+		// Counters of the merged snapshot, reported by Config.DebugState.
+		// The hits are only counted with konf.WithSnapshotStats since it's contended on every read.
+		countHits bool
+		hits      atomic.Uint64
+		rebuilds  atomic.Uint64
+		onRebuild func(stats SnapshotStats) // Only for Hooks.OnSnapshotRebuild.
 	}
 	provider struct {
-		loader  Loader
-		values  atomic.Pointer[map[string]any]
-		watched atomic.Bool
+		loader Loader
+		values atomic.Pointer[map[string]any]
+		raw    atomic.Pointer[map[string]any] // Only for konf.WithValueNormalizer.
+		// The paths of maps with the replace marker, only for konf.WithReplaceMarker.
+		replaced atomic.Pointer[[][]string]
+		watched  atomic.Bool
+		lastErr  atomic.Pointer[error]
+		duration time.Duration             // The duration of loading, only for the summary.
+		updated  atomic.Pointer[time.Time] // The time of the last loading or change, only for Config.KeyInfo.
+
+		// Only for Watcher, see Config.acquireWatcher.
+		refs     atomic.Int32
+		stop     atomic.Pointer[func()]
+		unloaded atomic.Bool
+
+		disabled   atomic.Bool   // Only for Config.Disable.
+		generation atomic.Uint64 // The generation of watching, increased by Config.Disable.
+		reloading  reloading     // Only for Config.Reload and konf.WithAutoReload.
+
+		// Only for konf.Optional.
+		optional bool
+		degraded atomic.Bool
+
+		imported bool // Only for konf.ImportState, replaced by the loader with the same string representation.
 	}
 )
 
-func (p *providers) append(loader Loader, values map[string]any) *provider {
+func (p *provider) status(err error) {
+	if err != nil {
+		p.lastErr.Store(&err)
+		if p.optional {
+			p.degraded.Store(true)
+		}
+	}
+}
+
+// attach replaces the layer imported by konf.ImportState which has the same string representation
+// as the loader of the given provider, and returns the replaced provider.
+// Otherwise, it appends the provider and returns nil.
+func (p *providers) attach(provider *provider) *provider {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
-	provider := &provider{loader: loader}
-	provider.values.Store(&values)
-	p.providers = append(p.providers, provider)
+	if !provider.imported {
+		name := fmt.Sprint(provider.loader)
+		for i, imported := range p.providers {
+			if imported.imported && fmt.Sprint(imported.loader) == name {
+				p.providers[i] = provider
+				p.sync()
 
+				return imported
+			}
+		}
+	}
+	p.providers = inserted(p.providers, provider)
 	p.sync()
 
-	return provider
+	return nil
+}
+
+func inserted(providers []*provider, provider *provider) []*provider {
+	if isDefaults(provider.loader) {
+		// Defaults are placed after other defaults but before all other loaders.
+		index := 0
+		for index < len(providers) && isDefaults(providers[index].loader) {
+			index++
+		}
+
+		return slices.Insert(providers, index, provider)
+	}
+
+	return append(providers, provider)
 }
 
 func (p *providers) changed() {
@@ -269,12 +661,84 @@ func (p *providers) changed() {
 	p.sync()
 }
 
+// resync merges the values of providers again, and returns the old and new merged values.
+func (p *providers) resync() (map[string]any, map[string]any) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var oldValues map[string]any
+	if values := p.values.Load(); values != nil {
+		oldValues = *values
+	}
+	p.sync()
+
+	return oldValues, *p.values.Load()
+}
+
 func (p *providers) sync() {
-	values := make(map[string]any)
-	for _, w := range p.providers {
-		maps.Merge(values, *w.values.Load())
+	values := p.merge(p.providers, nil, nil)
+	if len(p.overrides) > 0 {
+		maps.Merge(values, p.overrides)
 	}
 	p.values.Store(&values)
+	p.rebuilds.Add(1)
+	if p.onRebuild != nil {
+		p.onRebuild(p.stats())
+	}
+}
+
+func (p *providers) stats() SnapshotStats {
+	return SnapshotStats{Hits: p.hits.Load(), Rebuilds: p.rebuilds.Load()}
+}
+
+// merged returns the merged values of all providers with the given values replacing the values of the provider.
+// It's used for checking the values before applying, including the provider which has not been appended yet.
+func (p *providers) merged(provider *provider, values map[string]any) map[string]any {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	providers := p.providers
+	if !slices.Contains(providers, provider) {
+		providers = inserted(slices.Clone(providers), provider)
+	}
+
+	return p.merge(providers, provider, values)
+}
+
+func (p *providers) merge(providers []*provider, override *provider, overrideValues map[string]any) map[string]any {
+	valuesOf := func(provider *provider) map[string]any {
+		if provider == override {
+			return overrideValues
+		}
+
+		return *provider.values.Load()
+	}
+
+	values := make(map[string]any)
+	for index, w := range providers {
+		if w.disabled.Load() && w != override {
+			continue
+		}
+		var resolve func(path []string, lower, higher any) any
+		if p.resolve != nil {
+			resolve = func(path []string, lower, higher any) any {
+				// The lower value is provided by the highest loader which has value at the path.
+				var lowerLoader Loader
+				for i := index - 1; i >= 0; i-- {
+					if !providers[i].disabled.Load() && maps.Sub(valuesOf(providers[i]), path) != nil {
+						lowerLoader = providers[i].loader
+
+						break
+					}
+				}
+
+				return p.resolve(path, lower, higher, lowerLoader, w.loader)
+			}
+		}
+		maps.MergeResolve(values, valuesOf(w), func(path []string) bool { return p.replaces(w, path) }, resolve)
+	}
+
+	return values
 }
 
 func (p *providers) traverse(action func(*provider)) {
@@ -286,6 +750,19 @@ func (p *providers) traverse(action func(*provider)) {
 	}
 }
 
+// snapshot returns the merged values, which is immutable and never changes.
+func (p *providers) snapshot() map[string]any {
+	val := p.values.Load()
+	if val == nil { // To support zero Config
+		return nil
+	}
+	if p.countHits {
+		p.hits.Add(1)
+	}
+
+	return *val
+}
+
 func (p *providers) sub(path []string) any {
 	// Here does not need lock since p.values is atomic pointer.
 	// The map of configuration is just swapping in and out,
@@ -296,6 +773,9 @@ func (p *providers) sub(path []string) any {
 	if val == nil { // To support zero Config
 		return nil
 	}
+	if p.countHits {
+		p.hits.Add(1)
+	}
 
 	return maps.Sub(*val, path)
 }
@@ -313,8 +793,251 @@ var (
 		convert.WithHook[string, encoding.TextUnmarshaler](func(f string, t encoding.TextUnmarshaler) error {
 			return t.UnmarshalText(internal.String2ByteSlice(f))
 		}),
+		convert.WithNamedHook[json.Number, encoding.TextUnmarshaler](unmarshalNumberText[json.Number]),
+		convert.WithNamedHook[int, encoding.TextUnmarshaler](unmarshalNumberText[int]),
+		convert.WithNamedHook[int64, encoding.TextUnmarshaler](unmarshalNumberText[int64]),
+		convert.WithNamedHook[uint64, encoding.TextUnmarshaler](unmarshalNumberText[uint64]),
+		convert.WithNamedHook[float64, encoding.TextUnmarshaler](unmarshalNumberText[float64]),
+		convert.WithNamedHook[string, *time.Location](loadLocation),
+		convert.WithNamedHook[string, *url.URL](url.Parse),
+		convert.WithNamedHook[string, url.URL](func(from string) (url.URL, error) {
+			u, err := url.Parse(from)
+			if err != nil {
+				return url.URL{}, err //nolint:wrapcheck
+			}
+
+			return *u, nil
+		}),
+		convert.WithNamedHook[string, []byte](decodeBytes),
+		convert.WithNamedHook[map[string]any, map[string]string](stringMap),
+		convert.WithNamedHook[map[string]any, map[string][]string](stringsMap),
+		convert.WithNamedHook[map[string]any, url.Values](func(from map[string]any) (url.Values, error) {
+			return stringsMap(from)
+		}),
+		convert.WithHook[any, Raw](func(from any) (Raw, error) {
+			return rawJSON(from)
+		}),
+		convert.WithHook[any, json.RawMessage](func(from any) (json.RawMessage, error) {
+			return rawJSON(from)
+		}),
 	}
+	locations        sync.Map // Cache of *time.Location loaded by loadLocation.
 	defaultConverter = convert.New(
-		append(defaultHooks, convert.WithTagName(defaultTagName), convert.WithKeyMapper(defaultKeyMap))...,
+		append(defaultHooks,
+			convert.WithTagName(defaultTagName),
+			convert.WithKeyMapper(defaultKeyMap),
+			convert.WithImplementation(func(name string, typ reflect.Type, from map[string]any) (any, map[string]any, error) {
+				return instantiate(name, typ, from, defaultKeyMap)
+			}),
+		)...,
 	)
 )
+
+// unmarshalNumberText converts the number to the struct type which implements encoding.TextUnmarshaler,
+// e.g. big.Int, big.Rat and third-party decimal types, with the exact text of the number.
+// It returns error if the number is float64 which may have lost precision.
+func unmarshalNumberText[N json.Number | int | int64 | uint64 | float64](from N, to encoding.TextUnmarshaler) error {
+	if reflect.Indirect(reflect.ValueOf(to)).Kind() != reflect.Struct {
+		// Leave the numeric types (e.g. slog.Level) to the default conversion.
+		return errors.ErrUnsupported
+	}
+
+	var text string
+	switch from := any(from).(type) {
+	case json.Number:
+		text = from.String()
+	case int:
+		text = strconv.Itoa(from)
+	case int64:
+		text = strconv.FormatInt(from, 10)
+	case uint64:
+		text = strconv.FormatUint(from, 10)
+	case float64:
+		if math.Abs(from) > maxExactFloat {
+			return fmt.Errorf("%v may have lost precision as float64, use string instead", from) //nolint:err113
+		}
+		text = strconv.FormatFloat(from, 'f', -1, 64)
+	}
+
+	return to.UnmarshalText(internal.String2ByteSlice(text))
+}
+
+// maxExactFloat is the max integer that float64 can represent exactly without rounding from its neighbors.
+const maxExactFloat = 1<<53 - 1
+
+// loadLocation converts the name of time zone in IANA Time Zone database (e.g. "Europe/Berlin"),
+// or fixed offset (e.g. "+02:00", "-0700") to *time.Location.
+// The loaded locations are cached.
+func loadLocation(name string) (*time.Location, error) {
+	switch name {
+	case "":
+		return nil, nil //nolint:nilnil
+	case "UTC":
+		return time.UTC, nil
+	case "Local":
+		return time.Local, nil
+	}
+
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil //nolint:forcetypeassert
+	}
+
+	var (
+		location *time.Location
+		err      error
+	)
+	if name[0] == '+' || name[0] == '-' {
+		location, err = fixedZone(name)
+	} else {
+		location, err = time.LoadLocation(name)
+		if err != nil {
+			err = fmt.Errorf("load time zone %q (import time/tzdata if zoneinfo is not available, e.g. Windows): %w", name, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, location)
+
+	return location, nil
+}
+
+// fixedZone converts fixed offset in format of ±hh, ±hhmm or ±hh:mm to *time.Location.
+func fixedZone(name string) (*time.Location, error) {
+	offset := strings.ReplaceAll(name[1:], ":", "")
+	if len(offset) == 2 { //nolint:mnd
+		offset += "00"
+	}
+	invalid := fmt.Errorf("invalid time zone offset %q, expected ±hh:mm", name) //nolint:err113
+	if len(offset) != 4 || strings.Trim(offset, "0123456789") != "" {           //nolint:mnd
+		return nil, invalid
+	}
+	hours, err := strconv.Atoi(offset[:2])
+	if err != nil || hours > 14 { //nolint:mnd
+		return nil, invalid
+	}
+	minutes, err := strconv.Atoi(offset[2:])
+	if err != nil || minutes > 59 { //nolint:mnd
+		return nil, invalid
+	}
+	seconds := (hours*60 + minutes) * 60 //nolint:mnd
+	if name[0] == '-' {
+		seconds = -seconds
+	}
+
+	return time.FixedZone(name, seconds), nil
+}
+
+// decodeBytes decodes the string with "base64:" (standard encoding) or "hex:" prefix to []byte.
+// Other strings are left to the default conversion which uses the raw bytes of the string.
+func decodeBytes(text string) ([]byte, error) {
+	if encoded, ok := strings.CutPrefix(text, "base64:"); ok {
+		return base64.StdEncoding.DecodeString(encoded) //nolint:wrapcheck
+	}
+	if encoded, ok := strings.CutPrefix(text, "hex:"); ok {
+		return hex.DecodeString(encoded) //nolint:wrapcheck
+	}
+
+	return nil, errors.ErrUnsupported
+}
+
+// stringMap converts the map with scalar values (e.g. numbers and booleans) to map[string]string,
+// e.g. for HTTP headers. It returns error if any value is a map or a slice.
+func stringMap(from map[string]any) (map[string]string, error) {
+	to := make(map[string]string, len(from))
+	errs := stringLeaves(from, func(key string, value any) error {
+		str, err := stringify(key, value)
+		to[key] = str
+
+		return err
+	})
+
+	return to, errors.Join(errs...)
+}
+
+// stringsMap converts the map with scalar values or slices of scalar values to map[string][]string,
+// e.g. url.Values for query parameters. The string is not split by `,` since it may contain `,`.
+// It returns error if any value is a map, or a slice contains map or slice.
+func stringsMap(from map[string]any) (map[string][]string, error) {
+	to := make(map[string][]string, len(from))
+	errs := stringLeaves(from, func(key string, value any) error {
+		if value == nil {
+			to[key] = nil
+
+			return nil
+		}
+		values, ok := value.([]any)
+		if !ok {
+			str, err := stringify(key, value)
+			to[key] = []string{str}
+
+			return err
+		}
+
+		strs := make([]string, 0, len(values))
+		for i, value := range values {
+			str, err := stringify(key+"["+strconv.Itoa(i)+"]", value)
+			if err != nil {
+				return err
+			}
+			strs = append(strs, str)
+		}
+		to[key] = strs
+
+		return nil
+	})
+
+	return to, errors.Join(errs...)
+}
+
+// stringLeaves calls the convert function for each value of the map with its original key,
+// and returns the errors sorted by the keys.
+func stringLeaves(from map[string]any, convert func(key string, value any) error) []error {
+	keys := make([]string, 0, len(from))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var errs []error
+	for _, key := range keys {
+		originalKey, value := maps.Unpack(from[key])
+		if originalKey == "" {
+			originalKey = key
+		}
+		if err := convert(originalKey, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// stringify converts the scalar value to string, e.g. true to "true".
+func stringify(name string, value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case json.Number:
+		return value.String(), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	}
+
+	switch val := reflect.ValueOf(value); {
+	case val.CanInt():
+		return strconv.FormatInt(val.Int(), 10), nil
+	case val.CanUint():
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case val.CanFloat():
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64), nil
+	case val.Kind() == reflect.String:
+		return val.String(), nil
+	default:
+		return "", fmt.Errorf("'%s' is %T, not a scalar value", name, value) //nolint:err113
+	}
+}
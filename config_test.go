@@ -4,6 +4,14 @@
 package konf_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +29,9 @@ func TestConfig_nil(t *testing.T) {
 	assert.NoError(t, config.Unmarshal("key", &value))
 	assert.Equal(t, "", value)
 	assert.True(t, len(config.Explain("key")) > 0)
+	var values []struct{ Key string }
+	assert.NoError(t, config.UnmarshalKeyed("key", &values))
+	assert.Equal(t, nil, values)
 
 	config = konf.New()
 	assert.True(t, !config.Exists([]string{"key"}))
@@ -273,6 +284,702 @@ func TestConfig_Unmarshal(t *testing.T) {
 	}
 }
 
+func TestConfig_Unmarshal_big(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		value       any
+		expected    string
+		err         string
+	}{
+		{
+			description: "string beyond float64",
+			value:       "123456789012345678901234567890",
+			expected:    "123456789012345678901234567890",
+		},
+		{
+			description: "json number beyond float64",
+			value:       json.Number("123456789012345678901234567890"),
+			expected:    "123456789012345678901234567890",
+		},
+		{
+			description: "int",
+			value:       math.MaxInt64,
+			expected:    "9223372036854775807",
+		},
+		{
+			description: "uint64",
+			value:       uint64(math.MaxUint64),
+			expected:    "18446744073709551615",
+		},
+		{
+			description: "float64",
+			value:       float64(9007199254740991),
+			expected:    "9007199254740991",
+		},
+		{
+			description: "float64 beyond exact range",
+			value:       1e20,
+			err:         "decode: cannot parse 'Config' as big.Int: 1e+20 may have lost precision as float64, use string instead",
+		},
+		{
+			description: "fraction",
+			value:       1.5,
+			err:         `decode: cannot parse 'Config' as big.Int: math/big: cannot unmarshal "1.5" into a *big.Int`,
+		},
+		{
+			description: "invalid string",
+			value:       "abc",
+			err:         `decode: math/big: cannot unmarshal "abc" into a *big.Int`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var config konf.Config
+			assert.NoError(t, config.Load(mapLoader{"config": testcase.value}))
+			var value struct {
+				Config big.Int
+			}
+			err := config.Unmarshal("", &value)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, value.Config.String())
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_rat(t *testing.T) {
+	t.Parallel()
+
+	var config konf.Config
+	assert.NoError(t, config.Load(mapLoader{
+		"float":  0.1,
+		"number": json.Number("0.10000000000000000000000000001"),
+		"string": "1/3",
+	}))
+	var value struct {
+		Float  *big.Rat
+		Number *big.Rat
+		String big.Rat
+	}
+	assert.NoError(t, config.Unmarshal("", &value))
+	assert.Equal(t, "1/10", value.Float.String())
+	assert.Equal(t, "10000000000000000000000000001/100000000000000000000000000000", value.Number.String())
+	assert.Equal(t, "1/3", value.String.String())
+}
+
+func TestConfig_Unmarshal_location(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	testcases := []struct {
+		description string
+		value       string
+		expected    *time.Location
+		err         string
+	}{
+		{
+			description: "empty",
+		},
+		{
+			description: "UTC",
+			value:       "UTC",
+			expected:    time.UTC,
+		},
+		{
+			description: "Local",
+			value:       "Local",
+			expected:    time.Local,
+		},
+		{
+			description: "IANA name",
+			value:       "Europe/Berlin",
+			expected:    berlin,
+		},
+		{
+			description: "offset with colon",
+			value:       "+02:00",
+			expected:    time.FixedZone("+02:00", 2*60*60),
+		},
+		{
+			description: "offset without colon",
+			value:       "-0530",
+			expected:    time.FixedZone("-0530", -(5*60+30)*60),
+		},
+		{
+			description: "offset in hours",
+			value:       "+08",
+			expected:    time.FixedZone("+08", 8*60*60),
+		},
+		{
+			description: "invalid name",
+			value:       "Mars/Olympus",
+			err: "decode: cannot parse 'zone' as *time.Location: " +
+				`load time zone "Mars/Olympus" (import time/tzdata if zoneinfo is not available, e.g. Windows): ` +
+				"unknown time zone Mars/Olympus",
+		},
+		{
+			description: "invalid offset",
+			value:       "+2:0a",
+			err:         `decode: cannot parse 'zone' as *time.Location: invalid time zone offset "+2:0a", expected ±hh:mm`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var config konf.Config
+			assert.NoError(t, config.Load(mapLoader{"zone": testcase.value}))
+
+			var location *time.Location
+			err := config.Unmarshal("zone", &location)
+			var value struct{ Zone *time.Location }
+			structErr := config.Unmarshal("", &value)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.EqualError(t, structErr, strings.Replace(testcase.err, "'zone'", "'Zone'", 1))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, location)
+				assert.NoError(t, structErr)
+				assert.Equal(t, testcase.expected, value.Zone)
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_elements(t *testing.T) {
+	t.Parallel()
+
+	second, minute := time.Second, time.Minute
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		description string
+		value       any
+		target      any
+		expected    any
+		err         string
+	}{
+		{
+			description: "map of duration from string",
+			value:       map[string]any{"read": "1s", "write": "1m"},
+			target:      new(map[string]time.Duration),
+			expected:    &map[string]time.Duration{"read": time.Second, "write": time.Minute},
+		},
+		{
+			description: "map of duration from number",
+			value:       map[string]any{"read": int64(time.Second)},
+			target:      new(map[string]time.Duration),
+			expected:    &map[string]time.Duration{"read": time.Second},
+		},
+		{
+			description: "map of duration pointer",
+			value:       map[string]any{"read": "1s", "write": int64(time.Minute)},
+			target:      new(map[string]*time.Duration),
+			expected:    &map[string]*time.Duration{"read": &second, "write": &minute},
+		},
+		{
+			description: "slice of duration",
+			value:       []any{"1s", int64(time.Minute)},
+			target:      new([]time.Duration),
+			expected:    &[]time.Duration{time.Second, time.Minute},
+		},
+		{
+			description: "slice of duration pointer",
+			value:       []any{"1s", "1m"},
+			target:      new([]*time.Duration),
+			expected:    &[]*time.Duration{&second, &minute},
+		},
+		{
+			description: "pointer of duration slice",
+			value:       []any{"1s"},
+			target:      new(*[]time.Duration),
+			expected: func() **[]time.Duration {
+				durations := &[]time.Duration{time.Second}
+
+				return &durations
+			}(),
+		},
+		{
+			description: "map of time",
+			value:       map[string]any{"start": "2025-01-01T00:00:00Z"},
+			target:      new(map[string]time.Time),
+			expected:    &map[string]time.Time{"start": date},
+		},
+		{
+			description: "slice of time pointer",
+			value:       []any{"2025-01-01T00:00:00Z"},
+			target:      new([]*time.Time),
+			expected:    &[]*time.Time{&date},
+		},
+		{
+			description: "map of url",
+			value:       map[string]any{"api": "https://example.com/v1"},
+			target:      new(map[string]url.URL),
+			expected:    &map[string]url.URL{"api": {Scheme: "https", Host: "example.com", Path: "/v1"}},
+		},
+		{
+			description: "slice of url pointer",
+			value:       []any{"https://example.com"},
+			target:      new([]*url.URL),
+			expected:    &[]*url.URL{{Scheme: "https", Host: "example.com"}},
+		},
+		{
+			description: "invalid duration in map",
+			value:       map[string]any{"read": "fast"},
+			target:      new(map[string]*time.Duration),
+			err:         `decode: time: invalid duration "fast"`,
+		},
+		{
+			description: "invalid duration in slice",
+			value:       []any{"1s", "fast"},
+			target:      new([]time.Duration),
+			err:         `decode: time: invalid duration "fast"`,
+		},
+		{
+			description: "invalid url in map",
+			value:       map[string]any{"api": "://"},
+			target:      new(map[string]url.URL),
+			err:         `decode: cannot parse 'timeouts.api' as url.URL: parse "://": missing protocol scheme`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var config konf.Config
+			assert.NoError(t, config.Load(mapLoader{"timeouts": testcase.value}))
+
+			err := config.Unmarshal("timeouts", testcase.target)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, testcase.target)
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_stringMap(t *testing.T) {
+	t.Parallel()
+
+	type HTTP struct {
+		Headers map[string]string
+		Query   url.Values
+		Tags    map[string][]string
+	}
+	testcases := []struct {
+		description string
+		value       map[string]any
+		expected    HTTP
+		err         string
+	}{
+		{
+			description: "scalar leaves",
+			value: map[string]any{
+				"headers": map[string]any{"X-Request-ID": "abc", "X-Retry": 3, "X-Debug": true, "X-Ratio": 0.5, "X-Empty": nil},
+				"query":   map[string]any{"q": "a,b", "page": 2, "ids": []any{1, "2", false}},
+				"tags":    map[string]any{"env": "prod"},
+			},
+			expected: HTTP{
+				Headers: map[string]string{"x-request-id": "abc", "x-retry": "3", "x-debug": "true", "x-ratio": "0.5", "x-empty": ""},
+				Query:   url.Values{"q": {"a,b"}, "page": {"2"}, "ids": {"1", "2", "false"}},
+				Tags:    map[string][]string{"env": {"prod"}},
+			},
+		},
+		{
+			description: "nested map",
+			value:       map[string]any{"headers": map[string]any{"X-Nested": map[string]any{"a": 1}, "X-List": []any{1}}},
+			err: "decode: cannot parse 'Headers' as map[string]string: " +
+				"'x-list' is []interface {}, not a scalar value\n'x-nested' is map[string]interface {}, not a scalar value",
+		},
+		{
+			description: "nested slice",
+			value:       map[string]any{"query": map[string]any{"ids": []any{1, []any{2}}}},
+			err: "decode: cannot parse 'Query' as url.Values: " +
+				"'ids[1]' is []interface {}, not a scalar value",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New()
+			assert.NoError(t, config.Load(mapLoader(testcase.value)))
+
+			var value HTTP
+			err := config.Unmarshal("", &value)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, value)
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_raw(t *testing.T) {
+	t.Parallel()
+
+	type Plugins struct {
+		Tracing json.RawMessage
+		Broker  konf.Raw
+		Plugins map[string]konf.Raw
+	}
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		value       map[string]any
+		expected    Plugins
+	}{
+		{
+			description: "sorted keys",
+			value: map[string]any{
+				"tracing": map[string]any{"sampler": map[string]any{"Ratio": 0.5, "Type": "ratio"}, "enabled": true},
+				"broker":  []any{"a", 1, nil},
+				"plugins": map[string]any{"auth": map[string]any{"Realm": "konf"}, "gzip": 6},
+			},
+			expected: Plugins{
+				Tracing: json.RawMessage(`{"enabled":true,"sampler":{"ratio":0.5,"type":"ratio"}}`),
+				Broker:  konf.Raw(`["a",1,null]`),
+				Plugins: map[string]konf.Raw{"auth": konf.Raw(`{"realm":"konf"}`), "gzip": konf.Raw(`6`)},
+			},
+		},
+		{
+			description: "original keys",
+			opts:        []konf.Option{konf.WithMapKeyCaseSensitive()},
+			value: map[string]any{
+				"tracing": map[string]any{"Sampler": map[string]any{"Type": "ratio", "Ratio": 0.5}},
+				"broker":  "kafka",
+			},
+			expected: Plugins{
+				Tracing: json.RawMessage(`{"Sampler":{"Ratio":0.5,"Type":"ratio"}}`),
+				Broker:  konf.Raw(`"kafka"`),
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader(testcase.value)))
+
+			var value Plugins
+			assert.NoError(t, config.Unmarshal("", &value))
+			assert.Equal(t, testcase.expected, value)
+
+			bytes, err := json.Marshal(value.Broker)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte(testcase.expected.Broker), bytes)
+		})
+	}
+}
+
+func TestConfig_Unmarshal_bytes(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		value       any
+		expected    []byte
+		err         string
+	}{
+		{
+			description: "bytes",
+			value:       []byte{0x00, 0xff},
+			expected:    []byte{0x00, 0xff},
+		},
+		{
+			description: "string",
+			value:       "key",
+			expected:    []byte("key"),
+		},
+		{
+			description: "base64",
+			value:       "base64:AP8=",
+			expected:    []byte{0x00, 0xff},
+		},
+		{
+			description: "hex",
+			value:       "hex:00ff",
+			expected:    []byte{0x00, 0xff},
+		},
+		{
+			description: "invalid base64",
+			value:       "base64:AP8",
+			err:         "decode: cannot parse 'key' as []uint8: illegal base64 data at input byte 0",
+		},
+		{
+			description: "invalid hex",
+			value:       "hex:0g",
+			err:         "decode: cannot parse 'key' as []uint8: encoding/hex: invalid byte: U+0067 'g'",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var config konf.Config
+			assert.NoError(t, config.Load(mapLoader{"key": testcase.value}))
+
+			var value []byte
+			err := config.Unmarshal("key", &value)
+			var array [2]byte
+			arrayErr := config.Unmarshal("key", &array)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.EqualError(t, arrayErr, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, value)
+				if len(testcase.expected) == len(array) {
+					assert.NoError(t, arrayErr)
+					assert.Equal(t, [2]byte(testcase.expected), array)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_tagName(t *testing.T) {
+	t.Parallel()
+
+	type (
+		Base struct {
+			Name string `mapstructure:"service_name"`
+		}
+		Host struct {
+			Addr string `mapstructure:"address" validate:"required"`
+		}
+		Server struct {
+			Base  `mapstructure:",squash"`
+			Hosts []Host `mapstructure:"upstreams"`
+			TLS   struct {
+				Cert string `mapstructure:"cert_file"`
+			} `mapstructure:"tls"`
+		}
+	)
+	config := konf.New(konf.WithTagName("mapstructure"), konf.WithTagValidation())
+	assert.NoError(t, config.Load(mapLoader{
+		"server": map[string]any{
+			"service_name": "konf",
+			"upstreams":    []any{map[string]any{"address": "a:80"}, map[string]any{"address": "b:80"}},
+			"tls":          map[string]any{"cert_file": "cert.pem"},
+		},
+	}))
+
+	var value Server
+	assert.NoError(t, config.Unmarshal("server", &value))
+	expected := Server{Base: Base{Name: "konf"}, Hosts: []Host{{Addr: "a:80"}, {Addr: "b:80"}}}
+	expected.TLS.Cert = "cert.pem"
+	assert.Equal(t, expected, value)
+	assert.Equal(t, "server.tls.cert_file has value[cert.pem] that is loaded by loader[map].\n\n",
+		config.Explain("server.tls.cert_file"))
+
+	var partial Server
+	assert.NoError(t, config.Unmarshal("server", &partial, konf.Fields("service_name")))
+	assert.Equal(t, Server{Base: Base{Name: "konf"}}, partial)
+
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"upstreams": []any{map[string]any{}}}}))
+	assert.EqualError(t, config.Unmarshal("server", &value), "validate: 'server.upstreams[0].address' is required")
+}
+
+func TestConfig_Unmarshal_decodeHook(t *testing.T) {
+	t.Parallel()
+
+	hosts := map[string]net.IP{"localhost": net.IPv4(127, 0, 0, 1)}
+	config := konf.New(
+		konf.WithDecodeHook[string, net.IP](func(from string) (net.IP, error) {
+			if ip, ok := hosts[from]; ok {
+				return ip, nil
+			}
+
+			return nil, errors.ErrUnsupported // Fall back to the default hook.
+		}),
+	)
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"host": "localhost", "peer": "10.0.0.1", "timeout": "10s"}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	type Server struct {
+		Host    net.IP
+		Peer    net.IP
+		Timeout time.Duration
+	}
+	var value Server
+	assert.NoError(t, config.Unmarshal("server", &value))
+	assert.Equal(t, Server{Host: net.IPv4(127, 0, 0, 1), Peer: net.ParseIP("10.0.0.1"), Timeout: 10 * time.Second}, value)
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	newValue := make(chan Server)
+	config.OnChange(func(config *konf.Config) {
+		var value Server
+		assert.NoError(t, config.Unmarshal("server", &value))
+		newValue <- value
+	}, "server")
+	watcher.change <- map[string]any{"server": map[string]any{"host": "10.0.0.2", "peer": "localhost"}}
+	assert.Equal(t, Server{Host: net.ParseIP("10.0.0.2"), Peer: net.IPv4(127, 0, 0, 1)}, <-newValue)
+}
+
+func TestConfig_Unmarshal_bounds(t *testing.T) {
+	t.Parallel()
+
+	type bounded struct {
+		Workers  int      `konf:"workers,min=1,max=1024"`
+		Ratio    *float64 `konf:"ratio,min=0,max=1"`
+		Retries  uint8    `konf:",max=10"`
+		Password int      `konf:"password,max=9"`
+	}
+
+	testcases := []struct {
+		description string
+		values      map[string]any
+		target      any
+		err         string
+	}{
+		{
+			description: "in bounds",
+			values:      map[string]any{"workers": 1024, "ratio": 0.5, "retries": "10"},
+			target:      &bounded{},
+		},
+		{
+			description: "missing values",
+			values:      map[string]any{},
+			target:      &bounded{},
+		},
+		{
+			description: "out of bounds",
+			values:      map[string]any{"workers": 0, "ratio": 1.5, "retries": 11, "password": 12345},
+			target:      &bounded{},
+			err: "decode: 'workers' value 0 is less than min 1\n" +
+				"'ratio' value 1.5 is greater than max 1\n" +
+				"'Retries' value 11 is greater than max 10\n" +
+				"'password' value ****** is greater than max 9",
+		},
+		{
+			description: "invalid bound",
+			values:      map[string]any{"workers": 1},
+			target: &struct {
+				Workers int `konf:"workers,min=one"`
+			}{},
+			err: `decode: 'workers' invalid min "one": strconv.ParseInt: parsing "one": invalid syntax`,
+		},
+		{
+			description: "length",
+			values:      map[string]any{"name": "name", "timeout": "10ms"},
+			target: &struct {
+				Name    string        `konf:"name,min=5"`
+				Timeout time.Duration `konf:"timeout,min=1s"`
+			}{},
+			err: "decode: 'name' value length 4 is less than min 5\n'timeout' value 10ms is less than min 1s",
+		},
+		{
+			description: "unsupported type",
+			values:      map[string]any{"debug": true},
+			target: &struct {
+				Debug bool `konf:"debug,min=1"`
+			}{},
+			err: "decode: 'debug' min does not support bool",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var config konf.Config
+			assert.NoError(t, config.Load(mapLoader(testcase.values)))
+			err := config.Unmarshal("", testcase.target)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Load_mixedCaseKeys(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		expected    map[string]any
+	}{
+		{
+			description: "case insensitive",
+			expected: map[string]any{
+				"server": map[string]any{"host": "a", "port": 9090, "tls": map[string]any{"cert": "c", "enabled": true}},
+			},
+		},
+		{
+			description: "map key case sensitive",
+			opts:        []konf.Option{konf.WithMapKeyCaseSensitive()},
+			expected: map[string]any{
+				"server": map[string]any{"Host": "a", "port": 9090, "TLS": map[string]any{"cert": "c", "Enabled": true}},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader{"Server": map[string]any{"Port": 8080, "Host": "a"}}))
+			assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"port": 9090}}))
+			// The keys in the same map which are the same path are merged as well.
+			assert.NoError(t, config.Load(mapLoader{
+				"server": map[string]any{"TLS": map[string]any{"Enabled": true}},
+				"SERVER": map[string]any{"tls": map[string]any{"cert": "c"}},
+			}))
+
+			var port int
+			assert.NoError(t, config.Unmarshal("server.port", &port))
+			assert.Equal(t, 9090, port)
+			var values map[string]any
+			assert.NoError(t, config.Unmarshal("", &values))
+			assert.Equal(t, testcase.expected, values)
+			assert.Equal(t, "SERVER.Port has value[9090] that is loaded by loader[map].\n"+
+				"Here are other value(loader)s:\n  - 8080(map)\n\n", config.Explain("SERVER.Port"))
+			assert.Equal(t, "server.tls.enabled has value[true] that is loaded by loader[map].\n\n",
+				config.Explain("server.tls.enabled"))
+		})
+	}
+}
+
 func TestConfigCopyPanic(t *testing.T) {
 	defer func() {
 		assert.Equal(t, recover(), "illegal use of non-zero Config copied by value")
@@ -310,6 +1017,7 @@ func TestConfig_Explain(t *testing.T) {
 		"number":   123,
 		"password": "password",
 		"key":      []byte("AKIA9SKKLKSKKSKKSKK8"),
+		"cert":     []byte{0x30, 0x82},
 		"config":   map[string]any{"nest": "map"},
 	})
 	assert.NoError(t, err)
@@ -339,6 +1047,11 @@ func TestConfig_Explain(t *testing.T) {
 			path:        "key",
 			expected:    "key has value[AWS API Key] that is loaded by loader[map].\n\n",
 		},
+		{
+			description: "bytes",
+			path:        "cert",
+			expected:    "cert has value[<2 bytes>] that is loaded by loader[map].\n\n",
+		},
 		{
 			description: "config",
 			path:        "config",
@@ -359,6 +1072,70 @@ Here are other value(loader)s:
 	}
 }
 
+func TestConfig_Unmarshal_redacted(t *testing.T) {
+	t.Parallel()
+
+	var config konf.Config
+	assert.NoError(t, config.Load(mapLoader{
+		"password": "hunter2",
+		"db": map[string]any{
+			"password": []any{"hunter2"},
+		},
+		"key": "AKIA9SKKLKSKKSKKSKK8",
+	}))
+
+	testcases := []struct {
+		description string
+		path        string
+		target      any
+		err         string
+	}{
+		{
+			description: "secret name",
+			path:        "password",
+			target:      new(int),
+			err:         "decode: cannot parse 'password' as int: strconv.ParseInt: parsing \"******\": invalid syntax",
+		},
+		{
+			description: "secret name in struct",
+			path:        "db",
+			target: new(struct {
+				Password []int
+			}),
+			err: "decode: cannot parse 'db.Password[0]' as int: strconv.ParseInt: parsing \"******\": invalid syntax",
+		},
+		{
+			description: "secret value",
+			path:        "key",
+			target:      new(bool),
+			err:         "decode: cannot parse 'key' as bool: strconv.ParseBool: parsing \"AWS API Key\": invalid syntax",
+		},
+		{
+			description: "unconvertible secret",
+			path:        "db.password",
+			target:      new(bool),
+			err:         "decode: 'db.password' expected type 'bool', got unconvertible type '[]interface {}', value: '******'",
+		},
+		{
+			description: "secret in hook",
+			path:        "password",
+			target:      new(time.Duration),
+			err:         "decode: time: invalid duration \"******\"",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			err := config.Unmarshal(testcase.path, testcase.target)
+			assert.EqualError(t, err, testcase.err)
+			assert.True(t, !strings.Contains(err.Error(), "hunter2"))
+			assert.True(t, !strings.Contains(err.Error(), "AKIA9SKKLKSKKSKKSKK8"))
+		})
+	}
+}
+
 type Enum int
 
 const (
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"slices"
+)
+
+type changeContextKey struct{}
+
+// ChangeFromContext returns the ChangeEvent carried by the context passed to the callback
+// registered by Config.OnChangeContext, or the context derived from it, and reports whether it carries one.
+// For the initial delivery by konf.DeliverCurrent, the ChangeEvent is marked as Synthetic.
+//
+// The returned ChangeEvent is a copy, so changing it never affects the other callbacks.
+func ChangeFromContext(ctx context.Context) (ChangeEvent, bool) {
+	event, ok := ctx.Value(changeContextKey{}).(*ChangeEvent)
+	if !ok {
+		return ChangeEvent{}, false
+	}
+	copied := *event
+	copied.Keys = slices.Clone(event.Keys)
+	copied.Removed = slices.Clone(event.Removed)
+
+	return copied, true
+}
+
+// currentEvent returns the synthetic ChangeEvent for the initial delivery by konf.DeliverCurrent.
+func (c *Config) currentEvent() *ChangeEvent {
+	return &ChangeEvent{Version: c.version.Load(), Time: c.timeSource().Now(), Synthetic: true}
+}
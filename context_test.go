@@ -0,0 +1,144 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_OnChangeContext(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		onChange    []konf.OnChangeOption
+	}{
+		{
+			description: "default group",
+		},
+		{
+			description: "group with policy",
+			opts:        []konf.Option{konf.WithGroupPolicy("heavy", konf.Concurrent())},
+			onChange:    []konf.OnChangeOption{konf.Group("heavy")},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			watcher := mapWatcher{values: map[string]any{"port": 8080}, change: make(chan map[string]any)}
+			assert.NoError(t, config.Load(watcher))
+
+			events := make(chan konf.ChangeEvent, 2)
+			config.OnChangeContext(func(ctx context.Context, config *konf.Config) {
+				event, ok := handle(ctx)
+				assert.True(t, ok)
+				assert.Equal(t, event.Version, config.LastChange().Version)
+				events <- event
+			}, append(testcase.onChange, konf.Keys("port"))...)
+
+			stopped := make(chan struct{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer func() {
+				cancel()
+				<-stopped
+			}()
+			go func() {
+				defer close(stopped)
+				assert.NoError(t, config.Watch(ctx))
+			}()
+			waitWatch(t, config)
+
+			watcher.change <- map[string]any{"port": 8081}
+			event := <-events
+			assert.Equal(t, uint64(1), event.Version)
+			assert.Equal(t, "map", fmt.Sprint(event.Loader))
+			assert.Equal(t, []string{"port"}, event.Keys)
+			assert.True(t, !event.Synthetic)
+
+			// The event is immutable.
+			event.Keys[0] = "changed"
+			assert.Equal(t, []string{"port"}, config.LastChange().Keys)
+		})
+	}
+}
+
+func TestConfig_OnChangeContext_deliverCurrent(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"port": 8080}))
+
+	var event konf.ChangeEvent
+	config.OnChangeContext(func(ctx context.Context, _ *konf.Config) {
+		var ok bool
+		event, ok = handle(ctx)
+		assert.True(t, ok)
+	}, konf.DeliverCurrent())
+	assert.True(t, event.Synthetic)
+	assert.Equal(t, uint64(0), event.Version)
+	assert.True(t, event.Loader == nil)
+	assert.Equal(t, 0, len(event.Keys))
+
+	_, ok := konf.ChangeFromContext(context.Background())
+	assert.True(t, !ok)
+}
+
+func TestConfig_OnChangeContext_sub(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"server": map[string]any{"port": 8080}}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	sub := config.Sub("server")
+	events, ports := make(chan konf.ChangeEvent, 1), make(chan int, 1)
+	sub.OnChangeContext(func(ctx context.Context, config *konf.Config) {
+		event, ok := handle(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, sub, config)
+		events <- event
+	}, konf.Keys("port"))
+	sub.OnChangeWith(func(config *konf.Config) { ports <- config.GetInt("port") }, konf.Keys("port"))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	// The watcher receives the change once Config.Watch has started.
+	watcher.change <- map[string]any{"server": map[string]any{"port": 8081}}
+	assert.Equal(t, []string{"server.port"}, (<-events).Keys)
+	assert.Equal(t, 8081, <-ports)
+}
+
+// handle retrieves the change several call frames deep from the callback, with the derived context.
+func handle(ctx context.Context) (konf.ChangeEvent, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	return process(ctx)
+}
+
+func process(ctx context.Context) (konf.ChangeEvent, bool) {
+	return retrieve(context.WithValue(ctx, struct{}{}, "value"))
+}
+
+func retrieve(ctx context.Context) (konf.ChangeEvent, bool) {
+	return konf.ChangeFromContext(ctx)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+type (
+	// DebugState is the snapshot of the internal state of a Config for debugging.
+	// It never contains any configuration value.
+	DebugState struct {
+		// Watching reports whether Config.Watch has been called.
+		Watching bool
+		// Version is the number of changes that have been applied by Config.Watch.
+		Version uint64
+		// LastChanged is the time when the last change was applied. It's zero if no change.
+		LastChanged time.Time
+		// PendingChanges is the number of changes waiting for applying.
+		PendingChanges int
+		// CoalescedChanges is the number of changes coalesced since the queue provided by konf.WithChangeQueue is full.
+		CoalescedChanges uint64
+		// Watchers is the number of goroutines watching loaders,
+		// including the ones of unloaded loaders which have not returned yet.
+		Watchers int
+		// Loaders are the states of loaders, from the lowest to the highest precedence.
+		Loaders []LoaderState
+		// Subscriptions are the states of callbacks registered by Config.OnChange, sorted by path.
+		Subscriptions []SubscriptionState
+		// RestartPending are the changed keys requiring restart which have not been acknowledged, sorted.
+		RestartPending []string
+		// Temporaries are the states of temporary values set by Config.SetTemporary, sorted by path.
+		Temporaries []TemporaryState
+		// Snapshot is the statistics of the merged snapshot of values from all loaders.
+		Snapshot SnapshotStats
+	}
+
+	// SnapshotStats is the statistics of the merged snapshot in DebugState.
+	// The snapshot is rebuilt when a loader is loaded or changed, and reused by reads until then.
+	SnapshotStats struct {
+		// Hits is the number of reads served by the snapshot, e.g. Config.Unmarshal.
+		// It's only counted with konf.WithSnapshotStats, and zero otherwise.
+		Hits uint64
+		// Rebuilds is the number of times the snapshot has been rebuilt.
+		Rebuilds uint64
+	}
+
+	// LoaderState is the state of a loader in DebugState.
+	LoaderState struct {
+		Loader Loader
+		// Watcher reports whether the loader is a Watcher.
+		Watcher bool
+		// Watched reports whether the watching of the loader has been started.
+		Watched bool
+		// Disabled reports whether the loader has been disabled by Config.Disable.
+		Disabled bool
+		// LastError is the last error reported by the loader, in loading, watching or status.
+		LastError error
+		// Optional reports whether the loader is loaded with konf.Optional.
+		Optional bool
+		// Degraded reports whether the optional loader has failed since its values were last loaded,
+		// so that its values are missing or stale.
+		Degraded bool
+	}
+
+	// SubscriptionState is the state of callbacks registered by Config.OnChange for a path in DebugState.
+	SubscriptionState struct {
+		// Path is the path the callbacks are registered for. Empty path means any path.
+		Path string
+		// Count is the number of callbacks.
+		Count int
+		// Callers are the locations where Config.OnChange is called,
+		// only available with konf.WithCallerCapture.
+		Callers []string
+	}
+)
+
+// DebugState returns the snapshot of the internal state of the Config
+// for debugging, e.g. exposing via HTTP handler.
+//
+// This method is concurrent-safe.
+func (c *Config) DebugState() DebugState {
+	if c == nil { // To support nil
+		return DebugState{}
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.DebugState()
+	}
+
+	state := DebugState{Version: c.version.Load(), Watchers: int(c.watchers.running.Load())}
+	if watch := c.watched.Load(); watch != nil {
+		state.Watching = true
+		state.PendingChanges = watch.queue.len()
+		state.CoalescedChanges = watch.queue.coalesced.Load()
+	}
+	if lastChange := c.lastChange.Load(); lastChange != nil {
+		state.LastChanged = lastChange.Time
+	}
+	state.Loaders = c.loaderStates()
+	state.Subscriptions = c.onChanges.states()
+	state.RestartPending = c.restart.pending()
+	state.Temporaries = c.temporaryStates()
+	state.Snapshot = c.providers.stats()
+
+	return state
+}
+
+func (c *Config) loaderStates() []LoaderState {
+	var states []LoaderState
+	c.providers.traverse(func(provider *provider) {
+		_, isWatcher := provider.loader.(Watcher)
+		state := LoaderState{
+			Loader:   provider.loader,
+			Watcher:  isWatcher,
+			Watched:  provider.watched.Load(),
+			Disabled: provider.disabled.Load(),
+			Optional: provider.optional,
+			Degraded: provider.degraded.Load(),
+		}
+		if err := provider.lastErr.Load(); err != nil {
+			state.LastError = *err
+		}
+		states = append(states, state)
+	})
+
+	return states
+}
+
+// DumpState writes the human-readable internal state of the Config to the given writer for debugging.
+// The content is the same as Config.DebugState.
+//
+// This method is concurrent-safe.
+func (c *Config) DumpState(writer io.Writer) error {
+	state := c.DebugState()
+
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "Watching: %t\n", state.Watching)
+	fmt.Fprintf(builder, "Version: %d\n", state.Version)
+	if state.LastChanged.IsZero() {
+		builder.WriteString("Last Changed: never\n")
+	} else {
+		fmt.Fprintf(builder, "Last Changed: %s\n", state.LastChanged.Format(time.RFC3339Nano))
+	}
+	fmt.Fprintf(builder, "Pending Changes: %d\n", state.PendingChanges)
+	fmt.Fprintf(builder, "Watchers: %d\n", state.Watchers)
+	if len(state.RestartPending) == 0 {
+		builder.WriteString("Restart Pending: none\n")
+	} else {
+		fmt.Fprintf(builder, "Restart Pending: %s\n", strings.Join(state.RestartPending, ", "))
+	}
+	fmt.Fprintf(builder, "Snapshot: %d hits, %d rebuilds\n", state.Snapshot.Hits, state.Snapshot.Rebuilds)
+	if len(state.Temporaries) > 0 {
+		builder.WriteString("Temporaries:\n")
+		for _, tmp := range state.Temporaries {
+			fmt.Fprintf(builder, "  - %s: expires in %s at %s\n",
+				tmp.Path, tmp.Remaining, tmp.Expires.Format(time.RFC3339Nano),
+			)
+		}
+	}
+	builder.WriteString("Loaders (from the lowest to the highest precedence):\n")
+	for _, loader := range state.Loaders {
+		fmt.Fprintf(builder, "  - %v [watcher=%t, watched=%t", loader.Loader, loader.Watcher, loader.Watched)
+		if loader.Disabled {
+			builder.WriteString(", disabled=true")
+		}
+		if loader.Degraded {
+			builder.WriteString(", degraded=true")
+		}
+		if loader.LastError != nil {
+			fmt.Fprintf(builder, ", last error=%q", loader.LastError.Error())
+		}
+		builder.WriteString("]\n")
+	}
+	builder.WriteString("Subscriptions:\n")
+	for _, subscription := range state.Subscriptions {
+		path := subscription.Path
+		if path == "" {
+			path = "<any>"
+		}
+		fmt.Fprintf(builder, "  - %s: %d\n", path, subscription.Count)
+		for _, caller := range subscription.Callers {
+			fmt.Fprintf(builder, "      registered at %s\n", caller)
+		}
+	}
+
+	if _, err := io.WriteString(writer, builder.String()); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+
+	return nil
+}
+
+func (o *onChanges) states() []SubscriptionState {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	states := make([]SubscriptionState, 0, len(o.subscribers))
+	for path, subscribers := range o.subscribers {
+		state := SubscriptionState{Path: path, Count: len(subscribers)}
+		for _, sub := range subscribers {
+			if sub.caller != "" {
+				state.Callers = append(state.Callers, sub.caller)
+			}
+		}
+		states = append(states, state)
+	}
+	slices.SortFunc(states, func(a, b SubscriptionState) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return states
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_DebugState_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, konf.DebugState{}, config.DebugState())
+}
+
+func TestConfig_DumpState(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithCallerCapture(), konf.WithLogHandler(logHandler(&buffer{})))
+	assert.NoError(t, config.Load(mapLoader{"password": "secret"}))
+	assert.NoError(t, config.Load(&statusWatcher{}))
+	config.OnChange(func(*konf.Config) {}, "password")
+	config.OnChange(func(*konf.Config) {}, "password")
+	config.OnChange(func(*konf.Config) {})
+
+	builder := &strings.Builder{}
+	assert.NoError(t, config.DumpState(builder))
+	expected := `Watching: false
+Version: 0
+Last Changed: never
+Pending Changes: 0
+Watchers: 0
+Restart Pending: none
+Snapshot: 0 hits, 2 rebuilds
+Loaders (from the lowest to the highest precedence):
+  - map [watcher=false, watched=false]
+  - status [watcher=true, watched=false]
+Subscriptions:
+  - <any>: 1
+      registered at debug_test.go
+  - password: 2
+      registered at debug_test.go
+      registered at debug_test.go
+`
+	assert.Equal(t, expected, callerPattern.ReplaceAllString(builder.String(), "debug_test.go"))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	state := config.DebugState()
+	assert.True(t, state.Watching)
+	assert.Equal(t, 2, len(state.Loaders))
+	assert.True(t, state.Loaders[1].Watched)
+	assert.EqualError(t, state.Loaders[1].LastError, "watch error")
+
+	builder.Reset()
+	assert.NoError(t, config.DumpState(builder))
+	assert.True(t, !strings.Contains(builder.String(), "secret"))
+	assert.True(t, strings.Contains(builder.String(), `  - status [watcher=true, watched=true, last error="watch error"]`))
+}
+
+func TestConfig_DebugState_snapshot(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		expected    konf.SnapshotStats
+	}{
+		{
+			description: "default",
+			expected:    konf.SnapshotStats{Rebuilds: 1},
+		},
+		{
+			description: "with snapshot stats",
+			opts:        []konf.Option{konf.WithSnapshotStats()},
+			expected:    konf.SnapshotStats{Hits: 3, Rebuilds: 1},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader{"k": "v"}))
+			var value string
+			for range 3 {
+				assert.NoError(t, config.Unmarshal("k", &value))
+			}
+			assert.Equal(t, testcase.expected, config.DebugState().Snapshot)
+		})
+	}
+}
+
+func TestConfig_DebugState_snapshotHook(t *testing.T) {
+	t.Parallel()
+
+	var stats []konf.SnapshotStats
+	config := konf.New(
+		konf.WithSnapshotStats(),
+		konf.WithLifecycleHooks(konf.Hooks{
+			OnSnapshotRebuild: func(s konf.SnapshotStats) { stats = append(stats, s) },
+		}),
+	)
+	assert.NoError(t, config.Load(mapLoader{"k": "v"}))
+	var value string
+	assert.NoError(t, config.Unmarshal("k", &value))
+	assert.NoError(t, config.Load(mapLoader{"k": "w"}))
+	assert.Equal(t, []konf.SnapshotStats{{Rebuilds: 1}, {Hits: 1, Rebuilds: 2}}, stats)
+}
+
+var callerPattern = regexp.MustCompile(`/.*/debug_test\.go:\d+`)
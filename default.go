@@ -31,10 +31,30 @@ func Get[T any](path string) T { //nolint:ireturn
 }
 
 // Unmarshal reads configuration under the given path from the default Config
+// and decodes it into the given object pointed to by target with the given UnmarshalOption(s).
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+func Unmarshal(path string, target any, opts ...UnmarshalOption) error {
+	return defaultConfig.Load().Unmarshal(path, target, opts...)
+}
+
+// UnmarshalFor reads configuration under the given path for the given tenant from the default Config
 // and decodes it into the given object pointed to by target.
+// The value under `tenants.<tenant>.<path>` overlays the value under `<path>`.
+// The tenant and path are case-insensitive unless konf.WithCaseSensitive is set.
+func UnmarshalFor(tenant, path string, target any) error {
+	return defaultConfig.Load().UnmarshalFor(tenant, path, target)
+}
+
+// Describe registers the struct pointed to by target as the schema of the configuration
+// under the given path in the default Config.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
-func Unmarshal(path string, target any) error {
-	return defaultConfig.Load().Unmarshal(path, target)
+func Describe(path string, target any) error {
+	return defaultConfig.Load().Describe(path, target)
+}
+
+// Schema returns the documentations of all keys registered by konf.Describe, sorted by path.
+func Schema() []KeyDoc {
+	return defaultConfig.Load().Schema()
 }
 
 // OnChange registers a callback function that is executed
@@ -44,16 +64,22 @@ func Unmarshal(path string, target any) error {
 // The register function must be non-blocking and usually completes instantly.
 // If it requires a long time to complete, it should be executed in a separate goroutine.
 //
+// The validation of onChange and paths is the same as Config.OnChange.
+//
 // This method is concurrent-safe.
 func OnChange(onChange func(), paths ...string) {
-	defaultConfig.Load().OnChange(func(*Config) { onChange() }, paths...)
+	var callback func(*Config)
+	if onChange != nil {
+		callback = func(*Config) { onChange() }
+	}
+	defaultConfig.Load().registerOnChange(callback, paths, 2) //nolint:mnd
 }
 
 // Explain provides information about how default Config resolve each value
 // from loaders for the given path. It blur sensitive information.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
-func Explain(path string) string {
-	return defaultConfig.Load().Explain(path)
+func Explain(path string, opts ...ExplainOption) string {
+	return defaultConfig.Load().Explain(path, opts...)
 }
 
 // SetDefault sets the given Config as the default Config.
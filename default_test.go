@@ -5,6 +5,7 @@ package konf_test
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
 	"github.com/nil-go/konf"
@@ -46,11 +47,22 @@ func TestGet_error(t *testing.T) {
 	assert.True(t, !konf.Get[bool]("config"))
 	expected := `level=WARN msg="Could not read config, return empty value instead."` +
 		` path=config type=bool` +
-		` error="decode: cannot parse '' as bool: strconv.ParseBool: parsing \"string\": invalid syntax"` +
+		` error="decode: cannot parse 'config' as bool: strconv.ParseBool: parsing \"string\": invalid syntax"` +
 		"\n"
 	assert.Equal(t, expected, buf.String())
 }
 
+func TestOnChange_invalid(t *testing.T) {
+	buf := &buffer{}
+	konf.SetDefault(konf.New(konf.WithLogHandler(logHandler(buf))))
+
+	konf.OnChange(nil)
+	expected := `level=WARN msg="OnChange is nil, the registration has no effects." caller=default_test.go` + "\n"
+	assert.Equal(t, expected, defaultCallerPattern.ReplaceAllString(buf.String(), "default_test.go"))
+}
+
+var defaultCallerPattern = regexp.MustCompile(`/\S*/default_test\.go:\d+`)
+
 func TestOnChange(t *testing.T) {
 	var config konf.Config
 	watcher := stringWatcher{key: "Config", value: make(chan string)}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Defaults returns a Loader which seeds the given default values,
+// as a structured defaults document instead of defaults per field.
+//
+// Config.Load places it at the lowest precedence regardless of the order of loading,
+// so that the values from any other loader override it. So Config.Explain shows loader[defaults]
+// for the values which fall back to defaults.
+// The values should be nested like `{parent: {child: {key: 1}}}`, and they're copied as loading.
+func Defaults(values map[string]any) Loader { //nolint:ireturn
+	return &defaults{values: values}
+}
+
+type defaults struct {
+	values map[string]any
+}
+
+func (d *defaults) Load() (map[string]any, error) {
+	// Copy the values since Config transforms the keys in place.
+	values := make(map[string]any, len(d.values))
+	maps.Merge(values, d.values)
+
+	return values, nil
+}
+
+func (*defaults) String() string {
+	return "defaults"
+}
+
+func isDefaults(loader Loader) bool {
+	_, ok := loader.(*defaults)
+
+	return ok
+}
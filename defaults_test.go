@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestDefaults(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]any{"Server": map[string]any{"Host": "localhost", "Port": 8080, "TLS": false}}
+	defaults := konf.Defaults(values)
+	base := konf.Defaults(map[string]any{"server": map[string]any{"timeout": "1s", "port": 80}})
+	loader := mapLoader{"server": map[string]any{"host": "example.com"}}
+
+	config := konf.New()
+	assert.NoError(t, config.Load(loader))
+	assert.NoError(t, config.Load(base))
+	assert.NoError(t, config.Load(defaults))
+	assert.Equal(t, []konf.Loader{base, defaults, loader}, config.Precedence())
+
+	var server struct {
+		Host    string
+		Port    int
+		TLS     bool
+		Timeout string
+	}
+	assert.NoError(t, config.Unmarshal("server", &server))
+	assert.Equal(t, "example.com", server.Host)
+	assert.Equal(t, 8080, server.Port)
+	assert.Equal(t, "1s", server.Timeout)
+
+	expected := `server.host has value[example.com] that is loaded by loader[map].
+Here are other value(loader)s:
+  - localhost(defaults)
+
+server.port has value[8080] that is loaded by loader[defaults].
+Here are other value(loader)s:
+  - 80(defaults)
+
+server.timeout has value[1s] that is loaded by loader[defaults].
+
+server.tls has value[false] that is loaded by loader[defaults].
+
+`
+	assert.Equal(t, expected, config.Explain("server"))
+	// The given values are not changed by loading.
+	assert.Equal(t, map[string]any{"Server": map[string]any{"Host": "localhost", "Port": 8080, "TLS": false}}, values)
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(
+		konf.WithDefaults(map[string]any{"server": map[string]any{"host": "localhost", "port": 80}}),
+		konf.WithDefaults(map[string]any{"server": map[string]any{"port": 8080}}),
+	)
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "example.com"}}))
+
+	assert.Equal[any](t, map[string]any{"host": "example.com", "port": 8080}, config.Get("server"))
+	expected := `server.host has value[example.com] that is loaded by loader[map].
+Here are other value(loader)s:
+  - localhost(defaults)
+
+server.port has value[8080] that is loaded by loader[defaults].
+Here are other value(loader)s:
+  - 80(defaults)
+
+`
+	assert.Equal(t, expected, config.Explain("server"))
+}
+
+func TestWithDefaults_mutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	opts := []konf.Option{
+		konf.WithMutuallyExclusive("a", "b"),
+		konf.WithDefaults(map[string]any{"a": 1, "b": 2, "c": 3}),
+		konf.WithDefaults(map[string]any{"d": 4}),
+	}
+
+	// konf.New ignores the invalid defaults with a warning log.
+	buf := &buffer{}
+	config := konf.New(append(opts, konf.WithLogHandler(logHandler(buf)))...)
+	assert.True(t, strings.HasPrefix(buf.String(), `level=WARN msg="Invalid options have been ignored."`))
+	assert.True(t, !config.Exists([]string{"c"}))
+	assert.True(t, config.Exists([]string{"d"}))
+
+	_, err := konf.NewBuilder(opts...).Build()
+	assert.EqualError(t, err, "konf.WithDefaults has invalid values: load configuration: "+
+		"mutually exclusive keys are set together: a, b: invalid options")
+	assert.True(t, errors.Is(err, konf.ErrInvalidOptions))
+}
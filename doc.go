@@ -45,7 +45,7 @@ in the source value of "username" (case insensitive).
 
 You can change the behavior of konf by using struct tags.
 The default struct tag that konf looks for is "konf"
-but you can customize it using DecoderConfig.
+but you can customize it using konf.WithTagName, e.g. reusing the existing "mapstructure" tags.
 
 # Renaming Fields
 
@@ -94,6 +94,47 @@ Now the following input would be accepted:
 	    "name": "alice",
 	}
 
+# Numeric Bounds
+
+To validate the value of numeric field, append ",min=" and/or ",max=" to
+your tag value with the inclusive bounds. Example:
+
+	type Pool struct {
+	    Workers int `konf:"workers,min=1,max=1024"`
+	}
+
+The violations of all fields are aggregated into one error returned by [Config.Unmarshal],
+which names each field and its offending value.
+
+# Validation
+
+With [WithTagValidation], the decoded struct is validated against the `validate` tags
+of its fields, e.g. required, min, max, oneof, url and ip. Example:
+
+	type Server struct {
+	    Port int    `validate:"min=1,max=65535"`
+	    Mode string `validate:"oneof=dev prod"`
+	}
+
+The custom rules can be registered by [WithValidator].
+For the structs registered by [Config.Describe], the changes from watchers which fail the validation
+are rejected, and the configuration keeps the last good values.
+
+The options which can't coexist are declared by [WithMutuallyExclusive],
+which is checked on each load and change regardless of the tag validation.
+
+# Interfaces
+
+To decode into the interface type, register its implementations keyed by the discriminator field
+with [RegisterImpl]. Example:
+
+	konf.RegisterImpl[Storage]("type", map[string]func() Storage{
+	    "s3":   func() Storage { return &S3{} },
+	    "disk": func() Storage { return &Disk{} },
+	})
+
+Then the input {type: s3, bucket: ...} is decoded into *S3 in the field with type Storage.
+
 # Unexported fields
 
 Since unexported (private) struct fields cannot be set outside the package
@@ -0,0 +1,270 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// KeyDiff is the difference of the value for the path between two configurations.
+type KeyDiff struct {
+	Path string
+	// A and B are the values of the configurations, or nil if the configuration has no value for the path.
+	A, B any
+}
+
+// Equal reports whether the given configurations have the same merged values,
+// with the same canonicalization used by Config.Fingerprint, so that the loaders provide the values are ignored.
+// The nil configuration is equal to the empty configuration.
+//
+// This function is concurrent-safe.
+func Equal(a, b *Config) bool {
+	return len(DiffConfigs(a, b)) == 0
+}
+
+// DiffConfigs returns the differences of the merged values between the given configurations, sorted by path.
+// The values are compared with the same canonicalization used by Config.Fingerprint,
+// and the paths are joined with the delimiter of the first non-nil configuration.
+//
+// This function is concurrent-safe.
+func DiffConfigs(a, b *Config) []KeyDiff {
+	aLeaves, bLeaves := a.leaves(), b.leaves()
+	var delim string
+	switch {
+	case a != nil:
+		delim = a.delim()
+	case b != nil:
+		delim = b.delim()
+	}
+
+	var diffs []KeyDiff
+	add := func(segments []string, aValue, bValue any) {
+		diffs = append(diffs, KeyDiff{Path: strings.Join(segments, delim), A: aValue, B: bValue})
+	}
+	aIndex, bIndex := 0, 0
+	for aIndex < len(aLeaves) || bIndex < len(bLeaves) {
+		switch {
+		case bIndex == len(bLeaves):
+			add(aLeaves[aIndex].segments, aLeaves[aIndex].value, nil)
+			aIndex++
+		case aIndex == len(aLeaves):
+			add(bLeaves[bIndex].segments, nil, bLeaves[bIndex].value)
+			bIndex++
+		default:
+			aLeaf, bLeaf := aLeaves[aIndex], bLeaves[bIndex]
+			switch compare := slices.Compare(aLeaf.segments, bLeaf.segments); {
+			case compare < 0:
+				add(aLeaf.segments, aLeaf.value, nil)
+				aIndex++
+			case compare > 0:
+				add(bLeaf.segments, nil, bLeaf.value)
+				bIndex++
+			default:
+				if canonical(aLeaf.value) != canonical(bLeaf.value) {
+					add(aLeaf.segments, aLeaf.value, bLeaf.value)
+				}
+				aIndex++
+				bIndex++
+			}
+		}
+	}
+
+	return diffs
+}
+
+// Fingerprint returns the fingerprint of the merged values, which is the first 8 bytes of sha256 hash
+// of the canonical form of each leaf value in hex. It's the same if the merged values are the same,
+// regardless of the loaders provide the values. The canonical form is tagged with the kind of value,
+// so the string "8080" and the number 8080 are different, while int 8080 and float64 8080 are the same.
+//
+// This method is concurrent-safe.
+func (c *Config) Fingerprint() string {
+	if c == nil { // To support nil
+		return fingerprintOf(nil, "")
+	}
+
+	return fingerprintOf(c.leaves(), c.root().delim())
+}
+
+func fingerprint(values map[string]any, delim string) string {
+	return fingerprintOf(leavesOf(values), delim)
+}
+
+func fingerprintOf(leaves []leaf, delim string) string {
+	hash := sha256.New()
+	for _, leaf := range leaves {
+		fmt.Fprintf(hash, "%q=%s\n", strings.Join(leaf.segments, delim), canonical(leaf.value))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+type leaf struct {
+	segments []string
+	value    any
+}
+
+// leaves returns the leaf values of the merged values, sorted by the key segments.
+func (c *Config) leaves() []leaf {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	root := c.root()
+	var keys []string
+	if c.parent != nil {
+		keys = root.splitPath(c.prefix)
+	}
+	values, _ := root.providers.sub(keys).(map[string]any)
+
+	return leavesOf(values)
+}
+
+// leavesOf returns the leaf values of the given values, sorted by the key segments.
+func leavesOf(values map[string]any) []leaf {
+	var leaves []leaf
+	var walk func(segments []string, value any)
+	walk = func(segments []string, value any) {
+		_, value = maps.Unpack(value)
+		if values, ok := value.(map[string]any); ok {
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				walk(append(slices.Clip(segments), key), values[key])
+			}
+
+			return
+		}
+		if len(segments) > 0 {
+			leaves = append(leaves, leaf{segments: segments, value: value})
+		}
+	}
+	walk(nil, values)
+
+	return leaves
+}
+
+// canonical returns the canonical form of the value, which is tagged with the kind of each scalar,
+// and encodes the elements of slices and maps one by one, so that different values never have the same form,
+// e.g. "8080" and 8080, or []any{"a b"} and []any{"a", "b"}.
+// The numbers of different types with the same value have the same form, e.g. int 8080 and float64 8080.
+func canonical(value any) string {
+	var builder strings.Builder
+	writeCanonical(&builder, value)
+
+	return builder.String()
+}
+
+func writeCanonical(builder *strings.Builder, value any) { //nolint:cyclop,funlen
+	_, value = maps.Unpack(value)
+	switch value := value.(type) {
+	case nil:
+		builder.WriteString("null")
+
+		return
+	case json.Number:
+		builder.WriteString("number:")
+		if number, err := value.Int64(); err == nil {
+			builder.WriteString(strconv.FormatInt(number, 10))
+		} else if number, err := value.Float64(); err == nil {
+			builder.WriteString(strconv.FormatFloat(number, 'f', -1, 64))
+		} else {
+			builder.WriteString(value.String())
+		}
+
+		return
+	case time.Time:
+		builder.WriteString("time:")
+		builder.WriteString(value.Format(time.RFC3339Nano))
+
+		return
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.String:
+		builder.WriteString("string:")
+		quoted, _ := json.Marshal(val.String()) // It never fails for string.
+		builder.Write(quoted)
+	case reflect.Bool:
+		builder.WriteString("bool:")
+		builder.WriteString(strconv.FormatBool(val.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		builder.WriteString("number:")
+		builder.WriteString(strconv.FormatInt(val.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		builder.WriteString("number:")
+		builder.WriteString(strconv.FormatUint(val.Uint(), 10))
+	case reflect.Float32:
+		builder.WriteString("number:")
+		builder.WriteString(strconv.FormatFloat(val.Float(), 'f', -1, 32))
+	case reflect.Float64:
+		builder.WriteString("number:")
+		builder.WriteString(strconv.FormatFloat(val.Float(), 'f', -1, 64))
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, val.Len())
+			reflect.Copy(reflect.ValueOf(bytes), val)
+			builder.WriteString("bytes:")
+			builder.WriteString(base64.StdEncoding.EncodeToString(bytes))
+
+			return
+		}
+		builder.WriteString("[")
+		for i := range val.Len() {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			writeCanonical(builder, val.Index(i).Interface())
+		}
+		builder.WriteString("]")
+	case reflect.Map:
+		keys := make([]string, 0, val.Len())
+		values := make(map[string]any, val.Len())
+		for iter := val.MapRange(); iter.Next(); {
+			key := fmt.Sprint(iter.Key().Interface())
+			keys = append(keys, key)
+			values[key] = iter.Value().Interface()
+		}
+		slices.Sort(keys)
+		builder.WriteString("{")
+		for i, key := range keys {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			quoted, _ := json.Marshal(key) // It never fails for string.
+			builder.Write(quoted)
+			builder.WriteString(":")
+			writeCanonical(builder, values[key])
+		}
+		builder.WriteString("}")
+	case reflect.Pointer, reflect.Interface:
+		if val.IsNil() {
+			builder.WriteString("null")
+
+			return
+		}
+		writeCanonical(builder, val.Elem().Interface())
+	default:
+		builder.WriteString(val.Type().String())
+		builder.WriteString(":")
+		quoted, _ := json.Marshal(fmt.Sprintf("%v", value)) // It never fails for string.
+		builder.Write(quoted)
+	}
+}
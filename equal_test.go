@@ -0,0 +1,122 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	t.Parallel()
+
+	file := konf.New()
+	assert.NoError(t, file.Load(mapLoader{
+		"server": map[string]any{"host": "localhost", "port": 8080, "timeout": "1s"},
+	}))
+	layered := konf.New()
+	assert.NoError(t, layered.Load(mapLoader{"server": map[string]any{"host": "localhost", "port": 80}}))
+	assert.NoError(t, layered.Load(mapLoader{"server": map[string]any{"port": "8080", "tls": true}}))
+
+	testcases := []struct {
+		description string
+		a, b        *konf.Config
+		expected    []konf.KeyDiff
+	}{
+		{
+			description: "different",
+			a:           file,
+			b:           layered,
+			expected: []konf.KeyDiff{
+				{Path: "server.port", A: 8080, B: "8080"},
+				{Path: "server.timeout", A: "1s"},
+				{Path: "server.tls", B: true},
+			},
+		},
+		{
+			description: "same",
+			a:           file,
+			b:           file,
+		},
+		{
+			description: "nil and empty",
+			b:           konf.New(),
+		},
+		{
+			description: "nil and non-empty",
+			b:           layered,
+			expected: []konf.KeyDiff{
+				{Path: "server.host", B: "localhost"},
+				{Path: "server.port", B: "8080"},
+				{Path: "server.tls", B: true},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testcase.expected, konf.DiffConfigs(testcase.a, testcase.b))
+			assert.Equal(t, len(testcase.expected) == 0, konf.Equal(testcase.a, testcase.b))
+			assert.Equal(t, len(testcase.expected) == 0, testcase.a.Fingerprint() == testcase.b.Fingerprint())
+		})
+	}
+}
+
+func TestEqual_canonical(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		a, b        map[string]any
+		equal       bool
+	}{
+		{
+			description: "slice elements",
+			a:           map[string]any{"tags": []any{"a b"}},
+			b:           map[string]any{"tags": []any{"a", "b"}},
+		},
+		{
+			description: "string and number",
+			a:           map[string]any{"port": "8080"},
+			b:           map[string]any{"port": 8080},
+		},
+		{
+			description: "string and bool",
+			a:           map[string]any{"tls": "true"},
+			b:           map[string]any{"tls": true},
+		},
+		{
+			description: "bytes and string",
+			a:           map[string]any{"key": []byte("hi")},
+			b:           map[string]any{"key": "[104 105]"},
+		},
+		{
+			description: "nested map in slice",
+			a:           map[string]any{"hosts": []any{map[string]any{"a": "1,b"}}},
+			b:           map[string]any{"hosts": []any{map[string]any{"a": "1", "b": ""}}},
+		},
+		{
+			description: "numbers of different types",
+			a:           map[string]any{"port": 8080, "ratio": 0.5, "ports": []int{80}},
+			b:           map[string]any{"port": 8080.0, "ratio": float32(0.5), "ports": []any{int64(80)}},
+			equal:       true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := konf.New(), konf.New()
+			assert.NoError(t, a.Load(mapLoader(testcase.a)))
+			assert.NoError(t, b.Load(mapLoader(testcase.b)))
+			assert.Equal(t, testcase.equal, konf.Equal(a, b))
+			assert.Equal(t, testcase.equal, a.Fingerprint() == b.Fingerprint())
+		})
+	}
+}
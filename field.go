@@ -0,0 +1,50 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"reflect"
+	"slices"
+)
+
+// visitFields calls visit for the exported fields of the struct type the same way as Config.Unmarshal,
+// including the fields of the squashed structs, with the key of the field in the configuration
+// (mapped by the configured key mapper), the field name and the options in the struct tag.
+// The index of the field is relative to typ so it can be used with reflect.Value.FieldByIndex.
+// It stops once visit returns false, and reports whether it has visited all fields.
+func (c *Config) visitFields(
+	typ reflect.Type,
+	visit func(field reflect.StructField, key, name string, tags []string) bool,
+) bool {
+	return c.visitFieldsAt(typ, nil, visit)
+}
+
+func (c *Config) visitFieldsAt(
+	typ reflect.Type,
+	index []int,
+	visit func(field reflect.StructField, key, name string, tags []string) bool,
+) bool {
+	decoder := c.decoder()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue // Same as Config.Unmarshal, unexported fields are not settable.
+		}
+		field.Index = append(slices.Clip(index), i)
+
+		key, name, tags := decoder.FieldKey(field)
+		if slices.Contains(tags, "squash") {
+			if field.Type.Kind() == reflect.Struct && !c.visitFieldsAt(field.Type, field.Index, visit) {
+				return false
+			}
+
+			continue
+		}
+		if !visit(field, key, name, tags) {
+			return false
+		}
+	}
+
+	return true
+}
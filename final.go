@@ -0,0 +1,154 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type (
+	// Encoder encodes the given value into bytes, e.g. json.Marshal.
+	Encoder func(v any) ([]byte, error)
+
+	// FinalSnapshot is the content written by konf.WithFinalSnapshot when Config.Watch returns,
+	// e.g. for the post-mortem analysis. The sensitive values are blurred.
+	FinalSnapshot struct {
+		// Time is the time when the snapshot is taken.
+		Time time.Time `json:"time"`
+		// Version is the number of changes that have been applied by Config.Watch.
+		Version uint64 `json:"version"`
+		// Fingerprint is the same as Config.Fingerprint.
+		Fingerprint string `json:"fingerprint"`
+		// Loaders are the status of loaders, from the lowest to the highest precedence.
+		Loaders []FinalLoaderStatus `json:"loaders"`
+		// Values are the merged values with the original keys.
+		Values map[string]any `json:"values"`
+	}
+
+	// FinalLoaderStatus is the status of a loader in FinalSnapshot.
+	FinalLoaderStatus struct {
+		Loader   string `json:"loader"`
+		Watched  bool   `json:"watched"`
+		Disabled bool   `json:"disabled,omitempty"`
+		// LastError is the last error reported by the loader, or empty if no error.
+		LastError string `json:"last_error,omitempty"`
+	}
+
+	finalSnapshot struct {
+		path   string
+		encode Encoder
+	}
+)
+
+// finalSnapshotTimeout is the max duration to wait for writing the final snapshot,
+// so that it never blocks shutdown.
+const finalSnapshotTimeout = 5 * time.Second
+
+// FinalSnapshot returns the FinalSnapshot of the current merged configuration.
+//
+// This method is concurrent-safe.
+func (c *Config) FinalSnapshot() FinalSnapshot {
+	if c == nil { // To support nil
+		return FinalSnapshot{}
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.FinalSnapshot()
+	}
+
+	snapshot := FinalSnapshot{
+		Time:        c.timeSource().Now(),
+		Version:     c.version.Load(),
+		Fingerprint: c.Fingerprint(),
+	}
+	for _, state := range c.loaderStates() {
+		status := FinalLoaderStatus{
+			Loader:   fmt.Sprint(state.Loader),
+			Watched:  state.Watched,
+			Disabled: state.Disabled,
+		}
+		if state.LastError != nil {
+			status.LastError = state.LastError.Error()
+		}
+		snapshot.Loaders = append(snapshot.Loaders, status)
+	}
+	snapshot.Values, _ = c.export("", c.providers.sub(nil), false).(map[string]any)
+
+	return snapshot
+}
+
+// writeFinalSnapshot writes the FinalSnapshot to the path provided by konf.WithFinalSnapshot.
+// It only logs the failure, and gives up waiting after finalSnapshotTimeout.
+func (c *Config) writeFinalSnapshot(ctx context.Context) {
+	if c.finalSnapshot == nil {
+		return
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- writeFileAtomic(c.finalSnapshot.path, c.finalSnapshot.encode, c.FinalSnapshot())
+	}()
+
+	timeout, stop := c.timeSource().NewTimer(finalSnapshotTimeout)
+	defer stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			c.log(ctx, slog.LevelWarn,
+				"Error when writing final configuration snapshot.",
+				slog.String("path", c.finalSnapshot.path),
+				slog.Any("error", err),
+			)
+
+			return
+		}
+		c.log(ctx, slog.LevelDebug,
+			"Final configuration snapshot has been written.",
+			slog.String("path", c.finalSnapshot.path),
+		)
+	case <-timeout:
+		c.log(ctx, slog.LevelWarn,
+			"Final configuration snapshot has not been written in time, the writing has been abandoned.",
+			slog.String("path", c.finalSnapshot.path),
+			slog.Duration("timeout", finalSnapshotTimeout),
+		)
+	}
+}
+
+// writeFileAtomic writes the encoded value to a temporary file in the same directory,
+// and then renames it to the given path, so that the file is never partially written.
+func writeFileAtomic(path string, encode Encoder, value any) error {
+	data, err := encode(value)
+	if err != nil {
+		return fmt.Errorf("encode final snapshot: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name()) // It fails once the file has been renamed.
+	}()
+	if _, e := file.Write(data); e != nil {
+		return fmt.Errorf("write temporary file: %w", e)
+	}
+	if e := file.Sync(); e != nil {
+		return fmt.Errorf("sync temporary file: %w", e)
+	}
+	if e := file.Close(); e != nil {
+		return fmt.Errorf("close temporary file: %w", e)
+	}
+	if e := os.Rename(file.Name(), path); e != nil {
+		return fmt.Errorf("rename temporary file: %w", e)
+	}
+
+	return nil
+}
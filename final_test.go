@@ -0,0 +1,92 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestWithFinalSnapshot(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "final.json")
+	config := konf.New(konf.WithFinalSnapshot(path, json.Marshal))
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"port": 80}, "password": "secret"},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(mapLoader{"level": "info"}))
+	assert.NoError(t, config.Load(watcher))
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) })
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"server": map[string]any{"port": 8080}, "password": "secret"}
+	<-changed
+	_, err := os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	cancel()
+	<-stopped
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var snapshot konf.FinalSnapshot
+	assert.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.True(t, !snapshot.Time.IsZero())
+	assert.Equal(t, uint64(1), snapshot.Version)
+	assert.Equal(t, config.Fingerprint(), snapshot.Fingerprint)
+	assert.Equal(t, []konf.FinalLoaderStatus{
+		{Loader: "map", Watched: true},
+		{Loader: "map", Watched: true},
+	}, snapshot.Loaders)
+	assert.Equal(t, map[string]any{
+		"level":    "info",
+		"server":   map[string]any{"port": 8080.0},
+		"password": "******",
+	}, snapshot.Values)
+
+	// The keys of schema.
+	var schema map[string]any
+	assert.NoError(t, json.Unmarshal(data, &schema))
+	for _, key := range []string{"time", "version", "fingerprint", "loaders", "values"} {
+		_, ok := schema[key]
+		assert.True(t, ok)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries)) // No temporary file is left.
+}
+
+func TestWithFinalSnapshot_error(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	path := filepath.Join(t.TempDir(), "non-existing", "final.json")
+	config := konf.New(
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithFinalSnapshot(path, json.Marshal),
+	)
+	assert.NoError(t, config.Load(mapLoader{"level": "info"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, config.Watch(ctx))
+	assert.True(t, strings.Contains(buf.String(), `level=WARN msg="Error when writing final configuration snapshot."`))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// UnmarshalFirst decodes the value of the first path which exists in the Config into the given object
+// pointed to by target, the same as Config.Unmarshal, and returns the path used,
+// e.g. config.UnmarshalFirst([]string{"server.http.port", "port"}, &port) during the migration of keys,
+// so that the caller can log the deprecation warning if the legacy path is used.
+// The paths are case-insensitive unless konf.WithCaseSensitive is set.
+//
+// If none of the paths exists, it returns empty path, and the target is only validated
+// with the first path if konf.WithTagValidation is set.
+//
+// This method is concurrent-safe.
+func (c *Config) UnmarshalFirst(paths []string, target any) (string, error) {
+	if c == nil { // To support nil
+		return "", nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		parentPaths := c.firstParentPaths(paths)
+		path, err := c.parent.UnmarshalFirst(parentPaths, target)
+		if path == "" {
+			return "", err
+		}
+
+		return paths[slices.Index(parentPaths, path)], err
+	}
+
+	path, value, err := c.first(paths)
+	if err != nil {
+		return path, err
+	}
+	if path == "" && len(paths) > 0 {
+		return "", c.decode(paths[0], nil, target)
+	}
+
+	return path, c.decode(path, value, target)
+}
+
+// ExistsAny returns the first given path which exists in the Config, or false if none of them exists.
+// The paths are case-insensitive unless konf.WithCaseSensitive is set.
+//
+// This method is concurrent-safe.
+func (c *Config) ExistsAny(paths ...string) (string, bool) {
+	if c == nil { // To support nil
+		return "", false
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		parentPaths := c.firstParentPaths(paths)
+		path, ok := c.parent.ExistsAny(parentPaths...)
+		if !ok {
+			return "", false
+		}
+
+		return paths[slices.Index(parentPaths, path)], true
+	}
+
+	path, _, err := c.first(paths)
+
+	return path, path != "" && err == nil
+}
+
+// OnChangeFirst registers a callback function which is executed with the path used by Config.UnmarshalFirst
+// when the value it decodes changes, i.e. the value of the first path which exists changes,
+// or the first path which exists switches, e.g. the path with higher priority appears.
+// The path is empty if none of the paths exists. The validation of paths is the same as Config.OnChange.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangeFirst(onChange func(config *Config, path string), paths ...string) {
+	var callback func(*Config)
+	if onChange != nil {
+		var mutex sync.Mutex
+		lastPath, lastValue, _ := c.first(paths)
+		callback = func(config *Config) {
+			path, value, _ := config.first(paths)
+
+			mutex.Lock()
+			changed := path != lastPath || !maps.Equal(value, lastValue)
+			lastPath, lastValue = path, value
+			mutex.Unlock()
+
+			if changed {
+				onChange(config, path)
+			}
+		}
+	}
+	c.registerOnChange(callback, paths, 2) //nolint:mnd
+}
+
+// firstParentPaths returns the paths in the parent Config of the given paths in the view created by Config.Sub,
+// one by one so that the path used by the parent maps back to the given path.
+// The empty path stays empty since it's invalid, same as Config.OnChange.
+func (c *Config) firstParentPaths(paths []string) []string {
+	parentPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if strings.Trim(path, c.parent.delim()) != "" {
+			path = c.parentPath(path)
+		}
+		parentPaths = append(parentPaths, path)
+	}
+
+	return parentPaths
+}
+
+// first returns the first path which exists and its value, or empty path if none of them exists.
+// It stops at the path whose computed key fails to compute.
+func (c *Config) first(paths []string) (string, any, error) {
+	for _, path := range paths {
+		if strings.Trim(path, c.delim()) == "" {
+			continue // Same as Config.OnChange, the empty path is invalid.
+		}
+		value, err := c.sub(c.splitPath(path))
+		if err != nil || value != nil {
+			return path, value, err
+		}
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_UnmarshalFirst(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		paths       []string
+		expected    int
+		path        string
+		err         string
+	}{
+		{
+			description: "first path",
+			paths:       []string{"server.http.port", "port"},
+			expected:    9090,
+			path:        "server.http.port",
+		},
+		{
+			description: "fallback path",
+			paths:       []string{"server.grpc.port", "", "PORT"},
+			expected:    8080,
+			path:        "PORT",
+		},
+		{
+			description: "no path exists",
+			paths:       []string{"server.grpc.port"},
+		},
+		{
+			description: "no path exists with validation",
+			opts:        []konf.Option{konf.WithTagValidation()},
+			paths:       []string{"server.grpc.port"},
+			err:         "validate: 'server.grpc.port.port' is required",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader{"port": 8080, "server": map[string]any{"http": map[string]any{"port": 9090}}}))
+
+			if testcase.err != "" {
+				var value struct {
+					Port int `validate:"required"`
+				}
+				_, err := config.UnmarshalFirst(testcase.paths, &value)
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			var value int
+			path, err := config.UnmarshalFirst(testcase.paths, &value)
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.path, path)
+			assert.Equal(t, testcase.expected, value)
+
+			path, ok := config.ExistsAny(testcase.paths...)
+			assert.Equal(t, testcase.path, path)
+			assert.Equal(t, testcase.path != "", ok)
+		})
+	}
+}
+
+func TestConfig_ExistsAny(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		path        string
+		expected    bool
+	}{
+		{
+			description: "leaf",
+			path:        "server.port",
+			expected:    true,
+		},
+		{
+			description: "intermediate map",
+			path:        "server",
+			expected:    true,
+		},
+		{
+			description: "case insensitive",
+			path:        "Server.PORT",
+			expected:    true,
+		},
+		{
+			description: "case sensitive",
+			opts:        []konf.Option{konf.WithCaseSensitive()},
+			path:        "Server.PORT",
+		},
+		{
+			description: "custom delimiter",
+			opts:        []konf.Option{konf.WithDelimiter("/")},
+			path:        "server/port",
+			expected:    true,
+		},
+		{
+			description: "missing",
+			path:        "server.host",
+		},
+		{
+			description: "empty path",
+			path:        "",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"port": 8080}}))
+			_, ok := config.ExistsAny(testcase.path)
+			assert.Equal(t, testcase.expected, ok)
+		})
+	}
+
+	var config konf.Config
+	_, ok := config.ExistsAny("server.port")
+	assert.Equal(t, false, ok)
+}
+
+func TestConfig_OnChangeFirst(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	watcher := mapWatcher{values: map[string]any{"port": 8080, "host": "localhost"}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	paths := make(chan string, 3)
+	config.OnChangeFirst(func(_ *konf.Config, path string) { paths <- path }, "server.port", "port")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The value of the winner changes.
+	watcher.change <- map[string]any{"port": 8081, "host": "localhost"}
+	assert.Equal(t, "port", <-paths)
+	// The winner switches since the path with higher priority appears.
+	watcher.change <- map[string]any{"port": 8081, "server": map[string]any{"port": 8081}}
+	assert.Equal(t, "server.port", <-paths)
+	// The change of the path which does not win is ignored.
+	watcher.change <- map[string]any{"port": 8082, "server": map[string]any{"port": 8081}}
+	// The winner switches back since the path with higher priority disappears.
+	watcher.change <- map[string]any{"port": 8082}
+	assert.Equal(t, "port", <-paths)
+	// The changes are dispatched in order, so the unexpected callback if any is received before this one.
+	watcher.change <- map[string]any{"port": 8083}
+	assert.Equal(t, "port", <-paths)
+	assert.Equal(t, 0, len(paths))
+}
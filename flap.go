@@ -0,0 +1,261 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// FlapOption configures the flap detection provided by konf.WithFlapDetection with specific options.
+	FlapOption  func(*flapOptions)
+	flapOptions struct {
+		window    time.Duration
+		threshold int
+		suppress  bool
+	}
+)
+
+// SuppressFlapping suppresses the dispatching to the callbacks registered by Config.OnChange
+// for the keys which are flapping, until all flapping keys stabilize, i.e. they have not changed in the window.
+// Then the suppressed callbacks are executed once with the latest configuration, and the ChangeEvent
+// of the latest suppressed change with the keys of all suppressed changes.
+// The callbacks for other changed keys are executed as usual.
+func SuppressFlapping() FlapOption {
+	return func(options *flapOptions) {
+		options.suppress = true
+	}
+}
+
+// ErrFlapping is the error reported to the callback provided by konf.WithOnStatus
+// if the value of a key changes more than the threshold provided by konf.WithFlapDetection in the window.
+var ErrFlapping = errors.New("value changes too frequently")
+
+type (
+	flaps struct {
+		keys       map[string]*flap
+		suppressed suppression
+		timer      bool // Whether the timer for delivering the suppressed callbacks is pending.
+		mutex      sync.Mutex
+	}
+	// suppression holds the callbacks suppressed by konf.SuppressFlapping,
+	// and the merged event of the changes they have missed.
+	suppression struct {
+		event     *ChangeEvent
+		onChanges []*subscription
+	}
+	flap struct {
+		changes  []flapChange // At most threshold+1 changes in the window.
+		flapping bool
+	}
+	flapChange struct {
+		time   time.Time
+		loader Loader
+	}
+)
+
+// record records the changes of the event, and returns the keys which start flapping with the changes.
+// The keys which have not changed in the window are removed, so that the memory is bounded
+// by the keys changed recently.
+func (f *flaps) record(option flapOptions, event *ChangeEvent) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.expire(option, event.Time)
+	if f.keys == nil {
+		f.keys = make(map[string]*flap)
+	}
+	var started []string
+	for _, key := range event.Keys {
+		state := f.keys[key]
+		if state == nil {
+			state = &flap{}
+			f.keys[key] = state
+		}
+		state.changes = append(state.changes, flapChange{time: event.Time, loader: event.Loader})
+		if len(state.changes) > option.threshold+1 {
+			state.changes = slices.Delete(state.changes, 0, len(state.changes)-option.threshold-1)
+		}
+		if !state.flapping && len(state.changes) > option.threshold {
+			state.flapping = true
+			started = append(started, key)
+		}
+	}
+
+	return started
+}
+
+func (f *flaps) expire(option flapOptions, now time.Time) {
+	for key, state := range f.keys {
+		state.changes = slices.DeleteFunc(state.changes, func(change flapChange) bool {
+			return now.Sub(change.time) >= option.window
+		})
+		if len(state.changes) == 0 {
+			delete(f.keys, key) // The key has stabilized.
+		}
+	}
+}
+
+// flapping returns the keys which are flapping at the given time, sorted.
+func (f *flaps) flapping(option flapOptions, now time.Time) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.expire(option, now)
+	var keys []string
+	for key, state := range f.keys {
+		if state.flapping {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// loaders returns the distinct loaders which have changed the key in the window.
+func (f *flaps) loaders(key string) []Loader {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var loaders []Loader
+	if state := f.keys[key]; state != nil {
+		for _, change := range state.changes {
+			if !slices.ContainsFunc(loaders, func(loader Loader) bool { return sameLoader(loader, change.loader) }) {
+				loaders = append(loaders, change.loader)
+			}
+		}
+	}
+
+	return loaders
+}
+
+// detectFlaps records the changes of the event for konf.WithFlapDetection, reports the keys start flapping,
+// and returns the callbacks to execute, excluding the ones suppressed by konf.SuppressFlapping.
+func (c *Config) detectFlaps(ctx context.Context, event *ChangeEvent, onChanges []*subscription) []*subscription {
+	if c.flapDetection == nil {
+		return onChanges
+	}
+	option := *c.flapDetection
+
+	for _, key := range c.flaps.record(option, event) {
+		loaders := c.flaps.loaders(key)
+		c.log(ctx, slog.LevelWarn,
+			"Configuration is flapping.",
+			slog.String("key", key),
+			slog.Any("loaders", loaders),
+			slog.Int("changes", option.threshold+1),
+			slog.Duration("window", option.window),
+		)
+		if c.onStatus != nil {
+			c.onStatus(event.Loader, false, fmt.Errorf("key %s: %w", key, ErrFlapping))
+		}
+	}
+	if !option.suppress {
+		return onChanges
+	}
+
+	flapping := c.flaps.flapping(option, event.Time)
+	if len(flapping) == 0 {
+		return onChanges
+	}
+	var stable []string
+	for _, key := range event.Keys {
+		if !slices.Contains(flapping, key) {
+			stable = append(stable, key)
+		}
+	}
+	// The callbacks for any stable changed key are still executed.
+	allowed := c.onChanges.get(func(path string) bool {
+		return slices.ContainsFunc(stable, func(key string) bool {
+			return path == "" || key == path || strings.HasPrefix(key, path+c.delim()) ||
+				strings.HasPrefix(path, key+c.delim())
+		})
+	})
+	var dispatched, suppressed []*subscription
+	for _, sub := range onChanges {
+		if slices.Contains(allowed, sub) {
+			dispatched = append(dispatched, sub)
+		} else {
+			suppressed = append(suppressed, sub)
+		}
+	}
+	if len(suppressed) > 0 {
+		c.suppressFlapping(ctx, option, event, suppressed)
+	}
+
+	return dispatched
+}
+
+// suppressFlapping holds the callbacks, and executes them once with the latest configuration
+// after all flapping keys stabilize. The event they receive is the latest suppressed change,
+// with the keys of all suppressed changes.
+func (c *Config) suppressFlapping(ctx context.Context, option flapOptions, event *ChangeEvent, subs []*subscription) {
+	c.flaps.mutex.Lock()
+	defer c.flaps.mutex.Unlock()
+
+	c.flaps.suppressed.merge(event)
+	for _, sub := range subs {
+		if !slices.Contains(c.flaps.suppressed.onChanges, sub) {
+			c.flaps.suppressed.onChanges = append(c.flaps.suppressed.onChanges, sub)
+		}
+	}
+	if c.flaps.timer {
+		return
+	}
+	c.flaps.timer = true
+
+	go func() {
+		for {
+			timer, stop := c.timeSource().NewTimer(option.window)
+			select {
+			case <-ctx.Done():
+				stop()
+
+				return
+			case <-timer:
+			}
+
+			if len(c.flaps.flapping(option, c.timeSource().Now())) > 0 {
+				continue
+			}
+			c.flaps.mutex.Lock()
+			suppressed := c.flaps.suppressed
+			c.flaps.suppressed, c.flaps.timer = suppression{}, false
+			c.flaps.mutex.Unlock()
+			if watch := c.watched.Load(); watch != nil {
+				watch.resume(suppressed.event, suppressed.onChanges)
+			}
+
+			return
+		}
+	}()
+}
+
+// merge merges the event into the suppressed event. The latest event takes precedence,
+// and the keys of the earlier events are kept, so that no missed key is lost.
+func (s *suppression) merge(event *ChangeEvent) {
+	merged := *event
+	if s.event != nil {
+		merged.Keys = slices.Concat(s.event.Keys, event.Keys)
+		slices.Sort(merged.Keys)
+		merged.Keys = slices.Compact(merged.Keys)
+		merged.Removed = slices.Clone(event.Removed)
+		for _, key := range s.event.Removed {
+			if !slices.Contains(event.Keys, key) { // Otherwise, the latest event knows whether it's removed.
+				merged.Removed = append(merged.Removed, key)
+			}
+		}
+		slices.Sort(merged.Removed)
+		merged.RestartRequired = merged.RestartRequired || s.event.RestartRequired
+	}
+	s.event = &merged
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestConfig_WithFlapDetection(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithClock(fake),
+		konf.WithFlapDetection(10*time.Second, 2, konf.SuppressFlapping()),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) {
+			if err != nil {
+				statuses <- err
+			}
+		}),
+	)
+	watcher := mapWatcher{values: map[string]any{"flag": false, "other": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(&watcher))
+
+	flags := make(chan bool, 3)
+	events := make(chan konf.ChangeEvent, 3)
+	config.OnChangeContext(func(ctx context.Context, config *konf.Config) {
+		var flag bool
+		assert.NoError(t, config.Unmarshal("flag", &flag))
+		event, _ := konf.ChangeFromContext(ctx)
+		events <- event
+		flags <- flag
+	}, konf.Keys("flag"))
+	others := make(chan int, 3)
+	config.OnChange(func(config *konf.Config) {
+		var other int
+		assert.NoError(t, config.Unmarshal("other", &other))
+		others <- other
+	}, "other")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"flag": true, "other": 0}
+	assert.Equal(t, true, <-flags)
+	watcher.change <- map[string]any{"flag": false, "other": 0}
+	assert.Equal(t, false, <-flags)
+	// The third change in the window exceeds the threshold, so it's suppressed.
+	watcher.change <- map[string]any{"flag": true, "other": 0}
+	err := <-statuses
+	assert.True(t, errors.Is(err, konf.ErrFlapping))
+	assert.EqualError(t, err, "key flag: value changes too frequently")
+	assert.Equal(t, []string{"flag"}, config.Status().Flapping)
+
+	// The callbacks for the stable keys are still executed.
+	watcher.change <- map[string]any{"flag": true, "other": 1}
+	// The changes are dispatched in order, so the suppressed callback would have been executed before.
+	assert.Equal(t, 1, <-others)
+	assert.Equal(t, 0, len(flags))
+
+	// The suppressed callbacks are executed once the key stabilizes.
+	fake.BlockUntil(1)
+	fake.Advance(10 * time.Second)
+	assert.Equal(t, true, <-flags)
+	<-events
+	<-events
+	event := <-events
+	assert.Equal(t, uint64(3), event.Version)
+	assert.Equal(t, []string{"flag"}, event.Keys)
+	assert.Equal[konf.Loader](t, &watcher, event.Loader)
+	assert.True(t, !event.Synthetic)
+	assert.Equal(t, 0, len(config.Status().Flapping))
+	assert.True(t, strings.Contains(buf.String(), `level=WARN msg="Configuration is flapping." key=flag`))
+}
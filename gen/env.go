@@ -0,0 +1,110 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package gen generates the documentation of configuration from the config struct at runtime,
+// e.g. for the --help output or the markdown table, so that the documentation never drifts from the code.
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/provider/env"
+)
+
+// EnvVar is the environment variable which sets a field of the config struct, returned by EnvVars.
+type EnvVar struct {
+	// Path is the path of the key. The `*` segment matches any key of a map.
+	Path string
+	// Name is the name of the environment variable. The `*` segment is the placeholder for the key of a map.
+	Name string
+	// Type is the Go type of the field.
+	Type string
+	// Default is the value of the field in the config struct, or nil if the value is zero or the key is secret.
+	Default any
+	// Syntax is the accepted syntax of the value if it's not a plain value, e.g. comma-separated values for slices.
+	Syntax string
+}
+
+// EnvVars returns the environment variables which set the fields of the given config struct
+// unmarshalled from the given path, with the mapping of the given env loader, sorted by path.
+// The keys are derived by the same rules as konf.Config.Unmarshal with the default tag name and delimiter,
+// including the `squash` tag option.
+//
+// By default, the name is the uppercase keys joined by "_", which must be loaded back as the same keys.
+// The aliases are the names which are mapped to the keys by the custom name splitter of the loader,
+// e.g. "DATABASE_URL" for the key "db.url", and they override the default names of the keys.
+// It returns error if any key has neither default name nor alias loaded by the loader,
+// e.g. the default name does not start with the prefix of the loader.
+func EnvVars(path string, target any, loader env.Env, aliases ...string) ([]EnvVar, error) {
+	config := konf.New()
+	if err := config.Describe(path, target); err != nil {
+		return nil, fmt.Errorf("describe: %w", err)
+	}
+
+	aliased := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		keys := loader.Keys(alias)
+		if keys == nil {
+			return nil, fmt.Errorf("alias %s is not loaded by %v", alias, loader) //nolint:err113
+		}
+		aliased[strings.ToLower(strings.Join(keys, "."))] = alias
+	}
+
+	docs := config.Schema()
+	var (
+		vars []EnvVar
+		errs []error
+	)
+	for _, doc := range docs {
+		if slices.ContainsFunc(docs, func(d konf.KeyDoc) bool { return strings.HasPrefix(d.Path, doc.Path+".") }) {
+			continue // Only the leaf keys can be set, e.g. not the map of structs itself.
+		}
+
+		name, ok := aliased[doc.Path]
+		if !ok {
+			keys := strings.Split(strings.ToUpper(doc.Path), ".")
+			if strings.HasPrefix(doc.Type, "map[") {
+				keys = append(keys, "*") // Each key of the map is set by its own variable.
+			}
+			name = strings.Join(keys, "_")
+			if !sameKeys(loader.Keys(name), keys) {
+				errs = append(errs, fmt.Errorf("%s has no environment variable loaded by %v", doc.Path, loader)) //nolint:err113
+				continue
+			}
+		}
+		vars = append(vars, EnvVar{
+			Path:    doc.Path,
+			Name:    name,
+			Type:    doc.Type,
+			Default: doc.Default,
+			Syntax:  syntax(doc.Type, name),
+		})
+	}
+
+	return vars, errors.Join(errs...)
+}
+
+func sameKeys(actual, expected []string) bool {
+	return slices.EqualFunc(actual, expected, strings.EqualFold)
+}
+
+// syntax returns the accepted syntax of the value for the given type,
+// which follows the default decode hooks of konf.Config.Unmarshal.
+func syntax(typ, name string) string {
+	switch {
+	case typ == "[]uint8" || strings.HasPrefix(typ, "[") && strings.HasSuffix(typ, "]uint8"):
+		return `raw string, or encoded string with "base64:" or "hex:" prefix`
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "["):
+		return "comma-separated values, e.g. a,b,c"
+	case strings.HasPrefix(typ, "map["):
+		return "one variable for each key of the map, e.g. " + strings.TrimSuffix(name, "_*") + "_KEY=value"
+	case typ == "time.Duration":
+		return "duration, e.g. 1m30s"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package gen_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf/gen"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/provider/env"
+)
+
+type (
+	appConfig struct {
+		Common  `konf:",squash"`
+		DB      db
+		Hosts   []string
+		Labels  map[string]string
+		Servers map[string]server
+	}
+	Common struct {
+		Timeout time.Duration
+	}
+	db struct {
+		URL      string
+		Password string
+	}
+	server struct {
+		Port int
+	}
+)
+
+func TestEnvVars(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		path        string
+		target      any
+		loader      env.Env
+		aliases     []string
+		expected    []gen.EnvVar
+		err         string
+	}{
+		{
+			description: "default",
+			path:        "app",
+			loader:      env.New(env.WithPrefix("APP_")),
+			expected: []gen.EnvVar{
+				{Path: "app.db.password", Name: "APP_DB_PASSWORD", Type: "string"},
+				{Path: "app.db.url", Name: "APP_DB_URL", Type: "string", Default: "postgres://localhost"},
+				{Path: "app.hosts", Name: "APP_HOSTS", Type: "[]string", Syntax: "comma-separated values, e.g. a,b,c"},
+				{
+					Path: "app.labels", Name: "APP_LABELS_*", Type: "map[string]string",
+					Syntax: "one variable for each key of the map, e.g. APP_LABELS_KEY=value",
+				},
+				{Path: "app.servers.*.port", Name: "APP_SERVERS_*_PORT", Type: "int"},
+				{
+					Path: "app.timeout", Name: "APP_TIMEOUT", Type: "time.Duration", Default: time.Second,
+					Syntax: "duration, e.g. 1m30s",
+				},
+			},
+		},
+		{
+			description: "alias",
+			path:        "app",
+			loader: env.New(env.WithNameSplitter(func(name string) []string {
+				if name == "DATABASE_URL" {
+					return []string{"APP", "DB", "URL"}
+				}
+
+				return strings.Split(name, "_")
+			})),
+			aliases: []string{"DATABASE_URL"},
+			expected: []gen.EnvVar{
+				{Path: "app.db.password", Name: "APP_DB_PASSWORD", Type: "string"},
+				{Path: "app.db.url", Name: "DATABASE_URL", Type: "string", Default: "postgres://localhost"},
+				{Path: "app.hosts", Name: "APP_HOSTS", Type: "[]string", Syntax: "comma-separated values, e.g. a,b,c"},
+				{
+					Path: "app.labels", Name: "APP_LABELS_*", Type: "map[string]string",
+					Syntax: "one variable for each key of the map, e.g. APP_LABELS_KEY=value",
+				},
+				{Path: "app.servers.*.port", Name: "APP_SERVERS_*_PORT", Type: "int"},
+				{
+					Path: "app.timeout", Name: "APP_TIMEOUT", Type: "time.Duration", Default: time.Second,
+					Syntax: "duration, e.g. 1m30s",
+				},
+			},
+		},
+		{
+			description: "prefix mismatch",
+			path:        "db",
+			target:      &db{},
+			loader:      env.New(env.WithPrefix("APP_")),
+			err: "db.password has no environment variable loaded by env:APP_*\n" +
+				"db.url has no environment variable loaded by env:APP_*",
+		},
+		{
+			description: "alias not loaded",
+			loader:      env.New(env.WithPrefix("APP_")),
+			aliases:     []string{"DATABASE_URL"},
+			err:         "alias DATABASE_URL is not loaded by env:APP_*",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			target := testcase.target
+			if target == nil {
+				target = &appConfig{Common: Common{Timeout: time.Second}, DB: db{URL: "postgres://localhost"}}
+			}
+			vars, err := gen.EnvVars(testcase.path, target, testcase.loader, testcase.aliases...)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, vars)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+// GetValue reads configuration under the given path from the Config as T,
+// with the same path resolution and type conversion as Config.Unmarshal.
+// It returns the zero value if the path does not exist, and error if decoding fails.
+// Use konf.LookupValue to distinguish the missing path from the zero value.
+//
+// This function is concurrent-safe.
+func GetValue[T any](config *Config, path string) (T, error) { //nolint:ireturn
+	value, _, err := lookup[T](config, path)
+
+	return value, err
+}
+
+// LookupValue reads configuration under the given path from the Config as T,
+// and reports whether the path exists. It returns the zero value and false
+// if the path does not exist or decoding fails, and the error is logged.
+//
+// This function is concurrent-safe.
+func LookupValue[T any](config *Config, path string) (T, bool) { //nolint:ireturn
+	value, exist, err := lookup[T](config, path)
+	if err != nil {
+		config.log(context.Background(),
+			slog.LevelWarn,
+			"Could not read config, return empty value instead.",
+			slog.String("path", path),
+			slog.Any("type", reflect.TypeOf(value)),
+			slog.Any("error", err),
+		)
+
+		return value, false
+	}
+
+	return value, exist
+}
+
+func lookup[T any](config *Config, path string) (T, bool, error) { //nolint:ireturn
+	var value T
+	if config == nil { // To support nil
+		return value, false, nil
+	}
+	config.nocopy.Check()
+	if config.parent != nil {
+		return lookup[T](config.parent, config.parentPath(path))
+	}
+
+	from, err := config.sub(config.splitPath(path))
+	if err != nil || from == nil {
+		return value, false, err
+	}
+	if e := config.decode(path, from, &value); e != nil {
+		var zero T
+
+		return zero, true, e
+	}
+
+	return value, true, nil
+}
+
+// Get returns the raw value under the given path, e.g. map[string]any for the nested path,
+// or nil if the path does not exist. The returned map does not share memory with the Config.
+// See konf.GetValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) Get(path string) any {
+	value, _ := LookupValue[any](c, path)
+
+	return value
+}
+
+// GetString returns the value under the given path as string, or empty if the path does not exist
+// or it cannot be converted. See konf.GetValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) GetString(path string) string {
+	value, _ := LookupValue[string](c, path)
+
+	return value
+}
+
+// LookupString returns the value under the given path as string, and reports whether the path exists.
+// See konf.LookupValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) LookupString(path string) (string, bool) {
+	return LookupValue[string](c, path)
+}
+
+// GetInt returns the value under the given path as int, or 0 if the path does not exist
+// or it cannot be converted. See konf.GetValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) GetInt(path string) int {
+	value, _ := LookupValue[int](c, path)
+
+	return value
+}
+
+// LookupInt returns the value under the given path as int, and reports whether the path exists.
+// See konf.LookupValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) LookupInt(path string) (int, bool) {
+	return LookupValue[int](c, path)
+}
+
+// GetBool returns the value under the given path as bool, or false if the path does not exist
+// or it cannot be converted. See konf.GetValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) GetBool(path string) bool {
+	value, _ := LookupValue[bool](c, path)
+
+	return value
+}
+
+// LookupBool returns the value under the given path as bool, and reports whether the path exists.
+// See konf.LookupValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) LookupBool(path string) (bool, bool) {
+	return LookupValue[bool](c, path)
+}
+
+// GetDuration returns the value under the given path as time.Duration, or 0 if the path does not exist
+// or it cannot be converted. See konf.GetValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) GetDuration(path string) time.Duration {
+	value, _ := LookupValue[time.Duration](c, path)
+
+	return value
+}
+
+// LookupDuration returns the value under the given path as time.Duration, and reports whether the path exists.
+// See konf.LookupValue for details.
+//
+// This method is concurrent-safe.
+func (c *Config) LookupDuration(path string) (time.Duration, bool) {
+	return LookupValue[time.Duration](c, path)
+}
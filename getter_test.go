@@ -0,0 +1,111 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Get(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithLogHandler(logHandler(buf)))
+	assert.NoError(t, config.Load(mapLoader{
+		"Server": map[string]any{"Host": "localhost", "port": "8080", "tls": "true", "timeout": "5s"},
+		"zero":   map[string]any{"port": 0, "tls": false},
+	}))
+
+	assert.Equal(t, "localhost", config.GetString("server.host"))
+	assert.Equal(t, 8080, config.GetInt("SERVER.PORT"))
+	assert.Equal(t, true, config.GetBool("server.tls"))
+	assert.Equal(t, 5*time.Second, config.GetDuration("server.timeout"))
+	assert.Equal(t, "", config.GetString("server.missing"))
+
+	port, ok := config.LookupInt("zero.port")
+	assert.Equal(t, 0, port)
+	assert.True(t, ok)
+	tls, ok := config.LookupBool("zero.tls")
+	assert.Equal(t, false, tls)
+	assert.True(t, ok)
+	_, ok = config.LookupString("zero.missing")
+	assert.True(t, !ok)
+	timeout, ok := config.LookupDuration("server.timeout")
+	assert.Equal(t, 5*time.Second, timeout)
+	assert.True(t, ok)
+
+	// The value cannot be converted.
+	assert.Equal(t, 0, config.GetInt("server.host"))
+	_, ok = config.LookupInt("server.host")
+	assert.True(t, !ok)
+	expected := `level=WARN msg="Could not read config, return empty value instead." path=server.host type=int ` +
+		`error="decode: cannot parse 'server.host' as int: strconv.ParseInt: parsing \"localhost\": invalid syntax"` + "\n"
+	assert.Equal(t, strings.Repeat(expected, 2), buf.String())
+
+	value, err := konf.GetValue[map[string]string](config, "server")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"host": "localhost", "port": "8080", "tls": "true", "timeout": "5s"}, value)
+	_, err = konf.GetValue[int](config, "server.host")
+	assert.EqualError(t, err,
+		`decode: cannot parse 'server.host' as int: strconv.ParseInt: parsing "localhost": invalid syntax`)
+	missing, err := konf.GetValue[int](config, "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, missing)
+
+	raw, ok := config.Get("zero").(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"port": 0, "tls": false}, raw)
+	raw["port"] = 1 // It does not change the Config.
+	assert.Equal(t, 0, config.Get("zero.port"))
+	assert.Equal(t, nil, config.Get("zero.missing"))
+
+	var nilConfig *konf.Config
+	_, ok = nilConfig.LookupString("server.host")
+	assert.True(t, !ok)
+	assert.Equal(t, nil, nilConfig.Get("server.host"))
+}
+
+func TestConfig_Get_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"port": 8080}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	var waitGroup sync.WaitGroup
+	for range 4 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for range 100 {
+				port, ok := config.LookupInt("port")
+				assert.True(t, ok)
+				assert.True(t, port >= 8080 && port < 8090)
+			}
+		}()
+	}
+	for port := 8081; port < 8090; port++ {
+		watcher.change <- map[string]any{"port": port}
+	}
+	waitGroup.Wait()
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+type (
+	// OnChangeOption configures Config.OnChangeWith with specific options.
+	OnChangeOption  func(*onChangeOptions)
+	onChangeOptions struct {
+		paths          []string
+		group          string
+		deliverCurrent bool
+	}
+)
+
+// Keys provides the paths for Config.OnChangeWith, same as the paths of Config.OnChange.
+// The empty paths mean any path.
+func Keys(paths ...string) OnChangeOption {
+	return func(options *onChangeOptions) {
+		options.paths = append(options.paths, paths...)
+	}
+}
+
+// Group provides the dispatch group for Config.OnChangeWith.
+// The callbacks in the group are dispatched with the policy provided by konf.WithGroupPolicy,
+// or the same as the default group if the group has no policy.
+func Group(name string) OnChangeOption {
+	return func(options *onChangeOptions) {
+		options.group = name
+	}
+}
+
+// DeliverCurrent executes the callback of Config.OnChangeWith or Config.OnChangeContext once
+// with the current configuration right after the registration, so that the callback does not need
+// to read the configuration separately before it receives the first change.
+//
+// If Config.Watch has not been called, the callback is executed synchronously before Config.OnChangeWith returns.
+// Otherwise, it's dispatched asynchronously in order with the changes, with the policy of its group if any,
+// so it may be executed after Config.OnChangeWith returns. The executions of the callback are serialized,
+// and the change which has been observed by the initial delivery is not delivered again, and vice versa.
+func DeliverCurrent() OnChangeOption {
+	return func(options *onChangeOptions) {
+		options.deliverCurrent = true
+	}
+}
+
+// OnChangeWith registers a callback function the same as Config.OnChange with the given OnChangeOption(s),
+// e.g. config.OnChangeWith(reload, konf.Keys("db"), konf.Group("heavy")).
+//
+// The callbacks in the default group are executed one by one for each change,
+// and the dispatching moves on to the next change after one minute even if they have not completed.
+// The callbacks in the group with policy are dispatched independently from other groups,
+// so a slow group never delays the others. Since they are dispatched asynchronously,
+// Config.LastChange within the callbacks may be newer than the change triggers them.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangeWith(onChange func(*Config), opts ...OnChangeOption) {
+	option := &onChangeOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	c.onChangeWith(withoutContext(onChange), option, 3) //nolint:mnd
+}
+
+// OnChangeContext registers a callback function the same as Config.OnChangeWith,
+// but the callback receives the context carrying the ChangeEvent which triggers it, see konf.ChangeFromContext,
+// so that the functions called by the callback can retrieve the change, e.g. for logging and tracing,
+// without passing it explicitly. The context is canceled once Config.Watch stops.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangeContext(onChange func(ctx context.Context, config *Config), opts ...OnChangeOption) {
+	option := &onChangeOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	c.onChangeWith(onChange, option, 3) //nolint:mnd
+}
+
+func (c *Config) onChangeWith(onChange func(context.Context, *Config), option *onChangeOptions, skip int) {
+	sub := c.registerOnChangeIn(option.group, onChange, option.paths, skip)
+	if sub == nil || !option.deliverCurrent {
+		return
+	}
+	root := c.root()
+	if watch := root.watched.Load(); watch != nil {
+		watch.deliver(sub)
+	} else {
+		sub.call(context.Background(), root, root.currentEvent())
+	}
+}
+
+type (
+	// GroupPolicy configures the dispatching of the group provided by konf.WithGroupPolicy.
+	GroupPolicy func(*groupPolicy)
+	groupPolicy struct {
+		concurrent bool
+		queueSize  int
+
+		// Only for Builder to validate the policy.
+		sequential  bool
+		conflicting bool
+	}
+)
+
+// Sequential executes the callbacks in the group one by one for each change,
+// and it's the default policy. The changes are dispatched one by one in order as well,
+// which means the next change waits until all callbacks of the previous change have completed.
+func Sequential() GroupPolicy {
+	return func(policy *groupPolicy) {
+		policy.conflicting = policy.conflicting || policy.concurrent
+		policy.sequential = true
+		policy.concurrent = false
+	}
+}
+
+// Concurrent executes the callbacks in the group concurrently for each change.
+// The changes are still dispatched one by one in order.
+func Concurrent() GroupPolicy {
+	return func(policy *groupPolicy) {
+		policy.conflicting = policy.conflicting || policy.sequential
+		policy.concurrent = true
+	}
+}
+
+// Queue provides the size of the queue for the pending changes of the group.
+// If the queue is full, the oldest pending change is dropped, and ErrChangeDropped is reported
+// to the callback provided by konf.WithOnStatus. The callbacks of the dropped change
+// are still executed with the next pending change, so they observe the latest configuration.
+//
+// The default size is 1, and the size less than 1 is ignored.
+func Queue(size int) GroupPolicy {
+	return func(policy *groupPolicy) {
+		if size > 0 {
+			policy.queueSize = size
+		}
+	}
+}
+
+// ErrChangeDropped is the error reported to the callback provided by konf.WithOnStatus
+// if the change is dropped since the queue of the group is full.
+var ErrChangeDropped = errors.New("change is dropped since the queue of onChange group is full")
+
+type (
+	groupDispatcher struct {
+		config *Config
+		name   string
+		policy groupPolicy
+
+		queue  []groupChange
+		mutex  sync.Mutex
+		signal chan struct{}
+	}
+	groupChange struct {
+		loader Loader
+		event  *ChangeEvent
+		subs   []*subscription
+	}
+)
+
+func (d *groupDispatcher) enqueue(ctx context.Context, loader Loader, event *ChangeEvent, subs []*subscription) {
+	d.mutex.Lock()
+	var dropped *groupChange
+	if len(d.queue) >= max(d.policy.queueSize, 1) {
+		first := d.queue[0]
+		dropped = &first
+		d.queue = d.queue[1:]
+	}
+	d.queue = append(d.queue, groupChange{loader: loader, event: event, subs: subs})
+	if dropped != nil {
+		// Carry the callbacks of the dropped change to the next pending change.
+		next := &d.queue[0]
+		merged := slices.Clone(dropped.subs)
+		for _, sub := range next.subs {
+			if !slices.Contains(merged, sub) {
+				merged = append(merged, sub)
+			}
+		}
+		next.subs = merged
+	}
+	d.mutex.Unlock()
+
+	select {
+	case d.signal <- struct{}{}:
+	default:
+	}
+
+	if dropped != nil {
+		err := fmt.Errorf("dispatch to onChange group %s: %w", d.name, ErrChangeDropped)
+		d.config.log(ctx, slog.LevelWarn,
+			"Configuration change is dropped since the onChange group falls behind.",
+			slog.String("group", d.name),
+			slog.Any("loader", dropped.loader),
+		)
+		if d.config.onStatus != nil {
+			d.config.onStatus(dropped.loader, false, err)
+		}
+	}
+}
+
+func (d *groupDispatcher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.signal:
+		}
+
+		for {
+			d.mutex.Lock()
+			if len(d.queue) == 0 {
+				d.mutex.Unlock()
+
+				break
+			}
+			change := d.queue[0]
+			d.queue = d.queue[1:]
+			d.mutex.Unlock()
+
+			if !d.dispatch(ctx, change) {
+				return
+			}
+		}
+	}
+}
+
+// dispatch executes the callbacks of the change, and waits until they complete.
+// It returns false if ctx is done.
+func (d *groupDispatcher) dispatch(ctx context.Context, change groupChange) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if !d.policy.concurrent {
+			for _, sub := range change.subs {
+				sub.call(ctx, d.config, change.event)
+			}
+
+			return
+		}
+		var waitGroup sync.WaitGroup
+		for _, sub := range change.subs {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+
+				sub.call(ctx, d.config, change.event)
+			}()
+		}
+		waitGroup.Wait()
+	}()
+
+	timeout, stop := d.config.timeSource().NewTimer(time.Minute)
+	defer stop()
+	for {
+		select {
+		case <-done:
+			d.config.log(ctx, slog.LevelDebug,
+				"Configuration has been applied to onChanges.",
+				slog.String("group", d.name),
+			)
+
+			return true
+		case <-timeout:
+			d.config.log(ctx, slog.LevelWarn,
+				"Configuration has not been fully applied to onChanges in one minute."+
+					" Please check if the onChanges is blocking or takes too long to complete.",
+				slog.String("group", d.name),
+			)
+			timeout = nil // Keep waiting since the changes of the group are dispatched in order.
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_OnChangeWith_group(t *testing.T) {
+	t.Parallel()
+
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithLogHandler(logHandler(&buffer{})),
+		konf.WithGroupPolicy("heavy", konf.Sequential(), konf.Queue(1)),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) { statuses <- err }),
+	)
+	watcher := mapWatcher{values: map[string]any{"a": 0, "b": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	applied := make(chan int, 3)
+	config.OnChangeWith(func(config *konf.Config) {
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		applied <- a
+	}, konf.Keys("a"))
+	started, release := make(chan struct{}, 4), make(chan struct{})
+	heavy := make(chan int, 3)
+	config.OnChangeWith(func(config *konf.Config) {
+		started <- struct{}{}
+		<-release
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		heavy <- a
+	}, konf.Keys("a"), konf.Group("heavy"))
+	others := make(chan struct{}, 3)
+	config.OnChangeWith(func(*konf.Config) { others <- struct{}{} }, konf.Keys("b"), konf.Group("heavy"))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The default group is not blocked by the heavy group.
+	watcher.change <- map[string]any{"a": 1, "b": 0}
+	assert.Equal(t, 1, <-applied)
+	<-started // The heavy group is blocked by the first change.
+	watcher.change <- map[string]any{"a": 2, "b": 1}
+	assert.Equal(t, 2, <-applied)
+	watcher.change <- map[string]any{"a": 3, "b": 1}
+	assert.Equal(t, 3, <-applied)
+	err := <-statuses
+	assert.True(t, errors.Is(err, konf.ErrChangeDropped))
+	assert.EqualError(t, err, "dispatch to onChange group heavy: "+konf.ErrChangeDropped.Error())
+
+	// The callbacks of the dropped change are carried to the next change.
+	close(release)
+	assert.Equal(t, 3, <-heavy)
+	assert.Equal(t, 3, <-heavy)
+	<-others
+	// The heavy group is sequential, so the duplicate callback if any is executed before this change.
+	watcher.change <- map[string]any{"a": 4, "b": 1}
+	assert.Equal(t, 4, <-applied)
+	assert.Equal(t, 4, <-heavy)
+	assert.Equal(t, 0, len(others))
+}
+
+func TestConfig_OnChangeWith_concurrent(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithGroupPolicy("concurrent", konf.Concurrent()))
+	watcher := mapWatcher{values: map[string]any{"a": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	// Each callback waits for the other, so they only complete if executed concurrently.
+	first, second := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{}, 2)
+	config.OnChangeWith(func(*konf.Config) {
+		close(first)
+		<-second
+		done <- struct{}{}
+	}, konf.Group("concurrent"))
+	config.OnChangeWith(func(*konf.Config) {
+		close(second)
+		<-first
+		done <- struct{}{}
+	}, konf.Group("concurrent"))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"a": 1}
+	<-done
+	<-done
+}
+
+func TestConfig_OnChangeWith_deliverCurrent(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	watcher := mapWatcher{values: map[string]any{"a": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	// The callback is executed synchronously before Watch.
+	applied := make(chan int, 3)
+	config.OnChangeWith(func(config *konf.Config) {
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		applied <- a
+	}, konf.Keys("a"), konf.DeliverCurrent())
+	assert.Equal(t, 1, len(applied))
+	assert.Equal(t, 0, <-applied)
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"a": 1}
+	assert.Equal(t, 1, <-applied)
+
+	// The callback is executed by the dispatcher after Watch.
+	heavy := make(chan int, 3)
+	config.OnChangeWith(func(config *konf.Config) {
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		heavy <- a
+	}, konf.Keys("a"), konf.Group("heavy"), konf.DeliverCurrent())
+	assert.Equal(t, 1, <-heavy)
+
+	watcher.change <- map[string]any{"a": 2}
+	assert.Equal(t, 2, <-applied)
+	assert.Equal(t, 2, <-heavy)
+	// The deliveries are dispatched in order with the changes, so the duplicate one if any is received first.
+	watcher.change <- map[string]any{"a": 3}
+	assert.Equal(t, 3, <-applied)
+	assert.Equal(t, 3, <-heavy)
+	assert.Equal(t, 0, len(applied))
+	assert.Equal(t, 0, len(heavy))
+}
+
+func TestConfig_OnChangeWith_deliverCurrent_group(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})), konf.WithGroupPolicy("heavy"))
+	watcher := mapWatcher{values: map[string]any{"a": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	applied := make(chan int, 3)
+	config.OnChangeWith(func(config *konf.Config) {
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		applied <- a
+	}, konf.Keys("a"))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The blocking initial delivery of the group does not block the changes of other callbacks.
+	release := make(chan struct{})
+	delivered := make(chan struct{})
+	config.OnChangeWith(func(*konf.Config) {
+		select {
+		case delivered <- struct{}{}:
+			<-release
+		default:
+		}
+	}, konf.Keys("a"), konf.Group("heavy"), konf.DeliverCurrent())
+	<-delivered
+	defer close(release)
+
+	watcher.change <- map[string]any{"a": 1}
+	assert.Equal(t, 1, <-applied)
+}
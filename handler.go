@@ -0,0 +1,178 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DebugHandler returns a http.Handler which exposes the given Config for debugging:
+//
+//   - GET /debug/config/state: the human-readable state written by Config.DumpState.
+//   - GET /debug/config/schema: the keys registered by Config.Describe in JSON.
+//   - GET /debug/config/events: the server-sent events of changes, same as konf.SSEHandler.
+//   - GET /debug/config/keys/{path}: the Config.KeyInfo of the path in JSON, with the blurred value,
+//     and its human-readable form if the path is described as time.Duration or konf.ByteSize by Config.Describe.
+//   - POST /debug/config/secrets/{path}: the raw value of the path revealed by Config.RevealSecret,
+//     with the reason in the form value "reason" of the request body. The caller in the audit record
+//     is the remote address of the request. It never reveals the secret via GET.
+//
+// It's usually registered on the mux of the admin server,
+// e.g. mux.Handle("/debug/config/", konf.DebugHandler(config)).
+func DebugHandler(config *Config) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/config/state", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = config.DumpState(writer)
+	})
+	mux.HandleFunc("GET /debug/config/schema", func(writer http.ResponseWriter, _ *http.Request) {
+		docs := config.Schema()
+		if docs == nil {
+			docs = []KeyDoc{}
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(docs)
+	})
+	mux.Handle("GET /debug/config/events", SSEHandler(config))
+	mux.HandleFunc("GET /debug/config/keys/{path...}", func(writer http.ResponseWriter, request *http.Request) {
+		path := request.PathValue("path")
+		info := config.KeyInfo(path)
+		if info.Value == nil {
+			http.NotFound(writer, request)
+
+			return
+		}
+		response := map[string]any{
+			"path":         path,
+			"value":        config.export(path, info.Value, false),
+			"loader":       fmt.Sprint(info.Loader),
+			"last_changed": info.LastChanged,
+			"version":      info.Version,
+		}
+		if _, human := config.humanize(path, info.Value); human != "" {
+			response["human"] = human
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(response)
+	})
+
+	mux.HandleFunc("POST /debug/config/secrets/{path...}", func(writer http.ResponseWriter, request *http.Request) {
+		value, err := config.revealSecret(request.Context(),
+			request.PathValue("path"), request.PostFormValue("reason"), "http "+request.RemoteAddr,
+		)
+		switch {
+		case errors.Is(err, errNoReason):
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+		case err != nil:
+			http.NotFound(writer, request)
+		default:
+			writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			writer.Header().Set("Cache-Control", "no-store")
+			_, _ = writer.Write([]byte(value))
+		}
+	})
+
+	return mux
+}
+
+// SSEHandler returns a http.Handler which streams the changes of the given Config as server-sent events,
+// e.g. for the admin UI to live-update the configuration view. The events are:
+//
+//   - snapshot: the version and the whole configuration, sent once connected.
+//   - change: the version, the changed keys and their current values, sent after each change applied by Config.Watch.
+//   - resync: sent if changes have been dropped since the client is slower than changes,
+//     and followed by a new snapshot.
+//
+// The data of events is JSON, and the sensitive values are blurred.
+// It also sends a comment line as heartbeat every 15 seconds to keep the connection alive through proxies.
+// Each connection has its own buffered queue, so a slow client never blocks Config.Watch.
+func SSEHandler(config *Config) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		flusher, ok := writer.(http.Flusher)
+		if !ok {
+			http.Error(writer, "streaming is not supported", http.StatusInternalServerError)
+
+			return
+		}
+
+		events := make(chan ChangeEvent, sseQueueSize)
+		resync := make(chan struct{}, 1)
+		// Register before the snapshot so that no change is missed.
+		sub := config.registerOnChange(func(config *Config) {
+			select {
+			case events <- config.LastChange():
+			default:
+				select {
+				case resync <- struct{}{}:
+				default:
+				}
+			}
+		}, nil, 2) //nolint:mnd
+		defer config.unregisterOnChange(sub)
+
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.Header().Set("Cache-Control", "no-cache")
+		writer.Header().Set("Connection", "keep-alive")
+		send := func(event string, data any) error {
+			bytes, err := json.Marshal(data)
+			if err != nil {
+				return fmt.Errorf("marshal %s event: %w", event, err)
+			}
+			if _, err = fmt.Fprintf(writer, "event: %s\ndata: %s\n\n", event, bytes); err != nil {
+				return fmt.Errorf("write %s event: %w", event, err)
+			}
+			flusher.Flush()
+
+			return nil
+		}
+		snapshot := func() error {
+			return send("snapshot", map[string]any{
+				"version": config.LastChange().Version,
+				"values":  config.export("", config.providers.sub(nil), false),
+			})
+		}
+
+		if snapshot() != nil {
+			return
+		}
+		heartbeats, stop := config.timeSource().NewTicker(sseHeartbeatInterval)
+		defer stop()
+		for {
+			var err error
+			select {
+			case <-request.Context().Done():
+				return
+			case event := <-events:
+				values := make(map[string]any, len(event.Keys))
+				for _, key := range event.Keys {
+					values[key] = config.export(key, config.providers.sub(config.splitPath(key)), false)
+				}
+				err = send("change", map[string]any{"version": event.Version, "keys": event.Keys, "values": values})
+			case <-resync:
+				for len(events) > 0 {
+					<-events
+				}
+				if err = send("resync", map[string]any{"version": config.LastChange().Version}); err == nil {
+					err = snapshot()
+				}
+			case <-heartbeats:
+				if _, err = fmt.Fprint(writer, ": heartbeat\n\n"); err == nil {
+					flusher.Flush()
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	})
+}
+
+const (
+	sseQueueSize         = 16
+	sseHeartbeatInterval = 15 * time.Second
+)
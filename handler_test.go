@@ -0,0 +1,81 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestSSEHandler(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"port": 8080}, "password": "secret"},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	httpServer := httptest.NewServer(konf.DebugHandler(config))
+	defer httpServer.Close()
+
+	requestCtx, disconnect := context.WithCancel(context.Background())
+	request, err := http.NewRequestWithContext(requestCtx, http.MethodGet, httpServer.URL+"/debug/config/events", nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	next := func() string {
+		var lines []string
+		for scanner.Scan() {
+			if scanner.Text() == "" {
+				break
+			}
+			lines = append(lines, scanner.Text())
+		}
+
+		return strings.Join(lines, "\n")
+	}
+	assert.Equal(t,
+		`event: snapshot`+"\n"+`data: {"values":{"password":"******","server":{"port":8080}},"version":0}`,
+		next(),
+	)
+
+	watcher.change <- map[string]any{"server": map[string]any{"port": 9090}, "password": "changed"}
+	assert.Equal(t,
+		`event: change`+"\n"+
+			`data: {"keys":["password","server.port"],"values":{"password":"******","server.port":9090},"version":1}`,
+		next(),
+	)
+
+	// The subscription is removed once the client disconnects.
+	disconnect()
+	for len(config.DebugState().Subscriptions) > 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Hooks are the callbacks at the lifecycle points of Config.Watch provided by konf.WithLifecycleHooks,
+// e.g. for the frameworks which embed konf. All of them are optional, and executed synchronously,
+// so they must be non-blocking. The panics in hooks are recovered and logged.
+//
+// For each change, the hooks and callbacks are executed in the order:
+//
+//  1. The change is validated (e.g. konf.WithTagValidation), and it's rejected without hooks if invalid.
+//  2. The change is merged, and Config.LastChange returns the event of the change.
+//  3. BeforeApply is executed with the event.
+//  4. The callbacks registered by Config.OnChange in the default group are executed,
+//     and the callbacks of the groups with policy are queued to their own dispatchers.
+//  5. AfterApply is executed with the event once the callbacks in the default group have completed.
+type Hooks struct {
+	// OnWatchStart is executed with the loaders, from the lowest to the highest precedence,
+	// once Config.Watch starts, before watching any loader.
+	OnWatchStart func(loaders []string)
+	// OnWatchStop is executed with the error Config.Watch returns, after all watchers have returned.
+	OnWatchStop func(err error)
+	// BeforeApply is executed with the change before the callbacks registered by Config.OnChange.
+	BeforeApply func(event ChangeEvent)
+	// AfterApply is executed with the change after the callbacks registered by Config.OnChange in the default group.
+	// The error is non-nil if the callbacks have not completed in one minute, or Config.Watch is stopping.
+	AfterApply func(event ChangeEvent, err error)
+	// OnSnapshotRebuild is executed with the statistics once the merged snapshot is rebuilt,
+	// e.g. for exporting them as metrics. It's executed while the snapshot is locked for rebuilding,
+	// so it must not call the methods of Config.
+	OnSnapshotRebuild func(stats SnapshotStats)
+}
+
+var errApplyTimeout = errors.New("onChanges have not completed in one minute")
+
+// callHook executes the hook provided by konf.WithLifecycleHooks, and recovers the panic in it.
+func (c *Config) callHook(ctx context.Context, name string, hook func(Hooks)) {
+	if c.hooks == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			c.log(ctx, slog.LevelError, "Panic in lifecycle hook.",
+				slog.String("hook", name),
+				slog.String("panic", fmt.Sprint(r)),
+			)
+		}
+	}()
+	hook(*c.hooks)
+}
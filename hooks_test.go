@@ -0,0 +1,104 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestWithLifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex sync.Mutex
+		calls []string
+	)
+	record := func(call string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		calls = append(calls, call)
+	}
+	applied := make(chan struct{})
+	config := konf.New(konf.WithLifecycleHooks(konf.Hooks{
+		OnWatchStart: func(loaders []string) {
+			record("OnWatchStart " + strings.Join(loaders, ","))
+		},
+		OnWatchStop: func(err error) {
+			record(fmt.Sprintf("OnWatchStop %v", err))
+		},
+		BeforeApply: func(event konf.ChangeEvent) {
+			record(fmt.Sprintf("BeforeApply %d", event.Version))
+		},
+		AfterApply: func(event konf.ChangeEvent, err error) {
+			record(fmt.Sprintf("AfterApply %d %v", event.Version, err))
+			close(applied)
+		},
+	}))
+	watcher := mapWatcher{values: map[string]any{"port": 80}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(mapLoader{}))
+	assert.NoError(t, config.Load(watcher))
+	config.OnChange(func(config *konf.Config) {
+		var port int
+		assert.NoError(t, config.Unmarshal("port", &port))
+		record(fmt.Sprintf("OnChange %d", port))
+	}, "port")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"port": 8080}
+	<-applied
+	cancel()
+	<-stopped
+
+	version := config.LastChange().Version
+	assert.Equal(t, []string{
+		"OnWatchStart map,map",
+		fmt.Sprintf("BeforeApply %d", version),
+		"OnChange 8080",
+		fmt.Sprintf("AfterApply %d <nil>", version),
+		"OnWatchStop <nil>",
+	}, calls)
+}
+
+func TestWithLifecycleHooks_panic(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	stopped := make(chan struct{})
+	config := konf.New(
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithLifecycleHooks(konf.Hooks{
+			OnWatchStart: func([]string) {
+				panic("start")
+			},
+			OnWatchStop: func(error) {
+				defer close(stopped)
+				panic("stop")
+			},
+		}),
+	)
+	assert.NoError(t, config.Load(mapLoader{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, config.Watch(ctx))
+	<-stopped
+
+	assert.True(t, strings.Contains(buf.String(), `level=ERROR msg="Panic in lifecycle hook." hook=OnWatchStart panic=start`))
+	assert.True(t, strings.Contains(buf.String(), `level=ERROR msg="Panic in lifecycle hook." hook=OnWatchStop panic=stop`))
+}
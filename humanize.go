@@ -0,0 +1,99 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nil-go/konf/internal/credential"
+)
+
+// ByteSize is the size in bytes. It's decoded from the number of bytes, or the string of the number
+// with the decimal unit (e.g. `1.5GB`) or the binary unit (e.g. `512MiB`), which is case-insensitive.
+type ByteSize uint64
+
+//nolint:gochecknoglobals
+var byteUnits = map[string]float64{
+	"b":  1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12, "pb": 1e15,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50,
+}
+
+// String returns the size with the largest binary unit which is not greater than it,
+// and up to two decimal places, e.g. `512 MiB` and `1.5 GiB`.
+func (s ByteSize) String() string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	size, unit := float64(s), 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+
+	return strconv.FormatFloat(math.Round(size*100)/100, 'f', -1, 64) + " " + units[unit] //nolint:mnd
+}
+
+// UnmarshalText decodes the text of the number with optional unit, e.g. `512MiB`.
+func (s *ByteSize) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	index := strings.IndexFunc(str, func(r rune) bool { return unicode.IsLetter(r) })
+	if index < 0 {
+		index = len(str)
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(str[:index]), 64)
+	if err != nil || number < 0 {
+		return fmt.Errorf("invalid byte size %q", str) //nolint:err113
+	}
+	multiplier, ok := 1.0, true
+	if unit := str[index:]; unit != "" {
+		multiplier, ok = byteUnits[strings.ToLower(unit)]
+	}
+	if !ok {
+		return fmt.Errorf("unknown unit of byte size %q", str) //nolint:err113
+	}
+	*s = ByteSize(math.Round(number * multiplier))
+
+	return nil
+}
+
+// humanize returns the human-readable form of the value with its type name, if the path is described
+// as time.Duration or konf.ByteSize by Config.Describe, e.g. `5m0s` for 300000000000.
+// Otherwise, including the value is sensitive or already human-readable, it returns empty.
+func (c *Config) humanize(path string, value any) (string, string) {
+	if c.blur(path, value) != credential.Format(value) {
+		return "", ""
+	}
+
+	depth := len(c.splitPath(path))
+	for _, doc := range c.schema.list() {
+		if doc.open || len(c.splitPath(doc.Path)) != depth || !c.matchKey(doc.Path, path) {
+			continue
+		}
+
+		var human fmt.Stringer
+		switch typ := strings.TrimPrefix(doc.Type, "*"); typ {
+		case reflect.TypeFor[time.Duration]().String():
+			human = new(time.Duration)
+		case reflect.TypeFor[ByteSize]().String():
+			human = new(ByteSize)
+		default:
+			return "", ""
+		}
+		if err := c.decoder().ConvertAt(path, value, human); err != nil {
+			return "", "" // Let Config.Unmarshal report the error.
+		}
+		if formatted := human.String(); formatted != fmt.Sprint(value) {
+			return strings.TrimPrefix(doc.Type, "*"), formatted
+		}
+
+		return "", ""
+	}
+
+	return "", ""
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestByteSize(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		text     string
+		expected konf.ByteSize
+		str      string
+		err      string
+	}{
+		{text: "512", expected: 512, str: "512 B"},
+		{text: "512MiB", expected: 512 << 20, str: "512 MiB"},
+		{text: "1.5 gib", expected: 3 << 29, str: "1.5 GiB"},
+		{text: "2KB", expected: 2000, str: "1.95 KiB"},
+		{text: "1TB", expected: 1e12, str: "931.32 GiB"},
+		{text: "MiB", err: `invalid byte size "MiB"`},
+		{text: "-1B", err: `invalid byte size "-1B"`},
+		{text: "1XB", err: `unknown unit of byte size "1XB"`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.text, func(t *testing.T) {
+			t.Parallel()
+
+			var size konf.ByteSize
+			err := size.UnmarshalText([]byte(testcase.text))
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, size)
+				assert.Equal(t, testcase.str, size.String())
+			}
+		})
+	}
+}
+
+func TestConfig_Explain_humanize(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Timeout  time.Duration
+		Interval *time.Duration
+		Body     konf.ByteSize
+		Port     int
+		Password time.Duration
+	}
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{
+		"timeout":  300000000000,
+		"interval": "90s",
+		"body":     536870912,
+		"port":     8080,
+		"password": 300000000000,
+		"unknown":  300000000000,
+	}}))
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"timeout": "5m0s"}}))
+	assert.NoError(t, config.Describe("server", &Server{}))
+	var server Server
+	assert.NoError(t, config.Unmarshal("server", &server))
+	assert.Equal(t, konf.ByteSize(512<<20), server.Body)
+	assert.Equal(t, 90*time.Second, *server.Interval)
+
+	testcases := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path: "server.timeout",
+			expected: "server.timeout has value[5m0s] that is loaded by loader[map].\n" +
+				"Here are other value(loader)s:\n  - 300000000000 (as time.Duration: 5m0s)(map)\n\n",
+		},
+		{
+			path:     "server.interval",
+			expected: "server.interval has value[90s (as time.Duration: 1m30s)] that is loaded by loader[map].\n\n",
+		},
+		{
+			path:     "server.body",
+			expected: "server.body has value[536870912 (as konf.ByteSize: 512 MiB)] that is loaded by loader[map].\n\n",
+		},
+		{
+			path:     "server.port",
+			expected: "server.port has value[8080] that is loaded by loader[map].\n\n",
+		},
+		{
+			path:     "server.password",
+			expected: "server.password has value[******] that is loaded by loader[map].\n\n",
+		},
+		{
+			path:     "server.unknown",
+			expected: "server.unknown has value[300000000000] that is loaded by loader[map].\n\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testcase.expected, config.Explain(testcase.path))
+		})
+	}
+}
+
+func TestDebugHandler_humanize(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"timeout": 300000000000, "retries": 3}))
+	assert.NoError(t, config.Describe("", &struct {
+		Timeout time.Duration
+		Retries int
+	}{}))
+	httpServer := httptest.NewServer(konf.DebugHandler(config))
+	defer httpServer.Close()
+
+	get := func(path string) map[string]any {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, httpServer.URL+path, nil)
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+		var info map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+		assert.NoError(t, resp.Body.Close())
+
+		return info
+	}
+
+	info := get("/debug/config/keys/timeout")
+	assert.Equal[any](t, float64(300000000000), info["value"])
+	assert.Equal[any](t, "5m0s", info["human"])
+	info = get("/debug/config/keys/retries")
+	assert.Equal[any](t, float64(3), info["value"])
+	assert.Equal(t, nil, info["human"])
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// RegisterImpl registers the implementations of the interface type T keyed by the discriminator field,
+// so that Config.Unmarshal decodes the map into T by instantiating the implementation
+// with the value of the discriminator, and decoding the remaining keys into it. For example,
+//
+//	konf.RegisterImpl[Storage]("type", map[string]func() Storage{
+//	    "s3":   func() Storage { return &S3{} },
+//	    "disk": func() Storage { return &Disk{} },
+//	})
+//
+// decodes {type: s3, bucket: ...} into *S3. It returns error if the discriminator is missing or unknown.
+// In strict mode (see konf.WithStrictUnmarshal), the remaining keys must be the fields of the chosen implementation.
+// With konf.WithTagValidation, the chosen implementation is validated against its `validate` tags as well.
+//
+// The registration replaces the previous one of the same type, and it has no effects if T is not an interface.
+// It's designed to be called in init of the packages which own the interfaces.
+//
+// This function is concurrent-safe.
+func RegisterImpl[T any](field string, factories map[string]func() T) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Interface {
+		return
+	}
+
+	impl := implementation{field: field, factories: make(map[string]func() any, len(factories))}
+	for name, factory := range factories {
+		if factory != nil {
+			impl.factories[name] = func() any { return factory() }
+		}
+	}
+
+	implementations.mutex.Lock()
+	defer implementations.mutex.Unlock()
+
+	if implementations.types == nil {
+		implementations.types = make(map[reflect.Type]implementation)
+	}
+	implementations.types[typ] = impl
+}
+
+type implementation struct {
+	field     string
+	factories map[string]func() any
+}
+
+//nolint:gochecknoglobals
+var implementations struct {
+	types map[reflect.Type]implementation
+	mutex sync.RWMutex
+}
+
+// implement instantiates the implementation of the interface type registered by konf.RegisterImpl.
+func (c *Config) implement(name string, typ reflect.Type, from map[string]any) (any, map[string]any, error) {
+	keyMap := defaultKeyMap
+	if c.caseSensitive {
+		keyMap = nil
+	}
+
+	return instantiate(name, typ, from, keyMap)
+}
+
+// instantiate instantiates the implementation of the interface type registered by konf.RegisterImpl,
+// with the map except the discriminator. It returns nil if the type is not registered.
+func instantiate(
+	name string, typ reflect.Type, from map[string]any, keyMap func(string) string,
+) (any, map[string]any, error) {
+	implementations.mutex.RLock()
+	impl, ok := implementations.types[typ]
+	implementations.mutex.RUnlock()
+	if !ok {
+		return nil, nil, nil
+	}
+
+	field := impl.field
+	if keyMap != nil {
+		field = keyMap(field)
+	}
+	known := make([]string, 0, len(impl.factories))
+	for key := range impl.factories {
+		known = append(known, key)
+	}
+	slices.Sort(known)
+
+	_, discriminator := maps.Unpack(from[field])
+	if discriminator == nil {
+		return nil, nil, fmt.Errorf( //nolint:err113
+			"'%s': missing %q for %s, known: %s", name, impl.field, typ, strings.Join(known, ", "),
+		)
+	}
+	factory, ok := impl.factories[fmt.Sprint(discriminator)]
+	if !ok {
+		return nil, nil, fmt.Errorf( //nolint:err113
+			"'%s': unknown %s %q for %s, known: %s", name, impl.field, discriminator, typ, strings.Join(known, ", "),
+		)
+	}
+
+	value := factory()
+	if value == nil {
+		return nil, nil, fmt.Errorf("'%s': nil implementation for %s %q", name, impl.field, discriminator) //nolint:err113
+	}
+	rest := make(map[string]any, len(from)-1)
+	for key, val := range from {
+		if key != field {
+			rest[key] = val
+		}
+	}
+
+	return value, rest, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestRegisterImpl(t *testing.T) {
+	t.Parallel()
+
+	konf.RegisterImpl[Storage]("type", map[string]func() Storage{
+		"s3":   func() Storage { return &S3{} },
+		"disk": func() Storage { return Disk{} },
+	})
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		values      map[string]any
+		expected    Storage
+		err         string
+	}{
+		{
+			description: "pointer implementation",
+			values:      map[string]any{"type": "s3", "bucket": "konf"},
+			expected:    &S3{Bucket: "konf"},
+		},
+		{
+			description: "value implementation",
+			values:      map[string]any{"type": "disk", "path": "/tmp"},
+			expected:    Disk{Path: "/tmp"},
+		},
+		{
+			description: "unknown discriminator",
+			values:      map[string]any{"type": "gcs", "bucket": "konf"},
+			err:         `decode: 'Storage': unknown type "gcs" for konf_test.Storage, known: disk, s3`,
+		},
+		{
+			description: "missing discriminator",
+			values:      map[string]any{"bucket": "konf"},
+			err:         `decode: 'Storage': missing "type" for konf_test.Storage, known: disk, s3`,
+		},
+		{
+			description: "implementation in strict mode",
+			opts:        []konf.Option{konf.WithStrictUnmarshal()},
+			values:      map[string]any{"type": "s3", "bucket": "konf"},
+			expected:    &S3{Bucket: "konf"},
+		},
+		{
+			description: "unknown keys in strict mode",
+			opts:        []konf.Option{konf.WithStrictUnmarshal()},
+			values:      map[string]any{"type": "disk", "path": "/tmp", "bucket": "konf"},
+			err:         "decode: unknown keys: storage.bucket",
+		},
+		{
+			description: "invalid implementation with validation",
+			opts:        []konf.Option{konf.WithTagValidation()},
+			values:      map[string]any{"type": "s3"},
+			err:         "validate: 'storage.bucket' is required",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader{"storage": testcase.values}))
+
+			var value struct {
+				Storage Storage
+			}
+			err := config.Unmarshal("", &value)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, value.Storage)
+			}
+		})
+	}
+}
+
+type (
+	Storage interface {
+		Kind() string
+	}
+	S3 struct {
+		Bucket string `validate:"required"`
+	}
+	Disk struct {
+		Path string
+	}
+)
+
+func (*S3) Kind() string {
+	return "s3"
+}
+
+func (Disk) Kind() string {
+	return "disk"
+}
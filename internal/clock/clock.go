@@ -0,0 +1,141 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package clock provides the implementations of konf.Clock,
+// including the real clock and the fake clock for tests.
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Real is the clock backed by the wall clock.
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	timer := time.NewTimer(d)
+
+	return timer.C, timer.Stop
+}
+
+func (Real) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+
+	return ticker.C, ticker.Stop
+}
+
+// Fake is the clock which only moves forward by calling Fake.Advance.
+// It's used in tests to drive time deterministically.
+//
+// To create a new Fake, call [NewFake].
+type Fake struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	when   time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// NewFake creates a new Fake with the given current time.
+func NewFake(now time.Time) *Fake {
+	fake := &Fake{now: now}
+	fake.cond = sync.NewCond(&fake.mutex)
+
+	return fake
+}
+
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.now
+}
+
+func (f *Fake) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	w := f.add(d, 0)
+
+	return w.ch, func() bool { return f.remove(w) }
+}
+
+func (f *Fake) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	w := f.add(d, d)
+
+	return w.ch, func() { f.remove(w) }
+}
+
+// Advance moves the clock forward by the given duration,
+// and fires all timers and tickers that are due.
+func (f *Fake) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.now = f.now.Add(d)
+	waiters := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.when.After(f.now) {
+			waiters = append(waiters, w)
+
+			continue
+		}
+		select {
+		case w.ch <- f.now:
+		default:
+			// Drop the tick if the receiver is slow, same as time.Ticker.
+		}
+		if w.period > 0 {
+			for !w.when.After(f.now) {
+				w.when = w.when.Add(w.period)
+			}
+			waiters = append(waiters, w)
+		}
+	}
+	f.waiters = waiters
+}
+
+// BlockUntil blocks until there are at least n active timers and tickers.
+func (f *Fake) BlockUntil(n int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+func (f *Fake) add(d, period time.Duration) *waiter {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	w := &waiter{when: f.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	f.cond.Broadcast()
+
+	return w
+}
+
+func (f *Fake) remove(w *waiter) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	index := slices.Index(f.waiters, w)
+	if index < 0 {
+		return false
+	}
+	f.waiters = slices.Delete(f.waiters, index, index+1)
+
+	return true
+}
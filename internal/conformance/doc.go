@@ -0,0 +1,7 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package conformance runs konftest.ConformanceSuite against the providers in their own modules,
+// e.g. provider/file, with the konf in this repository via go.work,
+// so that the providers do not have to require and replace the konf in this repository.
+package conformance
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package conformance_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/konftest"
+	"github.com/nil-go/konf/provider/file"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	port := 8080
+	write := func(tb testing.TB) {
+		tb.Helper()
+
+		content := fmt.Sprintf(`{"server":{"port":%d,"hosts":["a",{"name":"b"}]}}`, port)
+		assert.NoError(tb, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write(t)
+	konftest.ConformanceSuite(t,
+		func() konf.Loader { return file.New(path) },
+		konftest.WithMutation(func(tb testing.TB) {
+			tb.Helper()
+
+			port++
+			write(tb)
+		}),
+	)
+}
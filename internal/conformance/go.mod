@@ -0,0 +1,3 @@
+module github.com/nil-go/konf/internal/conformance
+
+go 1.22
@@ -4,20 +4,26 @@
 package convert
 
 import (
+	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nil-go/konf/internal"
+	"github.com/nil-go/konf/internal/credential"
 	"github.com/nil-go/konf/internal/maps"
 )
 
 type Converter struct {
-	hooks   []hook
-	tagName string
-	keyMap  func(string) string
+	hooks     []hook
+	tagName   string
+	keyMap    func(string) string
+	implement func(name string, typ reflect.Type, from map[string]any) (any, map[string]any, error)
 }
 
 func New(opts ...Option) *Converter {
@@ -29,7 +35,39 @@ func New(opts ...Option) *Converter {
 	return (*Converter)(option)
 }
 
+// TagName returns the tag name that reads for field names.
+func (c Converter) TagName() string {
+	return c.tagName
+}
+
+// MapKey returns the key in the map for the given field name, which is mapped by the key mapper if any.
+func (c Converter) MapKey(name string) string {
+	if c.keyMap != nil {
+		return c.keyMap(name)
+	}
+
+	return name
+}
+
+// FieldKey returns the key in the map for the given struct field, the field name
+// (the name in the struct tag, or the name of the field if the tag has no name),
+// and the options in the struct tag, e.g. squash.
+func (c Converter) FieldKey(field reflect.StructField) (string, string, []string) {
+	name, tag, _ := strings.Cut(field.Tag.Get(c.tagName), ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return c.MapKey(name), name, strings.Split(tag, ",")
+}
+
 func (c Converter) Convert(from, to any) error {
+	return c.ConvertAt("", from, to)
+}
+
+// ConvertAt converts from to the to with the given name (path),
+// which is used in the error message and determines whether the value is sensitive.
+func (c Converter) ConvertAt(name string, from, to any) error {
 	toVal := reflect.ValueOf(to)
 	if toVal.Kind() != reflect.Pointer {
 		return errNotPointer
@@ -39,10 +77,10 @@ func (c Converter) Convert(from, to any) error {
 		return errNotAddressable
 	}
 
-	return c.convert("", from, toVal)
+	return c.convert(name, from, toVal)
 }
 
-func (c Converter) convert(name string, from any, toVal reflect.Value) error { //nolint:cyclop,funlen
+func (c Converter) convert(name string, from any, toVal reflect.Value) error {
 	if from == nil {
 		return nil // Do nothing if from is nil.
 	}
@@ -59,12 +97,43 @@ func (c Converter) convert(name string, from any, toVal reflect.Value) error { /
 		return fmt.Errorf("could be a bug: %w", errNotAddressable)
 	}
 
+	if err := c.convertValue(name, fromVal, toVal); err != nil {
+		switch fromVal.Kind() { //nolint:exhaustive
+		case reflect.Map, reflect.Struct, reflect.Interface:
+			// The error of nested value has been redacted with its own name.
+			return err
+		default:
+			return credential.Redact(name, from, err)
+		}
+	}
+
+	return nil
+}
+
+func (c Converter) convertValue(name string, fromVal, toVal reflect.Value) error { //nolint:cyclop
 	for _, h := range c.hooks {
-		if fromVal.Type().AssignableTo(h.fromType) && toVal.Type().AssignableTo(h.toType) {
-			if err := h.hook(fromVal.Interface(), toVal.Interface()); !errors.Is(err, errors.ErrUnsupported) {
-				return err
+		if !fromVal.Type().AssignableTo(h.fromType) {
+			continue
+		}
+		to := toVal
+		if !to.Type().AssignableTo(h.toType) {
+			// Try the address of the pointer so that the hook can replace the pointer itself,
+			// e.g. hook for *time.Location receives **time.Location.
+			if to.Kind() != reflect.Pointer || !to.CanAddr() || !to.Addr().Type().AssignableTo(h.toType) {
+				continue
 			}
+			to = to.Addr()
+		}
+
+		err := h.hook(fromVal.Interface(), to.Interface())
+		if errors.Is(err, errors.ErrUnsupported) {
+			continue
 		}
+		if err != nil && h.named && name != "" {
+			return fmt.Errorf("cannot parse '%s' as %s: %w", name, to.Type().Elem(), err)
+		}
+
+		return err
 	}
 
 	toVal = reflect.Indirect(toVal)
@@ -324,6 +393,16 @@ func (c Converter) convertArray(name string, fromVal, toVal reflect.Value) error
 
 		fallthrough
 	default:
+		if fromVal.Kind() == reflect.String && toVal.Type().Elem().Kind() == reflect.Uint8 {
+			// Convert the string to bytes first so that the hooks for []byte apply, e.g. base64.
+			var data []byte
+			if err := c.convertValue(name, fromVal, reflect.ValueOf(&data)); err != nil {
+				return err
+			}
+
+			return c.convertArray(name, reflect.ValueOf(data), toVal)
+		}
+
 		// All other types it tries to convert to the array type
 		// and "lift" it into it. i.e. a string becomes a string array.
 		// Just re-try this function with data as a slice.
@@ -350,7 +429,11 @@ func (c Converter) convertMap(name string, fromVal, toVal reflect.Value) error {
 		toValueType := toVal.Type().Elem()
 		errs := make([]error, 0, toVal.Len())
 		for _, fromKeyVal := range fromVal.MapKeys() {
-			fieldName := name + "[" + fromKeyVal.String() + "]"
+			// Same as the struct field, so that the name of the map value is the path of configuration.
+			fieldName := fromKeyVal.String()
+			if name != "" {
+				fieldName = name + "." + fieldName
+			}
 
 			fromValueVal := fromVal.MapIndex(fromKeyVal)
 			toValueVal := reflect.New(toValueType)
@@ -392,7 +475,9 @@ func (c Converter) convertPointer(name string, fromVal reflect.Value, toVal refl
 	}
 	toVal.Set(reflect.New(toVal.Type().Elem()))
 
-	return c.convert(name, fromVal.Interface(), reflect.Indirect(toVal.Elem()))
+	// Convert into the pointer rather than its element so that the hooks apply to the element type,
+	// e.g. the value of map[string]*time.Duration.
+	return c.convert(name, fromVal.Interface(), toVal)
 }
 
 func (c Converter) convertSlice(name string, fromVal, toVal reflect.Value) error { //nolint:cyclop
@@ -403,6 +488,12 @@ func (c Converter) convertSlice(name string, fromVal, toVal reflect.Value) error
 
 			return nil // avoid extra heap allocation
 		}
+		if fromVal.Kind() == reflect.Slice && fromVal.Type().Elem().Kind() == reflect.Uint8 &&
+			toVal.Type().Elem().Kind() == reflect.Uint8 {
+			toVal.SetBytes(bytes.Clone(fromVal.Bytes()))
+
+			return nil
+		}
 
 		toVal.Clear()
 		if toVal.Len() < fromVal.Len() {
@@ -530,11 +621,8 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 				}
 
 				// It always parse the tags cause it's looking for other tags too
-				fieldName, tag, _ := strings.Cut(fieldType.Tag.Get(c.tagName), ",")
-				if fieldName == "" {
-					fieldName = fieldType.Name
-				}
-				if tag == "squash" {
+				keyName, fieldName, tags := c.FieldKey(fieldType)
+				if slices.Contains(tags, "squash") {
 					if fieldVal.Kind() != reflect.Struct {
 						errs = append(errs, fmt.Errorf( //nolint:err113
 							"%s: unsupported type for squash: %s",
@@ -547,10 +635,6 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 					continue
 				}
 
-				keyName := fieldName
-				if c.keyMap != nil {
-					keyName = c.keyMap(keyName)
-				}
 				elemVal := fromVal.MapIndex(reflect.ValueOf(keyName))
 				if !elemVal.IsValid() {
 					// There was no matching key in the map for the value in the struct.
@@ -563,6 +647,11 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 				_, value := maps.Unpack(elemVal.Interface())
 				if err := c.convert(fieldName, value, pointer(fieldVal)); err != nil {
 					errs = append(errs, err)
+
+					continue
+				}
+				if err := checkBounds(fieldName, fieldVal, tags); err != nil {
+					errs = append(errs, credential.Redact(fieldName, reflect.Indirect(fieldVal).Interface(), err))
 				}
 			}
 		}
@@ -574,6 +663,18 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 }
 
 func (c Converter) convertInterface(name string, fromVal, toVal reflect.Value) error {
+	if c.implement != nil && toVal.Type().NumMethod() > 0 {
+		if from, ok := fromVal.Interface().(map[string]any); ok {
+			value, rest, err := c.implement(name, toVal.Type(), from)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				return c.convertImplementation(name, value, rest, toVal)
+			}
+		}
+	}
+
 	// Copy the value from map and slice to avoid the original value being modified.
 	switch fromVal.Kind() {
 	case reflect.Map:
@@ -603,6 +704,104 @@ func (c Converter) convertInterface(name string, fromVal, toVal reflect.Value) e
 	return nil
 }
 
+// convertImplementation converts the rest of map into the concrete value of the interface,
+// which is instantiated by the function provided by WithImplementation.
+func (c Converter) convertImplementation(name string, value any, rest map[string]any, toVal reflect.Value) error {
+	concrete := reflect.ValueOf(value)
+	target := concrete
+	if concrete.Kind() != reflect.Pointer {
+		target = reflect.New(concrete.Type())
+		target.Elem().Set(concrete)
+	}
+	if err := c.convert(name, rest, target); err != nil {
+		return err
+	}
+
+	if concrete.Kind() != reflect.Pointer {
+		target = target.Elem()
+	}
+	toVal.Set(target)
+
+	return nil
+}
+
+// checkBounds checks whether the value is in the inclusive bounds
+// specified by tags `min=` and `max=`.
+func checkBounds(name string, val reflect.Value, tags []string) error {
+	val = reflect.Indirect(val)
+	if !val.IsValid() {
+		return nil // Skip nil pointer.
+	}
+
+	var errs []error
+	for _, tag := range tags {
+		bound, limit, ok := strings.Cut(tag, "=")
+		if !ok || (bound != "min" && bound != "max") {
+			continue
+		}
+		if err := CheckBound(val, bound, limit); err != nil {
+			errs = append(errs, fmt.Errorf("'%s' %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckBound checks whether the number, or the length of string, slice and map
+// is in the inclusive bound of min or max. The bound of time.Duration is parsed as duration.
+//
+// It's shared by the `konf` tag options and the `validate` tag rules.
+func CheckBound(value reflect.Value, bound, limit string) error { //nolint:cyclop
+	var (
+		result int
+		err    error
+		actual any = value.Interface()
+	)
+	switch {
+	case value.Type() == reflect.TypeFor[time.Duration]():
+		var d time.Duration
+		if d, err = time.ParseDuration(limit); err == nil {
+			result = cmp.Compare(time.Duration(value.Int()), d)
+		}
+	case value.CanInt():
+		var i int64
+		if i, err = strconv.ParseInt(limit, 0, 64); err == nil {
+			result = cmp.Compare(value.Int(), i)
+		}
+	case value.CanUint():
+		var u uint64
+		if u, err = strconv.ParseUint(limit, 0, 64); err == nil {
+			result = cmp.Compare(value.Uint(), u)
+		}
+	case value.CanFloat():
+		var f float64
+		if f, err = strconv.ParseFloat(limit, 64); err == nil {
+			result = cmp.Compare(value.Float(), f)
+		}
+	case value.Kind() == reflect.String, value.Kind() == reflect.Slice,
+		value.Kind() == reflect.Map, value.Kind() == reflect.Array:
+		var n int
+		if n, err = strconv.Atoi(limit); err == nil {
+			result = cmp.Compare(value.Len(), n)
+			actual = "length " + strconv.Itoa(value.Len())
+		}
+	default:
+		return fmt.Errorf("%s does not support %s", bound, value.Type()) //nolint:err113
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", bound, limit, err)
+	}
+
+	if bound == "min" && result < 0 {
+		return fmt.Errorf("value %v is less than min %s", actual, limit) //nolint:err113
+	}
+	if bound == "max" && result > 0 {
+		return fmt.Errorf("value %v is greater than max %s", actual, limit) //nolint:err113
+	}
+
+	return nil
+}
+
 func pointer(val reflect.Value) reflect.Value {
 	if val.Kind() == reflect.Pointer {
 		if val.IsNil() {
@@ -624,4 +823,5 @@ type hook struct {
 	fromType reflect.Type
 	toType   reflect.Type
 	hook     func(from, to any) error
+	named    bool // Wrap the error with the name and type of the value.
 }
@@ -133,6 +133,37 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:       pointer([]*time.Duration(nil)),
 			expected: pointer([]*time.Duration{pointer(2 * time.Second)}),
 		},
+		{
+			description: "string to pointer (in slice)",
+			opts: []convert.Option{
+				convert.WithHook[string, *time.Duration](func(f string) (*time.Duration, error) {
+					duration, err := time.ParseDuration(f)
+
+					return &duration, err
+				}),
+			},
+			from:     []string{"2s"},
+			to:       pointer([]*time.Duration(nil)),
+			expected: pointer([]*time.Duration{pointer(2 * time.Second)}),
+		},
+		{
+			description: "string to duration (error in slice)",
+			opts: []convert.Option{
+				convert.WithHook[string, time.Duration](time.ParseDuration),
+			},
+			from: []string{"x"},
+			to:   pointer([]time.Duration(nil)),
+			err:  `time: invalid duration "x"`,
+		},
+		{
+			description: "string to duration (error with name)",
+			opts: []convert.Option{
+				convert.WithNamedHook[string, time.Duration](time.ParseDuration),
+			},
+			from: []string{"x"},
+			to:   pointer([]time.Duration(nil)),
+			err:  `cannot parse '[0]' as time.Duration: time: invalid duration "x"`,
+		},
 		{
 			description: "text unmarshaler",
 			opts: []convert.Option{
@@ -653,13 +684,13 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			description: "map to map (key convert error)",
 			from:        map[string]int{"-2": 42},
 			to:          pointer(map[uint]uint(nil)),
-			err:         "cannot parse '[-2]' as uint: strconv.ParseUint: parsing \"-2\": invalid syntax",
+			err:         "cannot parse '-2' as uint: strconv.ParseUint: parsing \"-2\": invalid syntax",
 		},
 		{
 			description: "map to map (value convert error)",
 			from:        map[string]int{"2": -42},
 			to:          pointer(map[uint]uint(nil)),
-			err:         "cannot parse '[2]', -42 overflows uint",
+			err:         "cannot parse '2', -42 overflows uint",
 		},
 		{
 			description: "slice to array (element convert error)",
@@ -759,6 +790,24 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer([]byte(nil)),
 			expected:    pointer([]byte{'s', 't', 'r'}),
 		},
+		{
+			description: "[]byte to []byte",
+			from:        []byte("str"),
+			to:          pointer([]byte(nil)),
+			expected:    pointer([]byte{'s', 't', 'r'}),
+		},
+		{
+			description: "string to [N]byte",
+			from:        "str",
+			to:          pointer([4]byte{}),
+			expected:    pointer([4]byte{'s', 't', 'r'}),
+		},
+		{
+			description: "[]byte to [N]byte",
+			from:        []byte("str"),
+			to:          pointer([3]byte{}),
+			expected:    pointer([3]byte{'s', 't', 'r'}),
+		},
 		// To string.
 		{
 			description: "bool to string (false)",
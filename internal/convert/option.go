@@ -20,6 +20,14 @@ func WithKeyMapper(keyMap func(string) string) Option {
 	}
 }
 
+func WithImplementation(
+	implement func(name string, typ reflect.Type, from map[string]any) (any, map[string]any, error),
+) Option {
+	return func(options *options) {
+		options.implement = implement
+	}
+}
+
 func WithHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) Option {
 	switch hookFunc := any(hook).(type) {
 	case func(F) (T, error):
@@ -39,6 +47,20 @@ func WithHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) Optio
 	}
 }
 
+// WithNamedHook is the same as WithHook, but the error of the hook is wrapped with the name
+// and the type of the value, e.g. `cannot parse 'zone' as *time.Location: ...`.
+func WithNamedHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) Option {
+	withHook := WithHook[F, T](hook)
+
+	return func(options *options) {
+		count := len(options.hooks)
+		withHook(options)
+		if len(options.hooks) > count {
+			options.hooks[count].named = true
+		}
+	}
+}
+
 func withHookFunc[F, T any](hookFunc func(F, T) error) Option {
 	return func(options *options) {
 		if hookFunc == nil {
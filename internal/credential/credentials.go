@@ -4,36 +4,90 @@
 package credential
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/nil-go/konf/internal"
 )
 
-func Blur(name string, value any) string {
-	if namePattern.MatchString(name) {
-		return "******"
-	}
+// Blurred is the replacement of the sensitive value.
+const Blurred = "******"
 
-	var formatted string
-	switch v := value.(type) {
-	case string:
-		formatted = v
-	case []byte:
-		formatted = internal.ByteSlice2String(v)
-	default:
-		formatted = fmt.Sprint(value)
+func Blur(name string, value any) string {
+	if Sensitive(name) {
+		return Blurred
 	}
 
+	formatted := Format(value)
 	for name, pattern := range secretsPatterns {
 		if pattern.MatchString(formatted) {
 			return name
 		}
 	}
+	if b, ok := value.([]byte); ok {
+		// The binary value is not readable, so only its length is shown.
+		return "<" + strconv.Itoa(len(b)) + " bytes>"
+	}
 
 	return formatted
 }
 
+// Sensitive reports whether the name indicates the value is sensitive, e.g. password.
+func Sensitive(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Redact replaces the value in the error message with the blurred one
+// if the value is sensitive.
+func Redact(name string, value any, err error) error {
+	return RedactAs(Blur(name, value), value, err)
+}
+
+// RedactAs replaces the value in the error message with the given blurred one
+// if it's different from the value.
+//
+// The value is only replaced where it's delimited as the value in the message, e.g. `parsing "value"`,
+// so that the short value, e.g. "a", does not corrupt other parts of the message.
+func RedactAs(blurred string, value any, err error) error {
+	if err == nil {
+		return nil
+	}
+	formatted := Format(value)
+	if formatted == "" {
+		return err
+	}
+	if blurred == formatted {
+		return err
+	}
+
+	message := err.Error()
+	redacted := strings.NewReplacer(
+		strconv.Quote(formatted), strconv.Quote(blurred), // e.g. strconv.NumError and %q.
+		"'"+formatted+"'", "'"+blurred+"'", // e.g. value: '%v'.
+		"value "+formatted+" ", "value "+blurred+" ", // e.g. value %v is less than min.
+	).Replace(message)
+	if redacted == message {
+		return err
+	}
+
+	// It does not wrap the original error since it may contain the sensitive value.
+	return errors.New(redacted) //nolint:err113
+}
+
+func Format(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return internal.ByteSlice2String(v)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
 //nolint:gochecknoglobals,lll
 var (
 	namePattern     = regexp.MustCompile(`(?i)password|passwd|pass|pwd|pw|secret|token|apiKey|bearer|cred`)
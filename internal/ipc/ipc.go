@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package ipc provides the wire format between konf.Serve and provider/ipc.
+//
+// Each message is the 4-byte big-endian length followed by the JSON of the configuration values.
+package ipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxSize is the maximum size of a message.
+const MaxSize = 64 << 20
+
+var errTooLarge = errors.New("message is too large")
+
+// Write writes the message of the given values.
+func Write(writer io.Writer, values map[string]any) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	if len(data) > MaxSize {
+		return errTooLarge
+	}
+
+	message := make([]byte, 4, 4+len(data)) //nolint:mnd
+	binary.BigEndian.PutUint32(message, uint32(len(data)))
+	if _, e := writer.Write(append(message, data...)); e != nil {
+		return fmt.Errorf("write: %w", e)
+	}
+
+	return nil
+}
+
+// Read reads the next message and returns the values in it.
+// The numbers are decoded as json.Number to keep the precision.
+func Read(reader io.Reader) (map[string]any, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(reader, size[:]); err != nil {
+		return nil, fmt.Errorf("read size: %w", err)
+	}
+	length := binary.BigEndian.Uint32(size[:])
+	if length > MaxSize {
+		return nil, errTooLarge
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values map[string]any
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package ipc_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/ipc"
+)
+
+func TestReadWrite(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, ipc.Write(buf, map[string]any{"server": map[string]any{"port": 8080}}))
+	assert.NoError(t, ipc.Write(buf, map[string]any{"k": "v"}))
+
+	values, err := ipc.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"server": map[string]any{"port": json.Number("8080")}}, values)
+	values, err = ipc.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+	_, err = ipc.Read(buf)
+	assert.EqualError(t, err, "read size: EOF")
+}
+
+func TestRead_error(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		data        []byte
+		err         string
+	}{
+		{
+			description: "too large",
+			data:        []byte{0xff, 0xff, 0xff, 0xff},
+			err:         "message is too large",
+		},
+		{
+			description: "truncated",
+			data:        []byte{0, 0, 0, 2, '{'},
+			err:         "read: unexpected EOF",
+		},
+		{
+			description: "invalid json",
+			data:        []byte{0, 0, 0, 1, '{'},
+			err:         "unmarshal: unexpected EOF",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ipc.Read(bytes.NewReader(testcase.data))
+			assert.EqualError(t, err, testcase.err)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+func TestDescend(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		rootPath    string
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "empty root path",
+			expected:    map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}, "d": 2}},
+		},
+		{
+			description: "root path",
+			rootPath:    "a.b",
+			expected:    map[string]any{"c": 1},
+		},
+		{
+			description: "not found",
+			rootPath:    "a.x",
+			err:         `root path "a.x" is not found`,
+		},
+		{
+			description: "not a map",
+			rootPath:    "a.d",
+			err:         `root path "a.d": int is not a map`,
+		},
+		{
+			description: "descend into leaf",
+			rootPath:    "a.d.e",
+			err:         `root path "a.d.e": int is not a map`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			values := map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}, "d": 2}}
+			root, err := maps.Descend(values, testcase.rootPath)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, root)
+			}
+		})
+	}
+}
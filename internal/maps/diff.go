@@ -0,0 +1,71 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package maps
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+)
+
+// Diff returns the paths of leaf values which are different between the given values, sorted by path.
+func Diff(oldValues, newValues map[string]any) [][]string {
+	var paths [][]string
+	diff(&paths, nil, oldValues, newValues)
+	slices.SortFunc(paths, slices.Compare)
+
+	return paths
+}
+
+func diff(paths *[][]string, path []string, oldValue, newValue any) {
+	_, oldValue = Unpack(oldValue)
+	_, newValue = Unpack(newValue)
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+	if !oldIsMap && !newIsMap {
+		if !Equal(oldValue, newValue) {
+			*paths = append(*paths, slices.Clone(path))
+		}
+
+		return
+	}
+	// The leaf value is replaced by map, or vice versa.
+	if (!oldIsMap && oldValue != nil) || (!newIsMap && newValue != nil) {
+		*paths = append(*paths, slices.Clone(path))
+	}
+
+	for key, value := range oldMap {
+		diff(paths, append(path, key), value, newMap[key])
+	}
+	for key, value := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			diff(paths, append(path, key), nil, value)
+		}
+	}
+}
+
+// Equal reports whether the given values are deeply equal,
+// while []byte values are compared with bytes.Equal so that nil and empty are equal.
+func Equal(x, y any) bool {
+	switch xv := x.(type) {
+	case []byte:
+		yv, ok := y.([]byte)
+
+		return ok && bytes.Equal(xv, yv)
+	case map[string]any:
+		yv, ok := y.(map[string]any)
+		if !ok || len(xv) != len(yv) {
+			return false
+		}
+		for key, value := range xv {
+			if other, exist := yv[key]; !exist || !Equal(value, other) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(x, y)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		oldValues   map[string]any
+		newValues   map[string]any
+		expected    [][]string
+	}{
+		{
+			description: "nil values",
+		},
+		{
+			description: "same values",
+			oldValues:   map[string]any{"a": map[string]any{"b": 1, "c": []any{1}}},
+			newValues:   map[string]any{"a": map[string]any{"b": 1, "c": []any{1}}},
+		},
+		{
+			description: "changed values",
+			oldValues:   map[string]any{"a": map[string]any{"b": 1, "c": []any{1}}, "d": 1},
+			newValues:   map[string]any{"a": map[string]any{"b": 2, "c": []any{2}}, "d": 1},
+			expected:    [][]string{{"a", "b"}, {"a", "c"}},
+		},
+		{
+			description: "added and removed values",
+			oldValues:   map[string]any{"a": map[string]any{"b": 1}},
+			newValues:   map[string]any{"c": map[string]any{"d": 1}},
+			expected:    [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			description: "leaf replaced by map",
+			oldValues:   map[string]any{"a": 1},
+			newValues:   map[string]any{"a": map[string]any{"b": 1}},
+			expected:    [][]string{{"a"}, {"a", "b"}},
+		},
+		{
+			description: "bytes values",
+			oldValues:   map[string]any{"a": []byte("a"), "b": []byte{}, "c": []byte("c")},
+			newValues:   map[string]any{"a": []byte("a"), "b": []byte(nil), "c": []byte("C")},
+			expected:    [][]string{{"c"}},
+		},
+		{
+			description: "packed values",
+			oldValues:   map[string]any{"a": maps.Pack("A", 1)},
+			newValues:   map[string]any{"a": 1},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testcase.expected, maps.Diff(testcase.oldValues, testcase.newValues))
+		})
+	}
+}
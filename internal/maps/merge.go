@@ -3,30 +3,79 @@
 
 package maps
 
+import "slices"
+
 // Merge recursively merges the src map into the dst map.
 // Key conflicts are resolved by preferring src,
 // or recursively descending, if both values from src and dst are map.
 func Merge(dst, src map[string]any) {
+	merge(dst, src, nil, nil, nil)
+}
+
+// MergeReplace is the same as Merge, but the map in src replaces the map in dst
+// instead of recursively descending if replace returns true for the path of the map.
+func MergeReplace(dst, src map[string]any, replace func(path []string) bool) {
+	merge(dst, src, nil, replace, nil)
+}
+
+// MergeResolve is the same as MergeReplace, but the conflict of leaf values in dst and src
+// is resolved by resolve with the path of the leaf instead of preferring src.
+// The values passed to resolve are unpacked, and the result is packed with the key of src if necessary.
+func MergeResolve(
+	dst, src map[string]any,
+	replace func(path []string) bool,
+	resolve func(path []string, dst, src any) any,
+) {
+	merge(dst, src, nil, replace, resolve)
+}
+
+func merge( //nolint:cyclop
+	dst, src map[string]any,
+	path []string,
+	replace func([]string) bool,
+	resolve func([]string, any, any) any,
+) {
 	for key, srcVal := range src {
+		var keyPath []string
+		if replace != nil || resolve != nil {
+			keyPath = append(slices.Clip(path), key)
+		}
+
 		// Direct override if the srcVal is not map[string]any.
-		srcMap, srcOk := srcVal.(map[string]any)
+		// The map packed with its original key is still merged, see TransformKeys.
+		srcKey, srcInner := Unpack(srcVal)
+		srcMap, srcOk := srcInner.(map[string]any)
 		if !srcOk {
+			_, lower := Unpack(dst[key])
+			if _, dstIsMap := lower.(map[string]any); resolve != nil && lower != nil && !dstIsMap {
+				srcVal = packed(srcKey, resolve(keyPath, lower, srcInner))
+			}
 			dst[key] = srcVal
 
 			continue
 		}
 
-		// Direct override if the dstVal is not map[string]any.
-		dstMap, dstOk := dst[key].(map[string]any)
-		if !dstOk {
+		// Direct override if the dstVal is not map[string]any, or the map should be replaced.
+		_, dstInner := Unpack(dst[key])
+		dstMap, dstOk := dstInner.(map[string]any)
+		if !dstOk || replace != nil && replace(keyPath) {
 			values := make(map[string]any)
-			Merge(values, srcMap)
-			dst[key] = values
+			merge(values, srcMap, keyPath, nil, nil)
+			dst[key] = packed(srcKey, values)
 
 			continue
 		}
 
 		// Merge if the srcVal and dstVal are both map[string]any.
-		Merge(dstMap, srcMap)
+		merge(dstMap, srcMap, keyPath, replace, resolve)
+		dst[key] = packed(srcKey, dstMap) // The original key of src takes precedence.
+	}
+}
+
+func packed(key string, value any) any {
+	if key == "" {
+		return value
 	}
+
+	return Pack(key, value)
 }
@@ -73,6 +73,18 @@ func TestMerge(t *testing.T) {
 			dst:         map[string]any{"a": map[string]any{"x": 3}},
 			expected:    map[string]any{"a": map[string]any{"x": 3, "X": 2}},
 		},
+		{
+			description: "packed map",
+			src:         map[string]any{"a": maps.Pack("A", map[string]any{"y": 2})},
+			dst:         map[string]any{"a": map[string]any{"x": 1}},
+			expected:    map[string]any{"a": maps.Pack("A", map[string]any{"x": 1, "y": 2})},
+		},
+		{
+			description: "map into packed map",
+			src:         map[string]any{"a": map[string]any{"y": 2}},
+			dst:         map[string]any{"a": maps.Pack("A", map[string]any{"x": 1})},
+			expected:    map[string]any{"a": map[string]any{"x": 1, "y": 2}},
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -84,3 +96,61 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeReplace(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string]any{
+		"endpoints": map[string]any{"a": "old-a", "b": "old-b"},
+		"server":    map[string]any{"nested": map[string]any{"x": 1, "y": 2}},
+	}
+	src := map[string]any{
+		"endpoints": map[string]any{"a": "new-a"},
+		"server":    map[string]any{"nested": map[string]any{"x": 3}},
+	}
+	maps.MergeReplace(dst, src, func(path []string) bool {
+		return len(path) == 1 && path[0] == "endpoints" || len(path) == 2 && path[1] == "nested"
+	})
+	assert.Equal(t, map[string]any{
+		"endpoints": map[string]any{"a": "new-a"},
+		"server":    map[string]any{"nested": map[string]any{"x": 3}},
+	}, dst)
+}
+
+func TestMergeResolve(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string]any{
+		"rate":   10,
+		"hosts":  []string{"a"},
+		"server": map[string]any{"port": 8080},
+		"name":   maps.Pack("Name", "dst"),
+	}
+	src := map[string]any{
+		"rate":   5,
+		"hosts":  []string{"b"},
+		"server": 9090,
+		"name":   maps.Pack("NAME", "src"),
+		"new":    1,
+	}
+	var paths [][]string
+	maps.MergeResolve(dst, src, nil, func(path []string, dst, src any) any {
+		paths = append(paths, path)
+		switch dst := dst.(type) {
+		case int:
+			return max(dst, src.(int))
+		case []string:
+			return append(dst, src.([]string)...)
+		default:
+			return src
+		}
+	})
+	assert.Equal(t, map[string]any{
+		"rate":   10,
+		"hosts":  []string{"a", "b"},
+		"server": 9090,
+		"name":   maps.Pack("NAME", "src"),
+		"new":    1,
+	}, dst)
+	assert.Equal(t, 3, len(paths))
+}
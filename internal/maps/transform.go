@@ -3,22 +3,69 @@
 
 package maps
 
+import "slices"
+
+// TransformKeys recursively transforms the keys of the src map with the keyMap.
+// The keys which are transformed into the same key are merged into one subtree, in lexical order of the keys,
+// so that the later key takes precedence for the conflict of leaf values, e.g. `server` over `Server`.
+// If mapKeyCaseSensitive is true, the transformed value is packed with its original key.
 func TransformKeys(src map[string]any, keyMap func(string) string, mapKeyCaseSensitive bool) {
 	if src == nil || keyMap == nil {
 		return
 	}
+
+	keys := make([]string, 0, len(src))
+	changed := false
 	for key, value := range src {
+		keys = append(keys, key)
+		changed = changed || keyMap(key) != key
 		if m, ok := value.(map[string]any); ok {
 			TransformKeys(m, keyMap, mapKeyCaseSensitive)
 		}
+	}
+	if !changed {
+		return
+	}
+	slices.Sort(keys)
+
+	transformed := make(map[string]any, len(src))
+	for _, key := range keys {
+		value := src[key]
 		newKey := keyMap(key)
-		if newKey != key {
-			delete(src, key)
-			if mapKeyCaseSensitive {
-				src[newKey] = Pack(key, value)
-			} else {
-				src[newKey] = value
-			}
+		if newKey != key && mapKeyCaseSensitive {
+			value = Pack(key, value)
+		}
+		if existing, ok := transformed[newKey]; ok {
+			value = mergeValue(existing, value)
+		}
+		transformed[newKey] = value
+	}
+	clear(src)
+	for key, value := range transformed {
+		src[key] = value
+	}
+}
+
+// mergeValue merges the value into the existing value if both are map, otherwise the value takes precedence.
+// The result is packed with the original key of the value if any.
+func mergeValue(existing, value any) any {
+	_, existingValue := Unpack(existing)
+	key, val := Unpack(value)
+	existingMap, existingOk := existingValue.(map[string]any)
+	valueMap, valueOk := val.(map[string]any)
+	if !existingOk || !valueOk {
+		return value
+	}
+
+	for k, v := range valueMap {
+		if e, ok := existingMap[k]; ok {
+			v = mergeValue(e, v)
 		}
+		existingMap[k] = v
 	}
+	if key == "" {
+		return existingMap
+	}
+
+	return Pack(key, existingMap)
 }
@@ -43,6 +43,30 @@ func TestTransformKeys(t *testing.T) {
 			mapKeyCaseSensitive: true,
 			expected:            map[string]any{"a": maps.Pack("A", map[string]any{"x": maps.Pack("X", 1), "y": 2})},
 		},
+		{
+			description: "merge keys transformed into the same key",
+			src: map[string]any{
+				"Server": map[string]any{"Port": 8080, "Host": "a"},
+				"server": map[string]any{"port": 9090, "TLS": map[string]any{"Enabled": true}},
+				"SERVER": map[string]any{"tls": map[string]any{"cert": "c"}},
+			},
+			keyMap: strings.ToLower,
+			expected: map[string]any{
+				"server": map[string]any{"port": 9090, "host": "a", "tls": map[string]any{"enabled": true, "cert": "c"}},
+			},
+		},
+		{
+			description: "merge keys transformed into the same key (map key case sensitive)",
+			src: map[string]any{
+				"SERVER": map[string]any{"Port": 8080},
+				"Server": map[string]any{"host": "a"},
+			},
+			keyMap:              strings.ToLower,
+			mapKeyCaseSensitive: true,
+			expected: map[string]any{
+				"server": maps.Pack("Server", map[string]any{"port": maps.Pack("Port", 8080), "host": "a"}),
+			},
+		},
 	}
 
 	for _, testcase := range testcases {
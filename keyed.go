@@ -0,0 +1,229 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// UnmarshalKeyed reads configuration under the given path from the Config
+// and decodes it into the given target with conversion between keyed map and slice.
+//
+// If the target is a pointer to slice of structs and the configuration is a map,
+// each value of the map is decoded into a struct with the key field set to the map key,
+// and the slice is sorted by the map keys, e.g. `servers: {a: {...}, b: {...}}` into []Server.
+// If the target is a pointer to map of structs and the configuration is a slice,
+// each element is decoded into a struct and then keyed by the key field in the map.
+// It returns error if the keys are duplicated after normalization.
+// Otherwise, it's the same as Config.Unmarshal.
+//
+// The key field is the field with tag `konf:",key"` (for the default tag name),
+// or the field named by the option KeyField.
+//
+// This method is concurrent-safe.
+func (c *Config) UnmarshalKeyed(path string, target any, opts ...KeyedOption) error { //nolint:cyclop
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.UnmarshalKeyed(c.parentPath(path), target, opts...)
+	}
+
+	option := &keyedOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Pointer || targetVal.IsNil() {
+		return errKeyedTarget
+	}
+	targetVal = targetVal.Elem()
+
+	value, err := c.sub(c.splitPath(path))
+	if err != nil {
+		return err
+	}
+	switch from := value.(type) {
+	case map[string]any:
+		if targetVal.Kind() == reflect.Slice {
+			return c.unmarshalMapToSlice(path, from, targetVal, option.keyField)
+		}
+	case []any:
+		if targetVal.Kind() == reflect.Map {
+			return c.unmarshalSliceToMap(path, from, targetVal, option.keyField)
+		}
+	}
+
+	return c.Unmarshal(path, target)
+}
+
+func (c *Config) unmarshalMapToSlice(path string, from map[string]any, targetVal reflect.Value, keyField string) error {
+	elemType := targetVal.Type().Elem()
+	field, err := c.keyField(elemType, keyField)
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		key   string
+		value any
+	}
+	entries := make([]entry, 0, len(from))
+	for key, value := range from {
+		originalKey, value := maps.Unpack(value)
+		if originalKey != "" {
+			key = originalKey
+		}
+		entries = append(entries, entry{key: key, value: value})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	converter := c.decoder()
+	slice := reflect.MakeSlice(targetVal.Type(), len(entries), len(entries))
+	var errs []error
+	for i, entry := range entries {
+		elemPath := c.joinPath(path, entry.key)
+		elemVal := slice.Index(i)
+		if elemType.Kind() == reflect.Pointer {
+			elemVal.Set(reflect.New(elemType.Elem()))
+		} else {
+			elemVal = elemVal.Addr()
+		}
+		if err := converter.ConvertAt(elemPath, entry.value, elemVal.Interface()); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+		keyVal := elemVal.Elem().FieldByIndex(field).Addr()
+		if err := converter.ConvertAt(elemPath, entry.key, keyVal.Interface()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	targetVal.Set(slice)
+
+	return nil
+}
+
+func (c *Config) unmarshalSliceToMap(path string, from []any, targetVal reflect.Value, keyField string) error {
+	elemType := targetVal.Type().Elem()
+	field, err := c.keyField(elemType, keyField)
+	if err != nil {
+		return err
+	}
+
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	converter := c.decoder()
+	result := reflect.MakeMapWithSize(targetVal.Type(), len(from))
+	var errs []error
+	for i, value := range from {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		elemPtr := reflect.New(structType)
+		if err := converter.ConvertAt(elemPath, value, elemPtr.Interface()); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		keyVal := elemPtr.Elem().FieldByIndex(field)
+		if keyVal.Kind() == reflect.String && !c.caseSensitive && !c.mapKeyCaseSensitive {
+			keyVal = reflect.ValueOf(defaultKeyMap(keyVal.String()))
+		}
+		key := reflect.New(targetVal.Type().Key())
+		if err := converter.ConvertAt(elemPath, keyVal.Interface(), key.Interface()); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+		if result.MapIndex(key.Elem()).IsValid() {
+			errs = append(errs, fmt.Errorf("%s: %w: %v", elemPath, errDuplicatedKey, key.Elem().Interface()))
+
+			continue
+		}
+		if elemType.Kind() == reflect.Pointer {
+			result.SetMapIndex(key.Elem(), elemPtr)
+		} else {
+			result.SetMapIndex(key.Elem(), elemPtr.Elem())
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	targetVal.Set(result)
+
+	return nil
+}
+
+// keyField returns the index of the key field in the given struct type (or pointer to struct).
+func (c *Config) keyField(typ reflect.Type, name string) ([]int, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %s", errKeyedTarget, typ)
+	}
+
+	if name != "" {
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("%s has no key field %s: %w", typ, name, errNoKeyField)
+		}
+
+		return field.Index, nil
+	}
+
+	tagName := c.decoder().TagName()
+	for _, field := range reflect.VisibleFields(typ) {
+		if _, tag, _ := strings.Cut(field.Tag.Get(tagName), ","); tag == "key" {
+			return field.Index, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s has no field with tag `%s:\",key\"`: %w", typ, tagName, errNoKeyField)
+}
+
+func (c *Config) joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + c.delim() + key
+}
+
+type (
+	// KeyedOption configures Config.UnmarshalKeyed with specific options.
+	KeyedOption  func(*keyedOptions)
+	keyedOptions struct {
+		keyField string
+	}
+)
+
+// KeyField provides the name of the struct field that holds the key for Config.UnmarshalKeyed,
+// which takes precedence over the field with tag `konf:",key"`.
+func KeyField(name string) KeyedOption {
+	return func(options *keyedOptions) {
+		options.keyField = name
+	}
+}
+
+var (
+	errKeyedTarget   = errors.New("target must be a pointer to slice or map of structs")
+	errNoKeyField    = errors.New("key field not found")
+	errDuplicatedKey = errors.New("duplicated key")
+)
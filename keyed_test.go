@@ -0,0 +1,155 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+type keyedServer struct {
+	Name string `konf:",key"`
+	Port int
+}
+
+func TestConfig_UnmarshalKeyed(t *testing.T) {
+	t.Parallel()
+
+	servers := mapLoader{
+		"servers": map[string]any{
+			"b": map[string]any{"port": 2},
+			"a": map[string]any{"port": 1, "name": "ignored"},
+		},
+	}
+	list := mapLoader{
+		"servers": []any{
+			map[string]any{"name": "B", "port": 2},
+			map[string]any{"name": "a", "port": 1},
+		},
+	}
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		loader      konf.Loader
+		target      func() any
+		keyedOpts   []konf.KeyedOption
+		expected    any
+		err         string
+	}{
+		{
+			description: "map to slice",
+			loader:      servers,
+			target:      func() any { return &[]keyedServer{} },
+			expected:    &[]keyedServer{{Name: "a", Port: 1}, {Name: "b", Port: 2}},
+		},
+		{
+			description: "map to slice of pointers",
+			loader:      servers,
+			target:      func() any { return &[]*keyedServer{} },
+			expected:    &[]*keyedServer{{Name: "a", Port: 1}, {Name: "b", Port: 2}},
+		},
+		{
+			description: "map to slice with key field",
+			loader:      servers,
+			target: func() any {
+				return &[]struct {
+					ID   string
+					Port int
+				}{}
+			},
+			keyedOpts: []konf.KeyedOption{konf.KeyField("ID")},
+			expected: &[]struct {
+				ID   string
+				Port int
+			}{{ID: "a", Port: 1}, {ID: "b", Port: 2}},
+		},
+		{
+			description: "map to slice (map key case sensitive)",
+			opts:        []konf.Option{konf.WithMapKeyCaseSensitive()},
+			loader:      mapLoader{"servers": map[string]any{"A": map[string]any{"port": 1}}},
+			target:      func() any { return &[]keyedServer{} },
+			expected:    &[]keyedServer{{Name: "A", Port: 1}},
+		},
+		{
+			description: "slice to map",
+			loader:      list,
+			target:      func() any { return &map[string]keyedServer{} },
+			expected:    &map[string]keyedServer{"a": {Name: "a", Port: 1}, "b": {Name: "B", Port: 2}},
+		},
+		{
+			description: "slice to map of pointers",
+			loader:      list,
+			target:      func() any { return &map[string]*keyedServer{} },
+			expected:    &map[string]*keyedServer{"a": {Name: "a", Port: 1}, "b": {Name: "B", Port: 2}},
+		},
+		{
+			description: "slice to map with duplicated keys",
+			loader: mapLoader{
+				"servers": []any{
+					map[string]any{"name": "A", "port": 2},
+					map[string]any{"name": "a", "port": 1},
+				},
+			},
+			target: func() any { return &map[string]keyedServer{} },
+			err:    "decode: servers[1]: duplicated key: a",
+		},
+		{
+			description: "map to map",
+			loader:      servers,
+			target:      func() any { return &map[string]keyedServer{} },
+			expected:    &map[string]keyedServer{"a": {Name: "ignored", Port: 1}, "b": {Port: 2}},
+		},
+		{
+			description: "slice to slice",
+			loader:      list,
+			target:      func() any { return &[]keyedServer{} },
+			expected:    &[]keyedServer{{Name: "B", Port: 2}, {Name: "a", Port: 1}},
+		},
+		{
+			description: "no key field",
+			loader:      servers,
+			target:      func() any { return &[]struct{ Port int }{} },
+			err:         "struct { Port int } has no field with tag `konf:\",key\"`: key field not found",
+		},
+		{
+			description: "unknown key field",
+			loader:      servers,
+			target:      func() any { return &[]keyedServer{} },
+			keyedOpts:   []konf.KeyedOption{konf.KeyField("ID")},
+			err:         "konf_test.keyedServer has no key field ID: key field not found",
+		},
+		{
+			description: "non struct",
+			loader:      servers,
+			target:      func() any { return &[]string{} },
+			err:         "target must be a pointer to slice or map of structs, got string",
+		},
+		{
+			description: "non pointer",
+			loader:      servers,
+			target:      func() any { return []keyedServer{} },
+			err:         "target must be a pointer to slice or map of structs",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(testcase.loader))
+			target := testcase.target()
+			err := config.UnmarshalKeyed("servers", target, testcase.keyedOpts...)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, target)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// KeyInfo is the freshness metadata of the value for a path, returned by Config.KeyInfo.
+type KeyInfo struct {
+	Value any
+	// Loader is the loader with the highest precedence which provides the value.
+	Loader Loader
+	// LastChanged is the time when the value was changed by Config.Watch,
+	// or the time when the loader provided the value was loaded or changed.
+	LastChanged time.Time
+	// Version is the version of ChangeEvent which changed the value,
+	// or 0 if it has not changed since loaded.
+	Version uint64
+}
+
+// KeyInfo returns the freshness metadata of the value for the given path.
+// It returns zero KeyInfo if there is no value for the path.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// The changes of each key are only tracked with konf.WithKeyInfo. Otherwise,
+// LastChanged is the last time the loader provided the value was loaded or changed, and Version is 0.
+//
+// This method is concurrent-safe.
+func (c *Config) KeyInfo(path string) KeyInfo {
+	if c == nil { // To support nil
+		return KeyInfo{}
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.KeyInfo(c.parentPath(path))
+	}
+
+	keys := c.splitPath(path)
+	value, _ := c.sub(keys)
+	_, value = maps.Unpack(value)
+	if value == nil {
+		return KeyInfo{}
+	}
+	info := KeyInfo{Value: value}
+	_, computed := c.computedAt(keys)
+	if computed {
+		info.Loader = computedLoader{}
+	}
+	c.providers.traverse(func(provider *provider) {
+		if computed || provider.disabled.Load() || maps.Sub(*provider.values.Load(), keys) == nil {
+			return
+		}
+		info.Loader = provider.loader
+		if updated := provider.updated.Load(); updated != nil {
+			info.LastChanged = *updated
+		}
+	})
+	if change, ok := c.keyChanges.get(strings.Join(keys, c.delim()), c.delim()); ok {
+		info.LastChanged, info.Version = change.time, change.version
+	}
+
+	return info
+}
+
+type (
+	keyChanges struct {
+		changes map[string]keyChange
+		mutex   sync.RWMutex
+	}
+	keyChange struct {
+		time    time.Time
+		version uint64
+	}
+)
+
+// record records the change for each changed key, which is only called with konf.WithKeyInfo,
+// so that the memory is only used for the keys changed since loaded.
+func (k *keyChanges) record(event *ChangeEvent) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.changes == nil {
+		k.changes = make(map[string]keyChange)
+	}
+	for _, key := range event.Keys {
+		k.changes[key] = keyChange{time: event.Time, version: event.Version}
+	}
+}
+
+// get returns the last change of the value for the given path,
+// including the changes of its parents and children.
+func (k *keyChanges) get(path, delim string) (keyChange, bool) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	var (
+		last  keyChange
+		found bool
+	)
+	for key, change := range k.changes {
+		related := key == path || strings.HasPrefix(key, path+delim) || strings.HasPrefix(path, key+delim)
+		if related && change.version > last.version {
+			last, found = change, true
+		}
+	}
+
+	return last, found
+}
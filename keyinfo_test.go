@@ -0,0 +1,85 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestConfig_KeyInfo(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		expected    func(loaded, changed time.Time, loader konf.Loader) konf.KeyInfo
+	}{
+		{
+			description: "with key info",
+			opts:        []konf.Option{konf.WithKeyInfo()},
+			expected: func(_, changed time.Time, loader konf.Loader) konf.KeyInfo {
+				return konf.KeyInfo{Value: 9090, Loader: loader, LastChanged: changed, Version: 1}
+			},
+		},
+		{
+			description: "without key info",
+			expected: func(_, changed time.Time, loader konf.Loader) konf.KeyInfo {
+				return konf.KeyInfo{Value: 9090, Loader: loader, LastChanged: changed}
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			loaded := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			fake := clock.NewFake(loaded)
+			config := konf.New(append(testcase.opts, konf.WithClock(fake))...)
+			file := mapLoader{"server": map[string]any{"host": "localhost", "port": 8080}}
+			assert.NoError(t, config.Load(file))
+			watcher := mapWatcher{values: map[string]any{"server": map[string]any{"port": 80}}, change: make(chan map[string]any)}
+			assert.NoError(t, config.Load(watcher))
+
+			assert.Equal(t, konf.KeyInfo{Value: 80, Loader: watcher, LastChanged: loaded}, config.KeyInfo("Server.Port"))
+			assert.Equal(t, konf.KeyInfo{}, config.KeyInfo("server.tls"))
+
+			stopped := make(chan struct{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer func() {
+				cancel()
+				<-stopped
+			}()
+			go func() {
+				defer close(stopped)
+				assert.NoError(t, config.Watch(ctx))
+			}()
+			waitWatch(t, config)
+
+			changed := make(chan struct{})
+			config.OnChange(func(*konf.Config) { close(changed) }, "server.port")
+			fake.Advance(time.Hour)
+			watcher.change <- map[string]any{"server": map[string]any{"port": 9090}}
+			<-changed
+
+			expected := testcase.expected(loaded, loaded.Add(time.Hour), watcher)
+			assert.Equal(t, expected, config.KeyInfo("server.port"))
+			assert.Equal(t, konf.KeyInfo{Value: "localhost", Loader: file, LastChanged: loaded}, config.KeyInfo("server.host"))
+			assert.Equal(t, expected.Version, config.KeyInfo("server").Version)
+		})
+	}
+}
+
+func TestConfig_KeyInfo_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, konf.KeyInfo{}, config.KeyInfo("port"))
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+// Keys returns the paths of all leaf values in the Config, joined by the delimiter and sorted,
+// e.g. `server.http.port`, including the computed keys provided by konf.WithComputed.
+// The keys are lower case unless konf.WithCaseSensitive is set.
+// The slice is a leaf value, so the path stops at it without index segments, and the empty map has no path.
+//
+// The paths are read from a single snapshot of the merged values,
+// so they are consistent even while Config.Watch is applying a change.
+//
+// This method is concurrent-safe.
+func (c *Config) Keys() []string {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	var keys []string
+	if values, ok := c.values().(map[string]any); ok {
+		c.root().walk("", values, func(path string) { keys = append(keys, path) })
+	}
+
+	return keys
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Keys(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		expected    []string
+	}{
+		{
+			description: "default",
+			expected:    []string{"server.hosts", "server.http.port", "server.tls", "timeout", "worker.count"},
+		},
+		{
+			description: "custom delimiter",
+			opts:        []konf.Option{konf.WithDelimiter("/")},
+			expected:    []string{"server/hosts", "server/http/port", "server/tls", "timeout", "worker/count"},
+		},
+		{
+			description: "computed",
+			opts: []konf.Option{
+				konf.WithComputed("worker.total", nil, func(*konf.Config) (any, error) { return 8, nil }),
+			},
+			expected: []string{"server.hosts", "server.http.port", "server.tls", "timeout", "worker.count", "worker.total"},
+		},
+		{
+			description: "case sensitive",
+			opts:        []konf.Option{konf.WithCaseSensitive()},
+			expected:    []string{"Timeout", "server.HTTP.port", "server.hosts", "server.tls", "worker.count"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(konf.Defaults(map[string]any{"server": map[string]any{"tls": false}})))
+			assert.NoError(t, config.Load(mapLoader{
+				"server": map[string]any{
+					"HTTP":  map[string]any{"port": 8080},
+					"hosts": []string{"a", "b"},
+					"grpc":  map[string]any{},
+				},
+				"Timeout": "1s",
+				"worker":  map[string]any{"count": 4},
+			}))
+			assert.Equal(t, testcase.expected, config.Keys())
+		})
+	}
+}
+
+func TestConfig_Keys_empty(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, nil, config.Keys())
+	config = &konf.Config{}
+	assert.Equal(t, nil, config.Keys())
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konftest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+type (
+	// ConformanceOption configures ConformanceSuite with specific options.
+	ConformanceOption  func(*conformanceOptions)
+	conformanceOptions struct {
+		mutate  func(tb testing.TB)
+		timeout time.Duration
+	}
+)
+
+// WithMutation provides the function which changes the values of the loader externally, e.g. writing the file,
+// so that ConformanceSuite verifies konf.Watcher delivers the change.
+// The suite cannot tell when the watcher is ready to observe the mutation, so it mutates again
+// until the watcher delivers the change. Hence, it must change the values on every call.
+func WithMutation(mutate func(tb testing.TB)) ConformanceOption {
+	return func(options *conformanceOptions) {
+		options.mutate = mutate
+	}
+}
+
+// WithTimeout provides the timeout for waiting konf.Watcher to return or deliver the change.
+//
+// By default, it's 5 seconds.
+func WithTimeout(timeout time.Duration) ConformanceOption {
+	return func(options *conformanceOptions) {
+		if timeout > 0 {
+			options.timeout = timeout
+		}
+	}
+}
+
+// ConformanceSuite runs the behavioral checks which every provider must pass, against the loaders created by newLoader.
+// It's the bar for the contributed providers. The checks are:
+//
+//   - String: fmt.Stringer returns non-empty string, if it's implemented.
+//   - Load: repeated Load returns the same values, and the values are not shared between Load,
+//     so the caller can modify them.
+//   - Watch: konf.Watcher returns once ctx is done, even if ctx has been canceled before watching.
+//     With konf.WithMutation, it also verifies the change is delivered with the same values as Load after mutation.
+//   - Status: konf.Statuser does not panic while watching if the callback is nil.
+//
+// Each check creates its own loader, so newLoader must return a new loader every time.
+func ConformanceSuite(t *testing.T, newLoader func() konf.Loader, opts ...ConformanceOption) {
+	t.Helper()
+
+	option := &conformanceOptions{timeout: 5 * time.Second} //nolint:mnd
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	t.Run("String", func(t *testing.T) {
+		if stringer, ok := newLoader().(fmt.Stringer); ok && stringer.String() == "" {
+			t.Error("String returns empty string")
+		}
+	})
+	t.Run("Load", func(t *testing.T) {
+		loader := newLoader()
+		values := load(t, loader)
+		expected := deepCopy(values)
+		mutate(values)
+		if actual := load(t, loader); !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Load returns the values modified via the previous Load:\n  actual: %v\nexpected: %v", actual, expected)
+		}
+	})
+	t.Run("Watch", func(t *testing.T) {
+		if _, ok := newLoader().(konf.Watcher); !ok {
+			t.Skip("It's not a konf.Watcher.")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		watch(ctx, t, option, newLoader(), func(map[string]any) {})
+
+		ctx, cancel = context.WithCancel(context.Background())
+		changes := make(chan map[string]any, 1)
+		done := watch(ctx, t, option, newLoader(), latest(changes))
+		if option.mutate != nil {
+			deliver(t, option, newLoader, changes)
+		}
+		cancel()
+		wait(t, option, done)
+	})
+	t.Run("Status", func(t *testing.T) {
+		loader := newLoader()
+		statuser, ok := loader.(konf.Statuser)
+		if !ok {
+			t.Skip("It's not a konf.Statuser.")
+		}
+		statuser.Status(nil)
+		if _, ok := loader.(konf.Watcher); !ok {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := make(chan map[string]any, 1)
+		done := watch(ctx, t, option, loader, latest(changes))
+		if option.mutate != nil {
+			deliver(t, option, newLoader, changes)
+		}
+		cancel()
+		wait(t, option, done)
+	})
+}
+
+func load(tb testing.TB, loader konf.Loader) map[string]any {
+	tb.Helper()
+
+	values, err := loader.Load()
+	if err != nil {
+		tb.Fatalf("Load returns error: %v", err)
+	}
+
+	return values
+}
+
+// watch starts watching the loader in a goroutine, and returns the channel closed once Watch returns.
+func watch(
+	ctx context.Context, tb testing.TB, option *conformanceOptions, loader konf.Loader, onChange func(map[string]any),
+) <-chan struct{} {
+	tb.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				tb.Errorf("Watch panics: %v", r)
+			}
+		}()
+
+		_ = loader.(konf.Watcher).Watch(ctx, onChange) //nolint:forcetypeassert
+	}()
+	if ctx.Err() != nil {
+		wait(tb, option, done)
+	}
+
+	return done
+}
+
+func wait(tb testing.TB, option *conformanceOptions, done <-chan struct{}) {
+	tb.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(option.timeout):
+		tb.Fatalf("Watch does not return in %v after ctx is done", option.timeout)
+	}
+}
+
+// latest returns the onChange which keeps the latest values only in the given channel.
+func latest(changes chan map[string]any) func(map[string]any) {
+	return func(values map[string]any) {
+		for {
+			select {
+			case changes <- values:
+				return
+			default:
+				select {
+				case <-changes:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// deliver mutates the loader externally until the watcher delivers the change with the same values as Load.
+// The delivered change is the signal that the watcher is ready, so it never waits for a fixed time.
+func deliver(tb testing.TB, option *conformanceOptions, newLoader func() konf.Loader, changes <-chan map[string]any) {
+	tb.Helper()
+
+	timeout := time.After(option.timeout)
+	for first := true; ; first = false {
+		before := load(tb, newLoader())
+		option.mutate(tb)
+		expected := load(tb, newLoader())
+		if first && reflect.DeepEqual(before, expected) {
+			tb.Fatal("The mutation does not change the values of Load.")
+		}
+
+		retry := time.After(option.timeout / 10) //nolint:mnd
+		for waiting := true; waiting; {
+			select {
+			case values := <-changes:
+				if reflect.DeepEqual(expected, values) {
+					return
+				}
+			case <-retry:
+				waiting = false // The watcher may have not been ready for the mutation.
+			case <-timeout:
+				tb.Fatalf("Watch does not deliver the change in %v", option.timeout)
+			}
+		}
+	}
+}
+
+func deepCopy(value map[string]any) map[string]any {
+	copied, _ := deepCopyValue(value).(map[string]any)
+
+	return copied
+}
+
+func deepCopyValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(value))
+		for key, val := range value {
+			copied[key] = deepCopyValue(val)
+		}
+
+		return copied
+	case []any:
+		copied := make([]any, len(value))
+		for i, val := range value {
+			copied[i] = deepCopyValue(val)
+		}
+
+		return copied
+	default:
+		return value
+	}
+}
+
+// mutate modifies the values in place recursively.
+func mutate(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, val := range value {
+			switch val.(type) {
+			case map[string]any, []any:
+				mutate(val)
+			default:
+				value[key] = "konftest"
+			}
+		}
+		value["konftest"] = "konftest"
+	case []any:
+		for i, val := range value {
+			switch val.(type) {
+			case map[string]any, []any:
+				mutate(val)
+			default:
+				value[i] = "konftest"
+			}
+		}
+	}
+}
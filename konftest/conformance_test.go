@@ -0,0 +1,80 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konftest_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/konftest"
+)
+
+func TestConformanceSuite(t *testing.T) {
+	t.Parallel()
+
+	source := &mapSource{port: 8080}
+	konftest.ConformanceSuite(t,
+		func() konf.Loader { return mapWatcher{source: source} },
+		konftest.WithMutation(func(testing.TB) { source.mutate() }),
+	)
+}
+
+// mapSource is the in-memory source shared by mapWatcher(s), which notifies its watchers on each mutation.
+type mapSource struct {
+	port     int
+	watchers map[*func(map[string]any)]struct{}
+	mutex    sync.Mutex
+}
+
+func (s *mapSource) values() map[string]any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return map[string]any{"server": map[string]any{"port": s.port, "hosts": []any{"a", map[string]any{"name": "b"}}}}
+}
+
+func (s *mapSource) mutate() {
+	s.mutex.Lock()
+	s.port++
+	watchers := make([]func(map[string]any), 0, len(s.watchers))
+	for watcher := range s.watchers {
+		watchers = append(watchers, *watcher)
+	}
+	s.mutex.Unlock()
+
+	for _, watcher := range watchers {
+		watcher(s.values())
+	}
+}
+
+type mapWatcher struct {
+	source *mapSource
+}
+
+func (m mapWatcher) Load() (map[string]any, error) {
+	return m.source.values(), nil
+}
+
+func (m mapWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	m.source.mutex.Lock()
+	if m.source.watchers == nil {
+		m.source.watchers = make(map[*func(map[string]any)]struct{})
+	}
+	m.source.watchers[&onChange] = struct{}{}
+	m.source.mutex.Unlock()
+
+	<-ctx.Done()
+
+	m.source.mutex.Lock()
+	delete(m.source.watchers, &onChange)
+	m.source.mutex.Unlock()
+
+	return nil
+}
+
+func (mapWatcher) String() string {
+	return "map"
+}
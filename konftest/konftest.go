@@ -0,0 +1,53 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package konftest provides the helpers for testing with konf.Config.
+package konftest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/credential"
+)
+
+// Equal asserts that the given configurations have the same merged values as konf.Equal.
+// Otherwise, it fails the test with the differing keys and both values, which are blurred if they are sensitive.
+func Equal(tb testing.TB, expected, actual *konf.Config) {
+	tb.Helper()
+
+	if message := Diff(expected, actual); message != "" {
+		tb.Errorf("configurations are not equal:\n%s", message)
+	}
+}
+
+// Diff returns the readable message of the differences between the given configurations,
+// or empty string if they are equal. The values are blurred if they are sensitive.
+func Diff(expected, actual *konf.Config) string {
+	diffs := konf.DiffConfigs(expected, actual)
+	if len(diffs) == 0 {
+		return ""
+	}
+
+	var message strings.Builder
+	for _, diff := range diffs {
+		message.WriteString("  ")
+		message.WriteString(diff.Path)
+		message.WriteString(":\n      actual: ")
+		message.WriteString(format(diff.Path, diff.B))
+		message.WriteString("\n    expected: ")
+		message.WriteString(format(diff.Path, diff.A))
+		message.WriteString("\n")
+	}
+
+	return message.String()
+}
+
+func format(path string, value any) string {
+	if value == nil {
+		return "<missing>"
+	}
+
+	return credential.Blur(path, value)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konftest_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/konftest"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	expected := konf.New()
+	assert.NoError(t, expected.Load(mapLoader{"server": map[string]any{"host": "localhost", "password": "secret"}}))
+	actual := konf.New()
+	assert.NoError(t, actual.Load(mapLoader{"server": map[string]any{"password": "changed", "port": 8080}}))
+
+	assert.Equal(t, ""+
+		"  server.host:\n      actual: <missing>\n    expected: localhost\n"+
+		"  server.password:\n      actual: ******\n    expected: ******\n"+
+		"  server.port:\n      actual: 8080\n    expected: <missing>\n",
+		konftest.Diff(expected, actual),
+	)
+	assert.Equal(t, "", konftest.Diff(expected, expected))
+	assert.Equal(t, "", konftest.Diff(nil, konf.New()))
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	expected := konf.New()
+	assert.NoError(t, expected.Load(mapLoader{"server": map[string]any{"port": 8080}}))
+	actual := konf.New()
+	assert.NoError(t, actual.Load(mapLoader{"server": map[string]any{"port": 8080.0}}))
+
+	konftest.Equal(t, expected, actual)
+}
+
+type mapLoader map[string]any
+
+func (m mapLoader) Load() (map[string]any, error) {
+	return m, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+type (
+	// UnmarshalOption configures Config.Unmarshal with specific options.
+	UnmarshalOption  func(*unmarshalOptions)
+	unmarshalOptions struct {
+		fields []string
+		strict bool
+	}
+)
+
+// Fields decodes only the given fields of the target struct in Config.Unmarshal,
+// and leaves all other fields untouched, e.g. for patching the config-backed object.
+// The field is matched by the field name or the name in the struct tag,
+// and the nested field is selected by the dotted path, e.g. `TLS.MinVersion`.
+// Config.Unmarshal returns error if any field is not found in the target struct.
+func Fields(fields ...string) UnmarshalOption {
+	return func(options *unmarshalOptions) {
+		options.fields = append(options.fields, fields...)
+	}
+}
+
+var errUnknownField = errors.New("unknown field")
+
+// fieldMask is the tree of the selected keys, where the nil fieldMask selects the whole value.
+type fieldMask map[string]fieldMask
+
+// fieldMask returns the mask of keys for the given fields of the struct type pointed to by target.
+func (c *Config) fieldMask(target any, fields []string) (fieldMask, error) {
+	root := reflect.TypeOf(target)
+	for root != nil && root.Kind() == reflect.Pointer {
+		root = root.Elem()
+	}
+	if root == nil || root.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mask fields: %T is not a pointer to struct", target) //nolint:err113
+	}
+
+	mask := make(fieldMask)
+	var errs []error
+	for _, field := range fields {
+		typ := root
+		keys := make([]string, 0, strings.Count(field, ".")+1)
+		for _, segment := range strings.Split(field, ".") {
+			for typ != nil && typ.Kind() == reflect.Pointer {
+				typ = typ.Elem()
+			}
+			fieldType, key, ok := c.maskedField(typ, segment)
+			if !ok {
+				errs = append(errs, fmt.Errorf("mask fields: %w %s in %s", errUnknownField, field, root))
+				keys = nil
+
+				break
+			}
+			typ = fieldType
+			keys = append(keys, key)
+		}
+		mask.add(keys)
+	}
+
+	return mask, errors.Join(errs...)
+}
+
+// maskedField returns the type and the key of the field matching the given name in the struct type,
+// including the fields of the squashed structs.
+func (c *Config) maskedField(typ reflect.Type, name string) (reflect.Type, string, bool) {
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, "", false
+	}
+	var (
+		fieldType reflect.Type
+		fieldKey  string
+	)
+	found := !c.visitFields(typ, func(field reflect.StructField, key, tagName string, _ []string) bool {
+		if field.Name != name && tagName != name {
+			return true
+		}
+		fieldType, fieldKey = field.Type, key
+
+		return false
+	})
+
+	return fieldType, fieldKey, found
+}
+
+// add selects the value at the given keys.
+func (m fieldMask) add(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	child, exist := m[keys[0]]
+	switch {
+	case len(keys) == 1:
+		m[keys[0]] = nil // Select the whole value of the field.
+	case exist && child == nil:
+		// The whole value of the field has been selected.
+	default:
+		if !exist {
+			child = make(fieldMask)
+			m[keys[0]] = child
+		}
+		child.add(keys[1:])
+	}
+}
+
+// masked returns the copy of the given value with only the keys selected by the mask.
+func (m fieldMask) masked(value any) any {
+	if m == nil {
+		return value
+	}
+	_, value = maps.Unpack(value)
+	values, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	masked := make(map[string]any, len(m))
+	for key, child := range m {
+		if val, exist := values[key]; exist {
+			masked[key] = child.masked(val)
+		}
+	}
+
+	return masked
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	type (
+		TLS struct {
+			MinVersion string
+			Cert       string
+		}
+		Base struct {
+			Name string
+		}
+		Server struct {
+			Base         `konf:",squash"`
+			Host         string
+			ReadTimeout  time.Duration
+			WriteTimeout time.Duration `konf:"write_timeout"`
+			TLS          TLS
+			Proxy        *TLS
+		}
+	)
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"server": map[string]any{
+			"name":          "loaded",
+			"host":          "loaded.example.com",
+			"readTimeout":   "5s",
+			"write_timeout": "10s",
+			"tls":           map[string]any{"minVersion": "1.3", "cert": "loaded.pem"},
+			"proxy":         map[string]any{"minVersion": "1.3", "cert": "loaded.pem"},
+		},
+	}))
+	current := func() Server {
+		return Server{
+			Base:         Base{Name: "current"},
+			Host:         "current.example.com",
+			ReadTimeout:  time.Second,
+			WriteTimeout: time.Second,
+			TLS:          TLS{MinVersion: "1.2", Cert: "current.pem"},
+			Proxy:        &TLS{MinVersion: "1.2", Cert: "current.pem"},
+		}
+	}
+
+	testcases := []struct {
+		description string
+		fields      []string
+		expected    func(*Server)
+		err         string
+	}{
+		{
+			description: "field names",
+			fields:      []string{"ReadTimeout", "WriteTimeout"},
+			expected: func(server *Server) {
+				server.ReadTimeout = 5 * time.Second
+				server.WriteTimeout = 10 * time.Second
+			},
+		},
+		{
+			description: "tag name",
+			fields:      []string{"write_timeout"},
+			expected: func(server *Server) {
+				server.WriteTimeout = 10 * time.Second
+			},
+		},
+		{
+			description: "nested fields",
+			fields:      []string{"TLS.MinVersion", "Proxy.Cert"},
+			expected: func(server *Server) {
+				server.TLS.MinVersion = "1.3"
+				server.Proxy.Cert = "loaded.pem"
+			},
+		},
+		{
+			description: "whole struct with nested field",
+			fields:      []string{"TLS", "TLS.MinVersion"},
+			expected: func(server *Server) {
+				server.TLS = TLS{MinVersion: "1.3", Cert: "loaded.pem"}
+			},
+		},
+		{
+			description: "squashed field",
+			fields:      []string{"Name"},
+			expected: func(server *Server) {
+				server.Name = "loaded"
+			},
+		},
+		{
+			description: "unknown fields",
+			fields:      []string{"Host", "Port", "TLS.Key", "Host.Name"},
+			err: "mask fields: unknown field Port in konf_test.Server\n" +
+				"mask fields: unknown field TLS.Key in konf_test.Server\n" +
+				"mask fields: unknown field Host.Name in konf_test.Server",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			server := current()
+			err := config.Unmarshal("server", &server, konf.Fields(testcase.fields...))
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, current(), server)
+
+				return
+			}
+			assert.NoError(t, err)
+			expected := current()
+			testcase.expected(&expected)
+			assert.Equal(t, expected, server)
+		})
+	}
+}
+
+func TestFields_notStruct(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "example.com"}}))
+
+	var server map[string]any
+	assert.EqualError(t, config.Unmarshal("server", &server, konf.Fields("host")),
+		"mask fields: *map[string]interface {} is not a pointer to struct")
+}
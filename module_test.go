@@ -0,0 +1,89 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf/internal/assert"
+)
+
+// TestModule_stdlib guarantees the root module stays stdlib-only.
+// The providers with external dependencies must live in their own nested modules.
+func TestModule_stdlib(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Open("go.mod")
+	assert.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var module string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if name, ok := strings.CutPrefix(line, "module "); ok {
+			module = name
+		}
+		if line == "require" || strings.HasPrefix(line, "require ") || strings.HasPrefix(line, "require(") {
+			t.Errorf("go.mod has requirement: %q", line)
+		}
+	}
+	assert.NoError(t, scanner.Err())
+	assert.Equal(t, "github.com/nil-go/konf", module)
+
+	fset := token.NewFileSet()
+	assert.NoError(t, filepath.WalkDir(".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path == "testdata" || strings.HasSuffix(path, string(filepath.Separator)+"testdata") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); path != "." && err == nil {
+				return filepath.SkipDir // Skip nested modules.
+			}
+
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		source, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, spec := range source.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return err
+			}
+			first, _, _ := strings.Cut(importPath, "/")
+			switch {
+			case !strings.Contains(first, "."): // Stdlib.
+			case importPath == module:
+			case strings.HasPrefix(importPath, module+"/"):
+				// The package must not belong to a nested module.
+				for dir := strings.TrimPrefix(importPath, module+"/"); dir != "."; dir = filepath.Dir(dir) {
+					if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+						t.Errorf("%s imports package %q in nested module", path, importPath)
+					}
+				}
+			default:
+				t.Errorf("%s imports non-stdlib package %q", path, importPath)
+			}
+		}
+
+		return nil
+	}))
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import "strings"
+
+// NamespaceView is the view of Config for the namespace created by konf.Namespace,
+// e.g. the configuration subtree of a tenant in the multi-tenant process.
+// It reads the values of the Config directly, so that creating view is cheap and never copies data.
+type NamespaceView struct {
+	config *Config
+	levels []string // The namespace and its fallbacks, from the highest to the lowest precedence.
+}
+
+type (
+	// NamespaceOption configures konf.Namespace with specific options.
+	NamespaceOption  func(*namespaceOptions)
+	namespaceOptions struct {
+		fallbacks []string
+	}
+)
+
+// FallbackTo provides the paths of the shared subtrees the namespace falls back to,
+// from the highest to the lowest precedence.
+func FallbackTo(paths ...string) NamespaceOption {
+	return func(options *namespaceOptions) {
+		options.fallbacks = append(options.fallbacks, paths...)
+	}
+}
+
+// Namespace returns the view of the given Config for the namespace under the given path,
+// e.g. `konf.Namespace(config, "tenants.acme", konf.FallbackTo("defaults"))`.
+// The value under the namespace overlays the values under the fallbacks provided by konf.FallbackTo:
+// it's deep merged if both are maps, or takes precedence otherwise, the same as Config.UnmarshalFor.
+//
+// This function is concurrent-safe.
+func Namespace(config *Config, path string, opts ...NamespaceOption) *NamespaceView {
+	option := &namespaceOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return &NamespaceView{config: config, levels: append([]string{path}, option.fallbacks...)}
+}
+
+// Unmarshal reads configuration under the given path in the namespace
+// and decodes it into the given object pointed to by target, the same as Config.Unmarshal.
+//
+// This method is concurrent-safe.
+func (n *NamespaceView) Unmarshal(path string, target any) error {
+	if n.config == nil { // To support nil
+		return nil
+	}
+	n.config.nocopy.Check()
+
+	value, err := n.sub(path)
+	if err != nil {
+		return err
+	}
+
+	return n.config.decode(path, value, target)
+}
+
+// Exists reports whether the given path exists in the namespace or any of its fallbacks.
+//
+// This method is concurrent-safe.
+func (n *NamespaceView) Exists(path string) bool {
+	_, ok := n.Level(path)
+
+	return ok
+}
+
+// Level returns the path of the namespace or fallback which supplies the value for the given path,
+// or false if none of them has the path. For the map value, it's the level with the highest precedence,
+// and Explain shows the level of each leaf value.
+//
+// This method is concurrent-safe.
+func (n *NamespaceView) Level(path string) (string, bool) {
+	if n.config == nil { // To support nil
+		return "", false
+	}
+	n.config.nocopy.Check()
+
+	for _, level := range n.levels {
+		if value, _ := n.levelSub(level, path); value != nil {
+			return level, true
+		}
+	}
+
+	return "", false
+}
+
+// OnChange registers a callback function that is executed
+// when the value of any given path changes in the namespace or any of its fallbacks.
+// The empty paths mean any path in the namespace and its fallbacks.
+// The callback receives the view, so that it reads the namespace with its fallbacks.
+//
+// The validation of onChange and paths is the same as Config.OnChange.
+//
+// This method is concurrent-safe.
+func (n *NamespaceView) OnChange(onChange func(*NamespaceView), paths ...string) {
+	if n.config == nil { // To support nil
+		return
+	}
+
+	var configOnChange func(*Config)
+	if onChange != nil {
+		configOnChange = func(*Config) { onChange(n) }
+	}
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+	levelPaths := make([]string, 0, len(n.levels)*len(paths))
+	for _, path := range paths {
+		for _, level := range n.levels {
+			levelPath := n.config.joinPath(level, path)
+			if levelPath == "" {
+				levelPaths = nil // The root namespace means any path.
+
+				break
+			}
+			levelPaths = append(levelPaths, levelPath)
+		}
+		if levelPaths == nil {
+			break
+		}
+	}
+	n.config.registerOnChange(configOnChange, levelPaths, 2) //nolint:mnd
+}
+
+// Explain provides information about how the namespace resolves each value for the given path,
+// the same as Config.Explain, with the full path which shows the level supplies the value.
+//
+// This method is concurrent-safe.
+func (n *NamespaceView) Explain(path string) string {
+	if n.config == nil { // To support nil
+		return path + " has no configuration.\n\n"
+	}
+	n.config.nocopy.Check()
+
+	value, _ := n.sub(path)
+	if value == nil {
+		return path + " has no configuration.\n\n"
+	}
+	explanation := &strings.Builder{}
+	n.config.walk(path, value, func(path string) {
+		for _, level := range n.levels {
+			if value, _ := n.levelSub(level, path); value != nil {
+				n.config.explain(explanation, n.config.joinPath(level, path), value, false)
+
+				return
+			}
+		}
+	})
+
+	return explanation.String()
+}
+
+// sub returns the value for the given path, which overlays the levels from the lowest precedence.
+func (n *NamespaceView) sub(path string) (any, error) {
+	var value any
+	for i := len(n.levels) - 1; i >= 0; i-- {
+		override, err := n.levelSub(n.levels[i], path)
+		if err != nil {
+			return nil, err
+		}
+		value = overlay(value, override)
+	}
+
+	return value, nil
+}
+
+func (n *NamespaceView) levelSub(level, path string) (any, error) {
+	return n.config.sub(n.config.splitPath(n.config.joinPath(level, path)))
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"defaults": map[string]any{
+			"server": map[string]any{"host": "localhost", "port": 8080, "tls": map[string]any{"enabled": false}},
+			"limit":  10,
+		},
+		"tenants": map[string]any{
+			"acme": map[string]any{"server": map[string]any{"port": 9090}},
+			"beta": map[string]any{"limit": 100},
+		},
+	}))
+
+	testcases := []struct {
+		description string
+		namespace   string
+		path        string
+		expected    any
+		level       string
+	}{
+		{
+			description: "partially overridden",
+			namespace:   "tenants.acme",
+			path:        "server",
+			expected: map[string]any{
+				"host": "localhost",
+				"port": 9090,
+				"tls":  map[string]any{"enabled": false},
+			},
+			level: "tenants.acme",
+		},
+		{
+			description: "overridden",
+			namespace:   "tenants.acme",
+			path:        "server.port",
+			expected:    9090,
+			level:       "tenants.acme",
+		},
+		{
+			description: "fallback",
+			namespace:   "tenants.acme",
+			path:        "limit",
+			expected:    10,
+			level:       "defaults",
+		},
+		{
+			description: "fallback for subtree",
+			namespace:   "tenants.beta",
+			path:        "server.tls",
+			expected:    map[string]any{"enabled": false},
+			level:       "defaults",
+		},
+		{
+			description: "unknown namespace",
+			namespace:   "tenants.unknown",
+			path:        "limit",
+			expected:    10,
+			level:       "defaults",
+		},
+		{
+			description: "non-existing path",
+			namespace:   "tenants.acme",
+			path:        "non-existing",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			view := konf.Namespace(config, testcase.namespace, konf.FallbackTo("defaults"))
+			var value any
+			assert.NoError(t, view.Unmarshal(testcase.path, &value))
+			assert.Equal(t, testcase.expected, value)
+			level, ok := view.Level(testcase.path)
+			assert.Equal(t, testcase.level, level)
+			assert.Equal(t, testcase.level != "", ok)
+			assert.Equal(t, testcase.level != "", view.Exists(testcase.path))
+		})
+	}
+}
+
+func TestNamespace_explain(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"defaults": map[string]any{"server": map[string]any{"host": "localhost", "port": 8080}},
+		"tenants":  map[string]any{"acme": map[string]any{"server": map[string]any{"port": 9090}}},
+	}))
+
+	view := konf.Namespace(config, "tenants.acme", konf.FallbackTo("defaults"))
+	expected := `defaults.server.host has value[localhost] that is loaded by loader[map].
+
+tenants.acme.server.port has value[9090] that is loaded by loader[map].
+
+`
+	assert.Equal(t, expected, view.Explain("server"))
+	assert.Equal(t, "non-existing has no configuration.\n\n", view.Explain("non-existing"))
+}
+
+func TestNamespace_nil(t *testing.T) {
+	t.Parallel()
+
+	view := konf.Namespace(nil, "tenants.acme", konf.FallbackTo("defaults"))
+	var value string
+	assert.NoError(t, view.Unmarshal("server", &value))
+	assert.Equal(t, "", value)
+	assert.True(t, !view.Exists("server"))
+	view.OnChange(func(*konf.NamespaceView) {}, "server")
+}
+
+func TestNamespace_onChange(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{
+		values: map[string]any{"defaults": map[string]any{"limit": 10}, "other": 1},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	view := konf.Namespace(config, "tenants.acme", konf.FallbackTo("defaults"))
+	changed := make(chan int)
+	view.OnChange(func(view *konf.NamespaceView) {
+		var limit int
+		assert.NoError(t, view.Unmarshal("limit", &limit))
+		changed <- limit
+	}, "limit")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// Change of shared value.
+	watcher.change <- map[string]any{"defaults": map[string]any{"limit": 20}, "other": 1}
+	assert.Equal(t, 20, <-changed)
+	// Change outside of namespace.
+	watcher.change <- map[string]any{"defaults": map[string]any{"limit": 20}, "other": 2}
+	// Change of namespace's value.
+	watcher.change <- map[string]any{
+		"defaults": map[string]any{"limit": 20}, "other": 2,
+		"tenants": map[string]any{"acme": map[string]any{"limit": 200}},
+	}
+	assert.Equal(t, 200, <-changed)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"strings"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// NormalizeTrimSpace is the normalizer for konf.WithValueNormalizer
+// which removes the leading and trailing white spaces of string values.
+func NormalizeTrimSpace(_ string, value any) any {
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+
+	return value
+}
+
+// NormalizeBytes is the normalizer for konf.WithValueNormalizer
+// which converts []byte values to string.
+func NormalizeBytes(_ string, value any) any {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+
+	return value
+}
+
+// store stores the values into the provider with each leaf normalized
+// by the normalizers provided by konf.WithValueNormalizer, and returns the old and new normalized values.
+// The given values are kept unchanged as the raw values of the provider.
+func (c *Config) store(provider *provider, values map[string]any) (map[string]any, map[string]any) {
+	if len(c.normalizers) > 0 {
+		raw := values
+		provider.raw.Store(&raw)
+		values = c.normalizeMap("", raw)
+	}
+
+	now := c.timeSource().Now()
+	provider.updated.Store(&now)
+	provider.degraded.Store(false)
+	var oldValues map[string]any
+	if old := provider.values.Swap(&values); old != nil {
+		oldValues = *old
+	}
+
+	return oldValues, values
+}
+
+func (c *Config) normalizeMap(path string, values map[string]any) map[string]any {
+	normalized := make(map[string]any, len(values))
+	for key, value := range values {
+		originalKey, value := maps.Unpack(value)
+		valuePath := c.joinPath(path, key)
+		if m, ok := value.(map[string]any); ok {
+			value = c.normalizeMap(valuePath, m)
+		} else {
+			for _, normalizer := range c.normalizers {
+				value = normalizer(valuePath, value)
+			}
+		}
+		if originalKey != "" {
+			value = maps.Pack(originalKey, value)
+		}
+		normalized[key] = value
+	}
+
+	return normalized
+}
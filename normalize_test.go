@@ -0,0 +1,100 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_WithValueNormalizer(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	config := konf.New(
+		konf.WithValueNormalizer(konf.NormalizeBytes),
+		konf.WithValueNormalizer(konf.NormalizeTrimSpace),
+		konf.WithValueNormalizer(func(path string, value any) any {
+			paths = append(paths, path)
+			if s, ok := value.(string); ok && strings.HasSuffix(path, "dir") {
+				return strings.ReplaceAll(s, `\`, "/")
+			}
+
+			return value
+		}),
+	)
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": " example.com\n"}}))
+	assert.NoError(t, config.Load(mapLoader{
+		"server": map[string]any{"name": []byte(" server "), "port": 8080},
+		"Dir":    `C:\Program Files\App`,
+	}))
+	slices.Sort(paths)
+	assert.Equal(t, []string{"dir", "server.host", "server.name", "server.port"}, paths)
+
+	var server struct {
+		Host string
+		Name string
+		Port int
+	}
+	assert.NoError(t, config.Unmarshal("server", &server))
+	assert.Equal(t, "example.com", server.Host)
+	assert.Equal(t, "server", server.Name)
+	assert.Equal(t, 8080, server.Port)
+	var dir string
+	assert.NoError(t, config.Unmarshal("dir", &dir))
+	assert.Equal(t, "C:/Program Files/App", dir)
+
+	assert.Equal(t, "server.host has value[example.com] that is loaded by loader[map].\n\n",
+		config.Explain("server.host"))
+	assert.Equal(t, "server.host has value[example.com (raw:  example.com\n)] that is loaded by loader[map].\n\n",
+		config.Explain("server.host", konf.RawValue()))
+	assert.Equal(t, "server.port has value[8080] that is loaded by loader[map].\n\n",
+		config.Explain("server.port", konf.RawValue()))
+}
+
+func TestConfig_WithValueNormalizer_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithValueNormalizer(konf.NormalizeTrimSpace))
+	watcher := mapWatcher{values: map[string]any{"tls": "disabled"}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	changes := make(chan string, 2)
+	config.OnChange(func(config *konf.Config) {
+		var tls string
+		assert.NoError(t, config.Unmarshal("tls", &tls))
+		changes <- tls
+	}, "tls")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"tls": " disabled "} // Only differs before normalization.
+	watcher.change <- map[string]any{"tls": "enabled "}
+	assert.Equal(t, "enabled", <-changes)
+	assert.Equal(t, 0, len(changes))
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a b", konf.NormalizeTrimSpace("", " a b\t"))
+	assert.Equal(t, any(1), konf.NormalizeTrimSpace("", 1))
+	assert.Equal(t, "bytes", konf.NormalizeBytes("", []byte("bytes")))
+	assert.Equal(t, any(" s "), konf.NormalizeBytes("", " s "))
+}
@@ -4,7 +4,10 @@
 package konf
 
 import (
+	"io"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/nil-go/konf/internal/convert"
 )
@@ -23,6 +26,8 @@ func WithDelimiter(delimiter string) Option {
 // The tag name is used when decoding configuration into structs.
 //
 // For example, with the default tag name `konf`, it would look for `konf` tags on struct fields.
+// It applies to all struct fields, including the nested, squashed and slice of structs,
+// as well as the key paths in Config.Describe, konf.Fields and validation errors.
 func WithTagName(tagName string) Option {
 	return func(options *options) {
 		options.tagName = tagName
@@ -30,13 +35,27 @@ func WithTagName(tagName string) Option {
 }
 
 // WithDecodeHook provides the decode hook for decoding.
-// The decode hook is a function that can customize how configuration are decoded.
+// The decode hook is a function that can customize how configuration are decoded,
+// and applies to all Config.Unmarshal calls of the Config, including the ones in the callbacks of Config.OnChange.
 //
 // It can be either `func(F) (T, error)` which returns the converted value,
 // or `func(F, T) error` which sets the converted value inline.
+// The hook may return errors.ErrUnsupported to fall back to the next hook for the same types.
+// It can be provided multiple times, and the hooks are tried in the order provided, ahead of the default hooks.
 //
-// By default, it composes string to time.Duration, string to []string split by `,`
-// and string to encoding.TextUnmarshaler.
+// By default, it composes string to time.Duration, string to []string split by `,`,
+// string to encoding.TextUnmarshaler, and number to encoding.TextUnmarshaler of struct type
+// (e.g. big.Int, big.Rat and third-party decimal types) with the exact text of the number.
+// The float64 number beyond the exact integer range of float64 is rejected since it may have lost precision.
+// It also composes string to *time.Location, which accepts the name in IANA Time Zone database,
+// "UTC", "Local", and fixed offset like "+02:00", and string to url.URL and *url.URL.
+// The hooks apply to the elements of slices, maps and pointers as well, e.g. map[string]time.Duration.
+// The string with "base64:" or "hex:" prefix is decoded into []byte and [N]byte,
+// while other strings are converted with their raw bytes.
+// The map with scalar values (e.g. numbers and booleans) is decoded into map[string]string with stringified values,
+// and the map with scalar values or slices of them is decoded into map[string][]string and url.Values
+// without splitting string by `,`. They return error if any value is composite, e.g. nested map.
+// Any value is re-encoded as JSON with sorted keys into konf.Raw and json.RawMessage without interpreting it.
 func WithDecodeHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) Option {
 	return func(options *options) {
 		options.convertOpts = append(options.convertOpts, convert.WithHook[F, T](hook))
@@ -54,6 +73,17 @@ func WithLogHandler(handler slog.Handler) Option {
 	}
 }
 
+// WithAuditWriter provides the writer for the audit records in JSON lines, e.g. of Config.RevealSecret.
+//
+// By default, the audit records are logged with warning level by the handler provided by konf.WithLogHandler.
+func WithAuditWriter(writer io.Writer) Option {
+	return func(options *options) {
+		if writer != nil {
+			options.auditLogger = slog.New(slog.NewJSONHandler(writer, nil))
+		}
+	}
+}
+
 // WithOnStatus provides the callback for monitoring status of configuration loading/watching.
 func WithOnStatus(onStatus func(loader Loader, changed bool, err error)) Option {
 	return func(options *options) {
@@ -75,6 +105,419 @@ func WithMapKeyCaseSensitive() Option {
 	}
 }
 
+// WithStrictLifecycle enforces the lifecycle that all loaders are loaded before Config.Watch.
+//
+// With strict lifecycle, Config.Load returns LifecycleError after Config.Watch has been called,
+// and Config.OnChange logs a warning if it's called after configuration has been changed.
+// By default, loaders can be loaded at any time.
+func WithStrictLifecycle() Option {
+	return func(options *options) {
+		options.strictLifecycle = true
+	}
+}
+
+// WithCallerCapture enables capturing the location where Config.OnChange is called,
+// which is reported by Config.DebugState and Config.DumpState.
+//
+// It's disabled by default due to the cost of runtime.Caller.
+func WithCallerCapture() Option {
+	return func(options *options) {
+		options.captureCaller = true
+	}
+}
+
+// WithCollisionReport enables the report of paths whose values are provided by more than one loader,
+// which is produced when Config.Watch is called, usually after all loaders have been loaded.
+// The paths under the given prefixes are excluded, e.g. the intentional overriding.
+//
+// The report is sent to the given callback, or logged as warnings if the callback is nil.
+// Use Config.Collisions to get the report directly without watching.
+func WithCollisionReport(report func([]Collision), allowPrefixes ...string) Option {
+	return func(options *options) {
+		options.collisionReport = true
+		options.onCollisions = report
+		options.collisionAllowPrefixes = allowPrefixes
+	}
+}
+
+// WithOrderAudit enables the report of paths whose effective values depend on the load order of loaders
+// in the same precedence class (see OrderDependency), which is produced each time a loader is loaded
+// by Config.Load, with the paths which have not been reported before, so it does not need Config.Watch.
+// It's a diagnostic mode, e.g. for CI, since it merges the values under permuted orders.
+//
+// The report is sent to the given callback, or logged as warnings if the callback is nil.
+// Use Config.OrderDependencies to get the full report at any time.
+func WithOrderAudit(report func([]OrderDependency)) Option {
+	return func(options *options) {
+		options.orderAudit = true
+		options.onOrderDependencies = report
+	}
+}
+
+// WithRestartRequired provides the patterns of keys which require restart to take effect,
+// e.g. `server.port` or `tls.*` where `*` matches any single key.
+// A pattern also matches all keys under the matched path.
+//
+// The change touching those keys is still applied, but it logs a warning
+// and marks the ChangeEvent as restart required.
+// The keys are recorded as pending restart in Config.Status until Config.AckRestart is called.
+func WithRestartRequired(patterns ...string) Option {
+	return func(options *options) {
+		options.restartRequired = append(options.restartRequired, patterns...)
+	}
+}
+
+// WithShadowWarnings enables the logs at DEBUG level for each path
+// whose value from lower precedence loader is overridden by a different value
+// from higher precedence loader after merging. The values in logs are blurred if they are sensitive.
+//
+// It's disabled by default to avoid log noise.
+func WithShadowWarnings() Option {
+	return func(options *options) {
+		options.shadowWarnings = true
+	}
+}
+
+// WithQuietChanges suppresses the routine log "Configuration has been changed." at INFO level
+// for each change from watchers, which is noisy for high-churn configuration, e.g. feature flags.
+// The warnings and errors are still logged, and the callback provided by konf.WithOnStatus still fires.
+func WithQuietChanges() Option {
+	return func(options *options) {
+		options.quietChanges = true
+	}
+}
+
+// WithValueNormalizer provides the normalizer applied to each leaf value during merge,
+// including the initial load and changes from watchers. The path of the value is joined by the delimiter.
+// Multiple normalizers are applied in the order they are provided.
+//
+// Since the values are normalized before diffing, both change detection and Config.Unmarshal
+// see the normalized values, e.g. the change only on trailing spaces are ignored with NormalizeTrimSpace.
+// The normalizer must be pure and fast, as it's called for every leaf on each load and change.
+// It has built-in normalizers NormalizeTrimSpace and NormalizeBytes.
+func WithValueNormalizer(normalizer func(path string, value any) any) Option {
+	return func(options *options) {
+		if normalizer != nil {
+			options.normalizers = append(options.normalizers, normalizer)
+		}
+	}
+}
+
+// WithReplaceKeys provides the paths of maps which are replaced entirely by the map
+// from higher precedence loader instead of deep merging with values from lower precedence loaders,
+// e.g. konf.WithReplaceKeys("endpoints", "routes").
+// It's honored on both initial load and changes from watchers, and Config.Explain only shows the winning loader
+// for the paths under the replaced map.
+func WithReplaceKeys(paths ...string) Option {
+	return func(options *options) {
+		options.replaceKeys = append(options.replaceKeys, paths...)
+	}
+}
+
+// WithReplaceMarker provides the special key (e.g. "$replace") which marks the map it belongs to
+// is replaced entirely like konf.WithReplaceKeys, if the value of the key is true.
+// The marker key is removed from the configuration.
+//
+// It's disabled by default so that no key is treated specially.
+func WithReplaceMarker(marker string) Option {
+	return func(options *options) {
+		options.replaceMarker = marker
+	}
+}
+
+// WithTagValidation enables the validation of decoded structs against the `validate` tags,
+// e.g. `validate:"required,min=1,max=65535"`. The violations of all fields are joined
+// into the error returned by Config.Unmarshal, with the path of each field.
+//
+// The built-in rules are:
+//   - required: the value must not be zero.
+//   - omitempty: skip the other rules if the value is zero.
+//   - min=N and max=N: the inclusive bounds of number, or length of string, slice and map.
+//     The bounds of time.Duration are durations, e.g. min=1s.
+//   - oneof=a b c: the formatted value must be one of the space separated values.
+//   - url: the string must be an absolute URL.
+//   - ip: the string must be an IP address.
+//
+// The custom rules can be provided by konf.WithValidator.
+// If any struct is registered by Config.Describe, the change from watchers
+// is rejected if it violates the rules, and the last good values are kept.
+func WithTagValidation() Option {
+	return func(options *options) {
+		options.tagValidation = true
+	}
+}
+
+// WithStrictUnmarshal makes Config.Unmarshal return ErrUnknownKeys if the configuration has keys
+// which do not match any field of the target struct, e.g. typos in the configuration files.
+// It still accepts any keys for the fields of map[string]any or interface types without implementations
+// registered by konf.RegisterImpl.
+// See konf.Strict for a single call of Config.Unmarshal.
+func WithStrictUnmarshal() Option {
+	return func(options *options) {
+		options.strictUnmarshal = true
+	}
+}
+
+// WithAcceptEmpty makes Config.Validate accept the empty values, i.e. empty string, map or slice,
+// as present instead of missing.
+func WithAcceptEmpty() Option {
+	return func(options *options) {
+		options.acceptEmpty = true
+	}
+}
+
+// WithValidator provides the validator for the rule with the given name in the `validate` tags,
+// e.g. `validate:"port"` for name `port`. The validator receives the value of the field and the parameter
+// after `=` in the rule, and returns the error if it's invalid. It overrides the built-in rule with the same name.
+//
+// It only takes effect with konf.WithTagValidation.
+func WithValidator(name string, validator func(value any, param string) error) Option {
+	return func(options *options) {
+		if validator == nil {
+			return
+		}
+		if options.validators == nil {
+			options.validators = make(map[string]func(any, string) error)
+		}
+		options.validators[name] = validator
+	}
+}
+
+// WithMutuallyExclusive declares that at most one of the given paths can be set,
+// e.g. konf.WithMutuallyExclusive("cache.memory", "cache.redis"). The path is set if it has non-zero value,
+// where the string of zero bool or number, e.g. "false" or "0" from environment variables, is zero.
+// It can be provided multiple times for different groups.
+//
+// Config.Load returns error without applying the loader if it sets more than one path of any group,
+// and the change from watchers which sets more than one path is rejected, keeping the last good values.
+// The error names all paths of the group which are set.
+func WithMutuallyExclusive(paths ...string) Option {
+	return func(options *options) {
+		if len(paths) > 1 {
+			options.exclusives = append(options.exclusives, slices.Clone(paths))
+		}
+	}
+}
+
+// WithGroupPolicy provides the GroupPolicy(s) for dispatching the callbacks in the group with given name,
+// which are registered by Config.OnChangeWith with konf.Group.
+// Each group with policy has its own dispatcher, so changes are delivered to every group
+// even if the queue of any group is backed up, e.g.
+//
+//	konf.WithGroupPolicy("heavy", konf.Sequential(), konf.Queue(8))
+//
+// The default group keeps the semantics of Config.OnChange, so the empty name is ignored.
+func WithGroupPolicy(name string, policies ...GroupPolicy) Option {
+	return func(options *options) {
+		if name == "" {
+			return
+		}
+		policy := groupPolicy{queueSize: 1}
+		for _, p := range policies {
+			p(&policy)
+		}
+		if options.groupPolicies == nil {
+			options.groupPolicies = make(map[string]groupPolicy)
+		}
+		options.groupPolicies[name] = policy
+	}
+}
+
+// WithChangeQueue limits the number of changes waiting for dispatching to the callbacks in the default group,
+// which are accumulated when the callbacks are slower than the changes.
+// If the queue is full, the new change is coalesced into the latest pending change,
+// since the intermediate states are rarely useful. The callbacks of both changes are executed once
+// with the latest configuration, and ChangeEvent.Keys is the union of the changed keys.
+// ErrChangeCoalesced is reported to the callback provided by konf.WithOnStatus for each coalesced change,
+// and the numbers of pending and coalesced changes are reported by Config.DebugState.
+//
+// By default, the size is 16, and the size less than 1 is ignored.
+func WithChangeQueue(size int) Option {
+	return func(options *options) {
+		if size > 0 {
+			options.changeQueueSize = size
+		}
+	}
+}
+
+// WithMaxWatchers limits the number of loaders being watched, which are the loaded Watchers
+// and the Watchers unloaded by Config.Unload whose Watch has not returned yet.
+// Config.Load returns error wrapping konf.ErrTooManyWatchers if the limit is reached.
+//
+// By default, it's unlimited, and the limit less than 1 is ignored.
+func WithMaxWatchers(limit int) Option {
+	return func(options *options) {
+		options.maxWatchers = limit
+	}
+}
+
+// WithConflictResolver provides the resolver for the conflict that loaders provide values for the same leaf path,
+// which returns the value taking effect, e.g. the max of rate limits or the concatenation of allowlists.
+// The lower is the value merged from the loaders with lower precedence, and the higher is the value of the loader
+// with higher precedence. The lowerLoader is the highest loader among the loaders providing the lower value.
+//
+// The resolver is invoked whenever the configuration is merged, including loading, changes and reordering,
+// so it must be deterministic. The values resolved by the resolver are marked by Config.Explain.
+//
+// By default, the value of the loader with higher precedence takes effect.
+func WithConflictResolver(resolver func(path string, lower, higher any, lowerLoader, higherLoader string) any) Option {
+	return func(options *options) {
+		options.conflictResolver = resolver
+	}
+}
+
+// WithStartupSummary logs the summary of the configuration sources once Config.Watch is called,
+// the same as Config.LogSummary.
+func WithStartupSummary() Option {
+	return func(options *options) {
+		options.startupSummary = true
+	}
+}
+
+// WithKeyInfo tracks the time and version of the changes applied by Config.Watch for each key,
+// which are reported by Config.KeyInfo. The memory is only used for the keys changed since loaded.
+func WithKeyInfo() Option {
+	return func(options *options) {
+		options.keyInfo = true
+	}
+}
+
+// WithDefaults loads the given default values into the Config created by konf.New, the same as loading
+// konf.Defaults, so they have the lowest precedence and are overridden by all loaders loaded later.
+// The nested maps are merged with the loaded values key by key instead of being replaced,
+// and Config.Explain shows loader[defaults] for the values which fall back to defaults.
+// The values from the later WithDefaults take precedence over the earlier ones.
+// The values violating konf.WithMutuallyExclusive are ignored by konf.New with a warning log,
+// and reported as error by Builder.Build.
+func WithDefaults(values map[string]any) Option {
+	return func(options *options) {
+		options.defaults = append(options.defaults, values)
+	}
+}
+
+// WithAutoReload reloads the given loaders on the interval while Config.Watch is running,
+// the same as Config.Reload, e.g. for the loaders which do not implement Watcher.
+// If no loader is given, it reloads all loaders which do not implement Watcher, including the ones loaded later.
+//
+// The interval is extended with a random jitter up to 10%, and doubled after each consecutive failure
+// of the loader, up to 32 times of the interval. The failures are reported with AutoReloadError
+// to the callback provided by konf.WithOnStatus. It's ignored if the interval is not positive.
+func WithAutoReload(interval time.Duration, loaders ...Loader) Option {
+	return func(options *options) {
+		if interval > 0 {
+			options.autoReloads = append(options.autoReloads, autoReload{interval: interval, loaders: loaders})
+		}
+	}
+}
+
+// WithAtomicGroup applies the changes of the given keys together, e.g. the certificate and its private key.
+// Once a watcher changes any key of the group, the change is held, and the callbacks registered by Config.OnChange
+// are not executed, until all keys of the group have changed, possibly by different watchers.
+// Then the held changes are applied and dispatched as one ChangeEvent.
+// If not all keys have changed in the quiet period since the first held change,
+// the held changes are applied anyway with a warning.
+//
+// The watcher's whole change is held, including the keys not in the group,
+// and its further changes are held as well until the held changes are applied.
+// The reads of the Config keep returning the values before the held changes.
+// The held changes are validated together once they are applied, e.g. with konf.WithTagValidation,
+// so the validator can reject the mismatched values if not all keys have changed in the quiet period,
+// and then all held changes are rejected.
+//
+// It's ignored if the quiet period is not positive or no key is given.
+func WithAtomicGroup(quiet time.Duration, paths ...string) Option {
+	return func(options *options) {
+		if quiet <= 0 || len(paths) == 0 {
+			return
+		}
+		paths = slices.Clone(paths)
+		slices.Sort(paths)
+		options.atomicGroups = append(options.atomicGroups, &atomicGroup{paths: slices.Compact(paths), quiet: quiet})
+	}
+}
+
+// WithFlapDetection detects the keys whose values change more than the threshold times in the window,
+// e.g. the feature flag toggled repeatedly by two controllers fighting each other.
+// Once a key starts flapping, it logs a warning with the key and the loaders changed it in the window,
+// and reports the error wrapping ErrFlapping to the callback provided by konf.WithOnStatus.
+// The flapping keys are reported by Config.Status until they have not changed in the window.
+// Only the keys changed in the window are tracked, so the memory is bounded.
+//
+// It's ignored if the window is not positive or the threshold is less than 1.
+func WithFlapDetection(window time.Duration, threshold int, opts ...FlapOption) Option {
+	return func(options *options) {
+		if window <= 0 || threshold < 1 {
+			return
+		}
+		option := &flapOptions{window: window, threshold: threshold}
+		for _, opt := range opts {
+			opt(option)
+		}
+		options.flapDetection = option
+	}
+}
+
+// WithLifecycleHooks provides the Hooks executed at the lifecycle points of Config.Watch,
+// e.g. for the frameworks which embed konf to add behaviors without forking.
+func WithLifecycleHooks(hooks Hooks) Option {
+	return func(options *options) {
+		options.hooks = &hooks
+	}
+}
+
+// WithSnapshotStats counts the reads served by the merged snapshot, e.g. Config.Unmarshal,
+// which are reported as SnapshotStats.Hits. It's opt-in since counting is
+// an atomic operation contended by all concurrent reads.
+func WithSnapshotStats() Option {
+	return func(options *options) {
+		options.providers.countHits = true
+	}
+}
+
+// WithFinalSnapshot writes the FinalSnapshot encoded by the given Encoder, e.g. json.Marshal,
+// to the given path when Config.Watch returns, e.g. its context is canceled on shutdown.
+// The file is replaced atomically, and the failure of writing is logged.
+// It waits for writing at most 5 seconds, so that it never blocks shutdown.
+// It's ignored if the path is empty or the encoder is nil.
+func WithFinalSnapshot(path string, encoder Encoder) Option {
+	return func(options *options) {
+		if path == "" || encoder == nil {
+			return
+		}
+		options.finalSnapshot = &finalSnapshot{path: path, encode: encoder}
+	}
+}
+
+// WithComputed registers the computed key at the given path, whose value is computed by the given function,
+// e.g. `worker.count` defaulting to runtime.NumCPU() scaled by the configured factor.
+// The value is computed on demand, and cached until the value of any given dependency changes.
+// The callbacks registered by Config.OnChange for the path are executed once any dependency changes.
+//
+// The computed key is visible to Config.Unmarshal, Config.Exists and Config.Explain, which shows loader[computed].
+// It takes precedence over the values loaded by loaders for the same path unless konf.Overridable is set.
+// The computed keys with cyclic dependencies, empty path or nil function are ignored by konf.New
+// with a warning log, and reported as error by Builder.Build.
+func WithComputed(path string, deps []string, fn func(*Config) (any, error), opts ...ComputedOption) Option {
+	return func(options *options) {
+		computed := &computed{path: path, deps: deps, compute: fn}
+		for _, opt := range opts {
+			opt(computed)
+		}
+		options.computeds = append(options.computeds, computed)
+	}
+}
+
+// WithClock provides the Clock for time-based behaviors,
+// e.g. time of ChangeEvent and warning of slow onChange callbacks.
+// It's useful for tests to drive time deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
 type (
 	// Option configures a Config with specific options.
 	Option  func(*options)
@@ -83,5 +526,7 @@ type (
 
 		tagName     string
 		convertOpts []convert.Option
+		replaceKeys []string
+		defaults    []map[string]any
 	}
 )
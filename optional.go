@@ -0,0 +1,51 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+)
+
+type (
+	// LoadOption configures Config.Load with specific options.
+	LoadOption  func(*loadOptions)
+	loadOptions struct {
+		optional bool
+	}
+)
+
+// Optional marks the loader as optional in Config.Load, e.g. the remote loader which is not critical for startup.
+//
+// If the optional loader fails, the failure is logged and reported via the callback provided by konf.WithOnStatus,
+// Config.Load returns nil, and the loader is degraded in Config.Status until its values are loaded,
+// e.g. by Config.Reload or konf.WithAutoReload. The failure of watching it does not stop Config.Watch either.
+func Optional() LoadOption {
+	return func(options *loadOptions) {
+		options.optional = true
+	}
+}
+
+// degrade reports the failure of the optional loader. If the loader has not been added yet,
+// it's added with empty values so that it can be reloaded later.
+func (c *Config) degrade(provider *provider, err error) {
+	if !c.providers.contains(provider) {
+		if e := c.apply(provider, make(map[string]any)); e != nil {
+			c.log(context.Background(), slog.LevelWarn,
+				"Error when adding optional loader, it has been ignored.",
+				slog.Any("loader", provider.loader),
+				slog.Any("error", e),
+			)
+		}
+	}
+	provider.status(err)
+	c.log(context.Background(), slog.LevelWarn,
+		"Error when loading optional configuration, the loader is degraded.",
+		slog.Any("loader", provider.loader),
+		slog.Any("error", err),
+	)
+	if c.onStatus != nil {
+		c.onStatus(provider.loader, false, err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithClock(fake),
+		konf.WithAutoReload(time.Minute),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) {
+			if err != nil {
+				statuses <- err
+			}
+		}),
+	)
+	assert.NoError(t, config.Load(mapLoader{"config": "critical"}))
+	assert.EqualError(t, config.Load(errorLoader{}), "load configuration: load error")
+
+	loader := &reloadLoader{err: errors.New("load error")}
+	assert.NoError(t, config.Load(loader, konf.Optional()))
+	assert.EqualError(t, <-statuses, "load configuration: load error")
+	assert.Equal(t, "critical", configValue(t, config))
+	status := config.Status()
+	assert.Equal(t, 2, len(status.Loaders))
+	assert.True(t, !status.Loaders[0].Optional)
+	assert.True(t, !status.Loaders[0].Degraded)
+	assert.True(t, status.Loaders[1].Optional)
+	assert.True(t, status.Loaders[1].Degraded)
+	assert.EqualError(t, status.Loaders[1].LastError, "load configuration: load error")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	// The optional loader starts working via auto reload.
+	loader.set(map[string]any{"config": "optional"}, nil)
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute * 11 / 10)
+	fake.BlockUntil(1)
+	assert.Equal(t, "optional", configValue(t, config))
+	assert.True(t, !config.Status().Loaders[1].Degraded)
+}
+
+func TestOptional_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"config": "string"}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+	assert.NoError(t, config.Load(errorWatcher{}, konf.Optional()))
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) })
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The failure of watching optional loader does not stop watching others.
+	watcher.change <- map[string]any{"config": "changed"}
+	<-changed
+	assert.Equal(t, "changed", configValue(t, config))
+	status := config.Status()
+	assert.True(t, status.Loaders[1].Degraded)
+	assert.EqualError(t, status.Loaders[1].LastError, "watch configuration change on error: watch error")
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// OrderDependency is a path whose effective value depends on the load order of loaders
+// in the same precedence class, i.e. loaders of the same type, e.g. two files or two konf.Defaults.
+// The loaders of different types are layered deliberately, e.g. defaults, then file, then env,
+// so their overrides are not order dependencies. It never contains any configuration value.
+type OrderDependency struct {
+	Path string
+	// Loaders are the loaders in the same class which provide value for the path, in the load order.
+	Loaders []Loader
+}
+
+// OrderDependencies merges the values again under permuted load orders of loaders in the same precedence class,
+// and returns all paths whose effective values differ across the permutations, sorted by path.
+// Each permutation only reorders the loaders in the class, and keeps other loaders at their positions.
+//
+// All permutations are merged if the class has no more than 5 loaders. Otherwise, only the rotations
+// of the load order and its reverse are merged, so that each loader takes the highest precedence once.
+//
+// This method is concurrent-safe.
+func (c *Config) OrderDependencies() []OrderDependency {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.OrderDependencies()
+	}
+
+	return c.providers.orderDependencies(c.delim())
+}
+
+func (p *providers) orderDependencies(delim string) []OrderDependency {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var providers []*provider
+	for _, provider := range p.providers {
+		if !provider.disabled.Load() {
+			providers = append(providers, provider)
+		}
+	}
+
+	base := p.merge(providers, nil, nil)
+	dependencies := make(map[string]OrderDependency)
+	for _, class := range precedenceClasses(providers) {
+		members := make([]*provider, len(class))
+		for i, index := range class {
+			members[i] = providers[index]
+		}
+		for _, order := range permutations(members) {
+			permuted := slices.Clone(providers)
+			for i, index := range class {
+				permuted[index] = order[i]
+			}
+			for _, path := range maps.Diff(base, p.merge(permuted, nil, nil)) {
+				key := strings.Join(path, delim)
+				if _, ok := dependencies[key]; ok {
+					continue
+				}
+				dependency := OrderDependency{Path: key}
+				for _, provider := range members {
+					if maps.Sub(*provider.values.Load(), path) != nil {
+						dependency.Loaders = append(dependency.Loaders, provider.loader)
+					}
+				}
+				dependencies[key] = dependency
+			}
+		}
+	}
+
+	var sorted []OrderDependency
+	for _, dependency := range dependencies {
+		sorted = append(sorted, dependency)
+	}
+	slices.SortFunc(sorted, func(a, b OrderDependency) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return sorted
+}
+
+// precedenceClasses returns the indexes of the given providers grouped by the type of their loaders,
+// in the order of the first appearance of each type.
+func precedenceClasses(providers []*provider) [][]int {
+	var (
+		classes [][]int
+		types   []reflect.Type
+	)
+	for index, provider := range providers {
+		typ := reflect.TypeOf(provider.loader)
+		if i := slices.Index(types, typ); i >= 0 {
+			classes[i] = append(classes[i], index)
+		} else {
+			types = append(types, typ)
+			classes = append(classes, []int{index})
+		}
+	}
+
+	return classes
+}
+
+// maxPermutedLoaders is the max number of loaders in a class whose all permutations are merged.
+const maxPermutedLoaders = 5
+
+// permutations returns the permuted orders of the given providers, except the given order itself.
+func permutations(providers []*provider) [][]*provider {
+	if len(providers) <= 1 {
+		return nil
+	}
+
+	var orders [][]*provider
+	if len(providers) > maxPermutedLoaders {
+		reversed := slices.Clone(providers)
+		slices.Reverse(reversed)
+		for i := range len(providers) {
+			if i > 0 {
+				orders = append(orders, slices.Concat(providers[i:], providers[:i]))
+			}
+			orders = append(orders, slices.Concat(reversed[i:], reversed[:i]))
+		}
+
+		return orders
+	}
+
+	var permute func(prefix, rest []*provider)
+	permute = func(prefix, rest []*provider) {
+		if len(rest) == 0 {
+			if !slices.Equal(prefix, providers) {
+				orders = append(orders, prefix)
+			}
+
+			return
+		}
+		for i := range rest {
+			permute(
+				append(slices.Clip(prefix), rest[i]),
+				slices.Concat(rest[:i], rest[i+1:]),
+			)
+		}
+	}
+	permute(nil, providers)
+
+	return orders
+}
+
+// reportOrderDependencies reports the order dependencies which have not been reported
+// via the callback provided by konf.WithOrderAudit.
+func (c *Config) reportOrderDependencies(ctx context.Context) {
+	if !c.orderAudit {
+		return
+	}
+
+	dependencies := c.orderReports.unreported(c.OrderDependencies())
+	if len(dependencies) == 0 {
+		return
+	}
+	if c.onOrderDependencies != nil {
+		c.onOrderDependencies(dependencies)
+
+		return
+	}
+	for _, dependency := range dependencies {
+		c.log(ctx, slog.LevelWarn,
+			"Configuration depends on the load order of loaders.",
+			slog.String("path", dependency.Path),
+			slog.Any("loaders", dependency.Loaders),
+		)
+	}
+}
+
+type orderReports struct {
+	reported map[string]struct{}
+	mutex    sync.Mutex
+}
+
+// unreported returns the given dependencies whose paths have not been reported, and marks them as reported.
+func (o *orderReports) unreported(dependencies []OrderDependency) []OrderDependency {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.reported == nil {
+		o.reported = make(map[string]struct{})
+	}
+	var unreported []OrderDependency
+	for _, dependency := range dependencies {
+		if _, ok := o.reported[dependency.Path]; !ok {
+			o.reported[dependency.Path] = struct{}{}
+			unreported = append(unreported, dependency)
+		}
+	}
+
+	return unreported
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_OrderDependencies(t *testing.T) {
+	t.Parallel()
+
+	first := mapLoader{"server": map[string]any{"host": "first", "port": 8080}}
+	second := mapLoader{"server": map[string]any{"host": "second", "port": 8080}}
+	defaultsA, defaultsB := konf.Defaults(map[string]any{"a": 1, "b": 1}), konf.Defaults(map[string]any{"a": 2, "b": 2})
+	many := make([]konf.Loader, 7)
+	for i := range many {
+		many[i] = mapLoader{"x": 1, "i": 1}
+	}
+	many[3] = mapLoader{"x": 2, "i": 1}
+
+	testcases := []struct {
+		description string
+		loaders     []konf.Loader
+		expected    []konf.OrderDependency
+	}{
+		{
+			description: "different values",
+			loaders:     []konf.Loader{first, second},
+			expected:    []konf.OrderDependency{{Path: "server.host", Loaders: []konf.Loader{first, second}}},
+		},
+		{
+			description: "same values",
+			loaders:     []konf.Loader{first, first},
+		},
+		{
+			description: "defaults",
+			loaders:     []konf.Loader{defaultsA, mapLoader{"a": 3}, defaultsB},
+			expected:    []konf.OrderDependency{{Path: "b", Loaders: []konf.Loader{defaultsA, defaultsB}}},
+		},
+		{
+			description: "different types",
+			loaders:     []konf.Loader{first, mapWatcher{values: map[string]any{"server": map[string]any{"host": "env"}}}},
+		},
+		{
+			description: "interleaved types",
+			loaders: []konf.Loader{
+				first, mapWatcher{values: map[string]any{"server": map[string]any{"port": 9090}}}, second,
+			},
+			expected: []konf.OrderDependency{{Path: "server.host", Loaders: []konf.Loader{first, second}}},
+		},
+		{
+			description: "rotations for many loaders",
+			loaders:     many,
+			expected:    []konf.OrderDependency{{Path: "x", Loaders: many}},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New()
+			for _, loader := range testcase.loaders {
+				assert.NoError(t, config.Load(loader))
+			}
+			assert.Equal(t, testcase.expected, config.OrderDependencies())
+		})
+	}
+}
+
+func TestConfig_OrderDependencies_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, nil, config.OrderDependencies())
+}
+
+func TestConfig_Load_order_audit(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithOrderAudit(nil), konf.WithLogHandler(logHandler(buf)))
+	assert.NoError(t, config.Load(mapLoader{"config": "first"}))
+	assert.Equal(t, "", buf.String())
+	assert.NoError(t, config.Load(mapLoader{"config": "second"}))
+	assert.NoError(t, config.Load(mapLoader{"config": "third"}))
+
+	expected := `level=WARN msg="Configuration depends on the load order of loaders." path=config loaders="[map map]"` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestConfig_Load_order_audit_callback(t *testing.T) {
+	t.Parallel()
+
+	var reports [][]konf.OrderDependency
+	config := konf.New(konf.WithOrderAudit(func(dependencies []konf.OrderDependency) {
+		reports = append(reports, dependencies)
+	}))
+	first, second, third := mapLoader{"a": 1, "b": 1}, mapLoader{"a": 2}, mapLoader{"a": 3, "b": 3}
+	assert.NoError(t, config.Load(first))
+	assert.NoError(t, config.Load(second))
+	assert.NoError(t, config.Load(third))
+
+	assert.Equal(t, [][]konf.OrderDependency{
+		{{Path: "a", Loaders: []konf.Loader{first, second}}},
+		{{Path: "b", Loaders: []konf.Loader{first, third}}},
+	}, reports)
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Precedence returns the loaders of the Config in the order of precedence,
+// from the lowest to the highest. Each loader takes precedence over the loaders before it.
+//
+// This method is concurrent-safe.
+func (c *Config) Precedence() []Loader {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Precedence()
+	}
+
+	var loaders []Loader
+	c.providers.traverse(func(provider *provider) {
+		loaders = append(loaders, provider.loader)
+	})
+
+	return loaders
+}
+
+// Reorder changes the precedence of loaders to the given order, from the lowest to the highest.
+// The order must be a permutation of the loaders returned by Config.Precedence.
+//
+// After reordering, the configuration is merged again, and the callbacks registered by Config.OnChange
+// are executed for the paths whose value has been changed if Config.Watch has been called.
+//
+// This method is concurrent-safe.
+func (c *Config) Reorder(order []Loader) error {
+	if c == nil { // To support nil
+		if len(order) > 0 {
+			return fmt.Errorf("reorder with %d loaders while 0 loaders have been loaded", len(order)) //nolint:err113
+		}
+
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("reorder: %w", errSubView)
+	}
+
+	oldValues, newValues, err := c.providers.reorder(order)
+	if err != nil {
+		return err
+	}
+	c.warnShadows(context.Background())
+	if watch := c.watched.Load(); watch != nil {
+		if onChanges := c.changedOnChanges(oldValues, newValues); len(onChanges) > 0 {
+			watch.notify(nil, onChanges)
+		}
+	}
+
+	return nil
+}
+
+// Unload removes the given loader from the Config, which takes no precedence afterwards.
+// If the loader has been loaded more than once, the one with the highest precedence is removed.
+//
+// After unloading, the configuration is merged again, and the callbacks registered by Config.OnChange
+// are executed for the paths whose value has been changed if Config.Watch has been called.
+// If the loader is being watched, the context passed to its Watch is canceled,
+// and its changes are ignored afterwards. Config.Watch does not wait for it anymore,
+// even if it ignores the canceled context.
+//
+// This method is concurrent-safe.
+func (c *Config) Unload(loader Loader) error {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("unload: %w", errSubView)
+	}
+
+	provider, oldValues, newValues, err := c.providers.remove(loader)
+	if err != nil {
+		return err
+	}
+	provider.unloaded.Store(true)
+	if stop := provider.stop.Load(); stop != nil {
+		(*stop)()
+	}
+	if _, ok := loader.(Watcher); ok {
+		c.releaseWatcher(provider)
+	}
+	c.remerge(oldValues, newValues)
+
+	return nil
+}
+
+// Disable removes the layer of the given loader from the merged configuration temporarily,
+// and stops watching it if Config.Watch has been called, while the loader keeps its precedence.
+// If the loader has been loaded more than once, the one with the highest precedence is disabled.
+// It returns UnknownLoaderError if the loader has not been loaded.
+//
+// After disabling, the callbacks registered by Config.OnChange are executed for the paths
+// whose value has been changed if Config.Watch has been called.
+//
+// This method is concurrent-safe.
+func (c *Config) Disable(loader Loader) error {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("disable: %w", errSubView)
+	}
+
+	provider := c.providers.find(loader)
+	if provider == nil {
+		return UnknownLoaderError{Loader: loader}
+	}
+	if !provider.disabled.CompareAndSwap(false, true) {
+		return nil // The loader has been disabled.
+	}
+	// The watcher may still deliver the change after stopped, which is discarded
+	// even after Config.Enable starts the new watcher.
+	provider.generation.Add(1)
+	if stop := provider.stop.Load(); stop != nil {
+		(*stop)()
+	}
+	provider.watched.Store(false)
+	c.remerge(c.providers.resync())
+
+	return nil
+}
+
+// Enable restores the layer of the given loader disabled by Config.Disable,
+// with the values loaded from the loader again, and resumes watching it if Config.Watch has been called.
+// It returns UnknownLoaderError if the loader has not been loaded,
+// and the loader keeps disabled if it fails to load.
+//
+// After enabling, the callbacks registered by Config.OnChange are executed for the paths
+// whose value has been changed if Config.Watch has been called.
+//
+// This method is concurrent-safe.
+func (c *Config) Enable(loader Loader) error {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("enable: %w", errSubView)
+	}
+
+	provider := c.providers.find(loader)
+	if provider == nil {
+		return UnknownLoaderError{Loader: loader}
+	}
+	if !provider.disabled.Load() {
+		return nil // The loader has been enabled.
+	}
+
+	values, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("enable loader %v: load configuration: %w", loader, err)
+	}
+	c.transformKeys(values)
+	replaced := provider.replaced.Load()
+	c.extractReplaceMarkers(provider, values)
+	if _, _, e := c.validateAndStore(provider, values, nil); e != nil {
+		provider.replaced.Store(replaced)
+
+		return fmt.Errorf("enable loader %v: %w", loader, e)
+	}
+	if !provider.disabled.CompareAndSwap(true, false) {
+		return nil // The loader has been enabled concurrently.
+	}
+	c.remerge(c.providers.resync())
+	if watch := c.watched.Load(); watch != nil {
+		if _, ok := loader.(Watcher); ok {
+			watch.provider(provider)
+		}
+	}
+
+	return nil
+}
+
+// remerge reports the changes of merged values, e.g. shadow warnings and onChange callbacks.
+func (c *Config) remerge(oldValues, newValues map[string]any) {
+	c.warnShadows(context.Background())
+	if watch := c.watched.Load(); watch != nil {
+		if onChanges := c.changedOnChanges(oldValues, newValues); len(onChanges) > 0 {
+			watch.notify(nil, onChanges)
+		}
+	}
+}
+
+// UnknownLoaderError is returned by Config.Disable and Config.Enable if the loader has not been loaded.
+type UnknownLoaderError struct {
+	Loader Loader
+}
+
+func (e UnknownLoaderError) Error() string {
+	return fmt.Sprintf("loader %v has not been loaded", e.Loader)
+}
+
+// find returns the provider of the given loader with the highest precedence, or nil if not found.
+func (p *providers) find(loader Loader) *provider {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for i := len(p.providers) - 1; i >= 0; i-- {
+		if sameLoader(p.providers[i].loader, loader) {
+			return p.providers[i]
+		}
+	}
+
+	return nil
+}
+
+// contains reports whether the given provider has been added.
+func (p *providers) contains(provider *provider) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return slices.Contains(p.providers, provider)
+}
+
+func (p *providers) remove(loader Loader) (*provider, map[string]any, map[string]any, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	index := -1
+	for i := len(p.providers) - 1; i >= 0; i-- {
+		if sameLoader(p.providers[i].loader, loader) {
+			index = i
+
+			break
+		}
+	}
+	if index < 0 {
+		return nil, nil, nil, fmt.Errorf("unload loader %v: %w", loader, errUnknownLoader)
+	}
+	provider := p.providers[index]
+
+	var oldValues map[string]any
+	if values := p.values.Load(); values != nil {
+		oldValues = *values
+	}
+	p.providers = slices.Delete(slices.Clone(p.providers), index, index+1)
+	p.sync()
+
+	return provider, oldValues, *p.values.Load(), nil
+}
+
+func (p *providers) reorder(order []Loader) (map[string]any, map[string]any, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(order) != len(p.providers) {
+		return nil, nil, fmt.Errorf( //nolint:err113
+			"reorder with %d loaders while %d loaders have been loaded", len(order), len(p.providers),
+		)
+	}
+	providers := make([]*provider, 0, len(order))
+	used := make([]bool, len(p.providers))
+	for _, loader := range order {
+		index := -1
+		for i, provider := range p.providers {
+			if !used[i] && sameLoader(provider.loader, loader) {
+				index = i
+
+				break
+			}
+		}
+		if index < 0 {
+			return nil, nil, fmt.Errorf("reorder with loader %v: %w", loader, errUnknownLoader)
+		}
+		used[index] = true
+		providers = append(providers, p.providers[index])
+	}
+
+	var oldValues map[string]any
+	if values := p.values.Load(); values != nil {
+		oldValues = *values
+	}
+	p.providers = providers
+	p.sync()
+
+	return oldValues, *p.values.Load(), nil
+}
+
+// sameLoader reports whether the given loaders are the same one.
+// Loaders with uncomparable type (e.g. map) are compared by the underlying pointer,
+// and other uncomparable loaders (e.g. the struct with func fields like env.Env) by their string representation,
+// including the comparable struct holding uncomparable values in interface fields.
+func sameLoader(a, b Loader) bool {
+	typ := reflect.TypeOf(a)
+	if typ == nil || typ != reflect.TypeOf(b) {
+		return false
+	}
+
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+	if aValue.Comparable() && bValue.Comparable() {
+		return aValue.Equal(bValue)
+	}
+	switch aValue.Kind() { //nolint:exhaustive
+	case reflect.Map, reflect.Func:
+		return aValue.Pointer() == bValue.Pointer()
+	case reflect.Slice:
+		return aValue.Pointer() == bValue.Pointer() && aValue.Len() == bValue.Len()
+	default:
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}
+}
+
+var errUnknownLoader = errors.New("loader has not been loaded")
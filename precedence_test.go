@@ -0,0 +1,494 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/provider/env"
+	kflag "github.com/nil-go/konf/provider/flag"
+)
+
+func TestConfig_Precedence(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, nil, config.Precedence())
+
+	config = konf.New()
+	first, second := mapLoader{"config": "first"}, mapLoader{"config": "second"}
+	assert.NoError(t, config.Load(first))
+	assert.NoError(t, config.Load(second))
+	assert.Equal(t, []konf.Loader{first, second}, config.Precedence())
+}
+
+func TestConfig_Reorder(t *testing.T) {
+	t.Parallel()
+
+	first, second := mapLoader{"config": "first"}, mapLoader{"config": "second"}
+	testcases := []struct {
+		description string
+		order       []konf.Loader
+		expected    string
+		err         string
+	}{
+		{
+			description: "reorder",
+			order:       []konf.Loader{second, first},
+			expected:    "first",
+		},
+		{
+			description: "same order",
+			order:       []konf.Loader{first, second},
+			expected:    "second",
+		},
+		{
+			description: "missing loader",
+			order:       []konf.Loader{second},
+			expected:    "second",
+			err:         "reorder with 1 loaders while 2 loaders have been loaded",
+		},
+		{
+			description: "duplicated loader",
+			order:       []konf.Loader{second, second},
+			expected:    "second",
+			err:         "reorder with loader map: loader has not been loaded",
+		},
+		{
+			description: "nil loader",
+			order:       []konf.Loader{first, nil},
+			expected:    "second",
+			err:         "reorder with loader <nil>: loader has not been loaded",
+		},
+		{
+			description: "unknown loader",
+			order:       []konf.Loader{first, mapLoader{"config": "second"}},
+			expected:    "second",
+			err:         "reorder with loader map: loader has not been loaded",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New()
+			assert.NoError(t, config.Load(first))
+			assert.NoError(t, config.Load(second))
+
+			err := config.Reorder(testcase.order)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.order, config.Precedence())
+			}
+			var value string
+			assert.NoError(t, config.Unmarshal("config", &value))
+			assert.Equal(t, testcase.expected, value)
+		})
+	}
+}
+
+func TestConfig_uncomparableLoader(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		loader      func() konf.Loader
+	}{
+		{
+			description: "env",
+			loader:      func() konf.Loader { return env.New(env.WithPrefix("KONF_UNCOMPARABLE_")) },
+		},
+		{
+			description: "flag",
+			loader: func() konf.Loader {
+				set := flag.NewFlagSet("uncomparable", flag.ContinueOnError)
+				set.String("config", "flag", "")
+
+				return kflag.New(konf.New(), kflag.WithFlagSet(set))
+			},
+		},
+		{
+			description: "uncomparable value in interface field",
+			loader:      func() konf.Loader { return anyLoader{values: map[string]any{"config": "any"}} },
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			loader := testcase.loader()
+			config := konf.New(konf.WithAutoReload(time.Minute, loader))
+			assert.NoError(t, config.Load(mapLoader{"config": "map"}))
+			assert.NoError(t, config.Load(loader))
+
+			assert.NoError(t, config.Reorder(config.Precedence()))
+			assert.NoError(t, config.Reload(loader))
+			assert.NoError(t, config.Disable(loader))
+			assert.True(t, config.Status().Loaders[1].Disabled)
+			assert.NoError(t, config.Enable(loader))
+			assert.NoError(t, config.Unload(loader))
+			assert.Equal(t, 1, len(config.Precedence()))
+
+			_, err := konf.NewBuilder(konf.WithAutoReload(time.Minute, loader)).Loader(loader).Build()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// anyLoader is comparable, but panics if it's compared with == since it holds a map in the interface field.
+type anyLoader struct {
+	values any
+}
+
+func (a anyLoader) Load() (map[string]any, error) {
+	return a.values.(map[string]any), nil
+}
+
+func TestConfig_AutoReload_uncomparableLoader(t *testing.T) {
+	t.Parallel()
+
+	set := flag.NewFlagSet("uncomparable", flag.ContinueOnError)
+	set.String("config", "flag", "")
+	loader := kflag.New(konf.New(), kflag.WithFlagSet(set))
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	config := konf.New(konf.WithClock(fake), konf.WithAutoReload(time.Minute, loader))
+	assert.NoError(t, config.Load(loader))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	assert.NoError(t, set.Set("config", "reloaded"))
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute * 11 / 10)
+	fake.BlockUntil(1)
+	assert.Equal(t, "reloaded", configValue(t, config))
+}
+
+func TestConfig_Reorder_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.NoError(t, config.Reorder(nil))
+	assert.EqualError(t, config.Reorder([]konf.Loader{mapLoader{}}),
+		"reorder with 1 loaders while 0 loaders have been loaded",
+	)
+}
+
+func TestConfig_Reorder_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	first, second := mapLoader{"config": "first", "other": "first"}, mapLoader{"config": "second"}
+	assert.NoError(t, config.Load(first))
+	assert.NoError(t, config.Load(second))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	newValue := make(chan string)
+	config.OnChange(func(*konf.Config) {
+		t.Error("other should not be changed")
+	}, "other")
+	config.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("config", &value))
+		newValue <- value
+	}, "config")
+	assert.NoError(t, config.Reorder([]konf.Loader{second, first}))
+	assert.Equal(t, "first", <-newValue)
+}
+
+func TestConfig_Unload(t *testing.T) {
+	t.Parallel()
+
+	first, second := mapLoader{"config": "first"}, mapLoader{"config": "second"}
+	testcases := []struct {
+		description string
+		loader      konf.Loader
+		precedence  []konf.Loader
+		expected    string
+		err         string
+	}{
+		{
+			description: "highest",
+			loader:      second,
+			precedence:  []konf.Loader{first, first},
+			expected:    "first",
+		},
+		{
+			description: "duplicated loader",
+			loader:      first,
+			precedence:  []konf.Loader{first, second},
+			expected:    "second",
+		},
+		{
+			description: "unknown loader",
+			loader:      mapLoader{"config": "second"},
+			precedence:  []konf.Loader{first, second, first},
+			expected:    "first",
+			err:         "unload loader map: loader has not been loaded",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New()
+			assert.NoError(t, config.Load(first))
+			assert.NoError(t, config.Load(second))
+			assert.NoError(t, config.Load(first))
+
+			err := config.Unload(testcase.loader)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testcase.precedence, config.Precedence())
+			var value string
+			assert.NoError(t, config.Unmarshal("config", &value))
+			assert.Equal(t, testcase.expected, value)
+		})
+	}
+}
+
+func TestConfig_Unload_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithMaxWatchers(1))
+	assert.NoError(t, config.Load(mapLoader{"config": "map"}))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	newValue := make(chan string, 1)
+	config.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("config", &value))
+		newValue <- value
+	}, "config")
+
+	// The watcher which ignores the canceled context still holds the slot until it returns.
+	watcher := &stubbornWatcher{values: map[string]any{"config": "stubborn"}, release: make(chan struct{})}
+	assert.NoError(t, config.Load(watcher))
+	assert.Equal(t, "stubborn", <-newValue)
+	waitWatchers(t, config, 1)
+	assert.NoError(t, config.Unload(watcher))
+	assert.Equal(t, "map", <-newValue)
+	assert.Equal(t, 1, config.DebugState().Watchers)
+	err := config.Load(&mapWatcher{values: map[string]any{}})
+	assert.EqualError(t, err, "load configuration: watch map: too many loaders are being watched (limit 1)")
+	assert.True(t, errors.Is(err, konf.ErrTooManyWatchers))
+
+	// Config.Watch does not wait for the unloaded watcher.
+	cancel()
+	<-stopped
+
+	close(watcher.release)
+	waitWatchers(t, config, 0)
+	assert.NoError(t, config.Load(&mapWatcher{values: map[string]any{}}))
+}
+
+//nolint:paralleltest // It checks the number of goroutines of the whole process.
+func TestConfig_Unload_leak(t *testing.T) {
+	config := konf.New(konf.WithMaxWatchers(1))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	goroutines := runtime.NumGoroutine()
+	for range 100 {
+		watcher := &mapWatcher{values: map[string]any{"config": "watcher"}, change: make(chan map[string]any)}
+		assert.NoError(t, config.Load(watcher))
+		waitWatchers(t, config, 1)
+		assert.NoError(t, config.Unload(watcher))
+		waitWatchers(t, config, 0)
+	}
+	for i := 0; runtime.NumGoroutine() > goroutines && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond) // Wait for the goroutines to exit.
+	}
+	assert.True(t, runtime.NumGoroutine() <= goroutines)
+}
+
+func waitWatchers(t *testing.T, config *konf.Config, expected int) {
+	t.Helper()
+
+	for i := 0; config.DebugState().Watchers != expected && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, expected, config.DebugState().Watchers)
+}
+
+func TestConfig_Enable_staleWatcher(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := &keepingWatcher{values: map[string]any{"config": "watcher"}, onChanges: make(chan func(map[string]any), 2)}
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	stale := <-watcher.onChanges
+
+	newValue := make(chan string, 2)
+	config.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("config", &value))
+		newValue <- value
+	}, "config")
+	assert.NoError(t, config.Disable(watcher))
+	assert.Equal(t, "", <-newValue)
+	assert.NoError(t, config.Enable(watcher))
+	assert.Equal(t, "watcher", <-newValue)
+	current := <-watcher.onChanges
+
+	// The change from the watcher stopped by Disable is discarded after Enable.
+	stale(map[string]any{"config": "stale"})
+	current(map[string]any{"config": "current"})
+	// The changes are dispatched in order, so the stale one would have been received first.
+	assert.Equal(t, "current", <-newValue)
+	assert.Equal(t, 0, len(newValue))
+}
+
+// keepingWatcher sends the onChange of each Watch, which is kept and called even after Watch returns,
+// like the watcher ignoring the canceled context.
+type keepingWatcher struct {
+	values    map[string]any
+	onChanges chan func(map[string]any)
+}
+
+func (k *keepingWatcher) Load() (map[string]any, error) {
+	return k.values, nil
+}
+
+func (k *keepingWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	k.onChanges <- onChange
+	<-ctx.Done()
+
+	return nil
+}
+
+type stubbornWatcher struct {
+	values  map[string]any
+	release chan struct{}
+}
+
+func (s *stubbornWatcher) Load() (map[string]any, error) {
+	return s.values, nil
+}
+
+func (s *stubbornWatcher) Watch(context.Context, func(map[string]any)) error {
+	<-s.release // Ignore the context.
+
+	return nil
+}
+
+func (*stubbornWatcher) String() string {
+	return "stubborn"
+}
+
+func TestConfig_Disable(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	file := mapLoader{"config": "file"}
+	assert.NoError(t, config.Load(file))
+	watcher := &mapWatcher{values: map[string]any{"config": "watcher"}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	var unknown konf.UnknownLoaderError
+	assert.True(t, errors.As(config.Disable(mapLoader{}), &unknown))
+	assert.EqualError(t, config.Enable(mapLoader{}), "loader map has not been loaded")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	newValue := make(chan string)
+	config.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("config", &value))
+		newValue <- value
+	}, "config")
+
+	assert.NoError(t, config.Disable(watcher))
+	assert.Equal(t, "file", <-newValue)
+	assert.NoError(t, config.Disable(watcher))
+	state := config.Status().Loaders[1]
+	assert.True(t, state.Disabled)
+	assert.True(t, !state.Watched)
+	builder := &strings.Builder{}
+	assert.NoError(t, config.DumpState(builder))
+	assert.True(t, strings.Contains(builder.String(), "  - map [watcher=true, watched=false, disabled=true]"))
+
+	watcher.values = map[string]any{"config": "enabled"}
+	assert.NoError(t, config.Enable(watcher))
+	assert.Equal(t, "enabled", <-newValue)
+	assert.True(t, !config.Status().Loaders[1].Disabled)
+
+	// The watching is resumed.
+	watcher.change <- map[string]any{"config": "changed"}
+	assert.Equal(t, "changed", <-newValue)
+}
@@ -20,6 +20,12 @@ type Loader interface {
 // Watch watches the configuration and triggers the register callback with the latest
 // full configurations as a nested map[string]any when it changes.
 // It blocks until ctx is done, or the watching returns an error.
+//
+// The latest configuration fully replaces the previous one of the watcher, so the keys it no longer contains
+// are removed, including the nested keys under the removed subtree. The removed key falls back to the value
+// of the loader with lower precedence if any, or it no longer exists in Config.
+// Either way, the callbacks registered by Config.OnChange for the key are executed,
+// and ChangeEvent.Removed contains the keys that no longer exist.
 type Watcher interface {
 	Watch(ctx context.Context, onChange func(map[string]any)) error
 }
@@ -31,14 +37,29 @@ type Statuser interface {
 	Status(onStatus func(changed bool, err error))
 }
 
-// Exists tests if the given path exist in the configuration.
+// SecretLoader is the interface that wraps the Secrets method.
+//
+// Secrets reports whether all values of the loader are sensitive, e.g. credentials,
+// so that they are blurred in logs, Config.Explain and konf.Serve regardless of their keys and contents.
+type SecretLoader interface {
+	Secrets() bool
+}
+
+// Exists tests if the given path exist in the configuration,
+// even if its value is the zero value, e.g. `false` or empty string.
 //
 // It's used by the loader to check if the configuration has been set by other loaders.
+// The path is the keys split by the delimiter, so use Config.ExistsAny for the path string, e.g. `server.port`.
 func (c *Config) Exists(path []string) bool {
 	if c == nil { // To support nil
 		return false
 	}
 	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Exists(append(c.parent.splitPath(c.prefix), path...))
+	}
+
+	value, _ := c.sub(path)
 
-	return c.providers.sub(path) != nil
+	return value != nil
 }
@@ -38,6 +38,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+
+	"github.com/nil-go/konf/provider/appconfig/internal/maps"
 )
 
 // AppConfig is a Provider that loads configuration from AWS AppConfig.
@@ -45,7 +47,9 @@ import (
 // To create a new AppConfig, call [New].
 type AppConfig struct {
 	unmarshal    func([]byte, any) error
+	rootPath     string
 	pollInterval time.Duration
+	clock        Clock
 
 	onStatus  func(bool, error)
 	changedCh chan struct{}
@@ -55,20 +59,41 @@ type AppConfig struct {
 // New creates an AppConfig with the given application (ID or Name),
 // environment (ID or Name), profile (ID or Name) and Option(s).
 func New(application, environment, profile string, opts ...Option) *AppConfig {
-	option := &options{
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return newAppConfig(application, environment, profile, Options(*option))
+}
+
+// NewFromOptions creates an AppConfig with the given application, environment, profile and Options,
+// same as New with the corresponding Option(s).
+// It returns error if any of application, environment and profile is empty,
+// or the options are invalid, e.g. the poll interval is negative.
+func NewFromOptions(application, environment, profile string, options Options) (*AppConfig, error) {
+	if err := options.validate(application, environment, profile); err != nil {
+		return nil, err
+	}
+
+	return newAppConfig(application, environment, profile, options), nil
+}
+
+func newAppConfig(application, environment, profile string, options Options) *AppConfig {
+	return &AppConfig{
+		unmarshal:    options.Unmarshal,
+		rootPath:     options.RootPath,
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		changedCh:    make(chan struct{}, 1),
 		client: clientProxy{
+			config:      options.AWSConfig,
 			application: application,
 			environment: environment,
 			profile:     profile,
+			timeout:     options.PollInterval / 2, //nolint:mnd
 		},
-		changedCh: make(chan struct{}, 1),
 	}
-	for _, opt := range opts {
-		opt(option)
-	}
-	option.client.timeout = option.pollInterval / 2 //nolint:mnd
-
-	return (*AppConfig)(option)
 }
 
 var errNil = errors.New("nil AppConfig")
@@ -95,14 +120,14 @@ func (a *AppConfig) Watch(ctx context.Context, onChange func(map[string]any)) er
 	if pollInterval == 0 {
 		pollInterval = time.Minute
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := a.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-ticker.C:
+		case <-ticks:
 			a.changed()
 		case <-a.changedCh:
 			values, changed, err := a.load(ctx)
@@ -138,6 +163,10 @@ func (a *AppConfig) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	values, err = maps.Descend(values, a.rootPath)
+	if err != nil {
+		return nil, false, err
+	}
 
 	return values, true, nil
 }
@@ -228,6 +257,15 @@ func (a *AppConfig) String() string {
 	return "appconfig://" + a.client.application + "/" + a.client.profile
 }
 
+func (a *AppConfig) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if a.clock != nil {
+		return a.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	config        aws.Config
 	application   string
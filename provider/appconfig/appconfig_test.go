@@ -748,3 +748,17 @@ func TestAppConfig_String(t *testing.T) {
 	loader := kappconfig.New("app", "env", "profile")
 	assert.Equal(t, "appconfig://app/profile", loader.String())
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := kappconfig.NewFromOptions(
+		"app", "env", "profile", kappconfig.Options{PollInterval: time.Second, RootPath: "spec.config"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "appconfig://app/profile", loader.String())
+
+	_, err = kappconfig.NewFromOptions("app", "", "profile", kappconfig.Options{PollInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: empty application, environment or profile\n"+
+		"poll interval -1s is negative")
+}
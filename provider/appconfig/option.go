@@ -4,6 +4,9 @@
 package appconfig
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -14,7 +17,7 @@ import (
 // By default, it loads the default AWS Config.
 func WithAWSConfig(config aws.Config) Option {
 	return func(options *options) {
-		options.client.config = config
+		options.AWSConfig = config
 	}
 }
 
@@ -24,7 +27,23 @@ func WithAWSConfig(config aws.Config) Option {
 // The default interval is 1 minute.
 func WithPollInterval(interval time.Duration) Option {
 	return func(options *options) {
-		options.pollInterval = interval
+		options.PollInterval = interval
+	}
+}
+
+// Clock provides the ticker which drives polling of the AppConfig configuration.
+// konf.Clock satisfies it.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.Clock = clock
 	}
 }
 
@@ -34,12 +53,56 @@ func WithPollInterval(interval time.Duration) Option {
 // The default function is json.Unmarshal.
 func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	return func(options *options) {
-		options.unmarshal = unmarshal
+		options.Unmarshal = unmarshal
+	}
+}
+
+// WithRootPath provides the path, separated by `.`, of the map used as the configuration
+// after parsing, e.g. `spec.config` for the configuration nested in a Kubernetes custom resource.
+// It returns error if the path is not found or is not a map.
+//
+// By default, the whole document is the configuration.
+func WithRootPath(path string) Option {
+	return func(options *options) {
+		options.RootPath = path
 	}
 }
 
 type (
 	// Option configures the a AppConfig with specific options.
 	Option  func(options *options)
-	options AppConfig
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the AppConfig from generated configuration.
+	Options struct {
+		// AWSConfig is the same as WithAWSConfig.
+		AWSConfig aws.Config
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+		// Unmarshal is the same as WithUnmarshal.
+		Unmarshal func([]byte, any) error
+		// RootPath is the same as WithRootPath.
+		RootPath string
+	}
 )
+
+func (o Options) validate(application, environment, profile string) error {
+	var errs []error
+	if application == "" || environment == "" || profile == "" {
+		errs = append(errs, errors.New("empty application, environment or profile")) //nolint:err113
+	}
+	if o.RootPath != "" && strings.Contains("."+o.RootPath+".", "..") {
+		errs = append(errs, fmt.Errorf("root path %q has empty key", o.RootPath)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
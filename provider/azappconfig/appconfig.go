@@ -43,6 +43,7 @@ import (
 type AppConfig struct {
 	splitter     func(string) []string
 	pollInterval time.Duration
+	clock        Clock
 
 	onStatus  func(bool, error)
 	changedCh chan struct{}
@@ -52,19 +53,45 @@ type AppConfig struct {
 // New creates an AppConfig with the given endpoint and Option(s).
 func New(endpoint string, opts ...Option) *AppConfig {
 	option := &options{
-		client: clientProxy{
-			// Place holder for the default credential.
-			credential: &azidentity.DefaultAzureCredential{},
-			endpoint:   endpoint,
-		},
-		changedCh: make(chan struct{}, 1),
+		// Place holder for the default credential.
+		Credential: &azidentity.DefaultAzureCredential{},
 	}
 	for _, opt := range opts {
 		opt(option)
 	}
-	option.client.timeout = option.pollInterval / 2 //nolint:mnd
 
-	return (*AppConfig)(option)
+	return newAppConfig(endpoint, Options(*option))
+}
+
+// NewFromOptions creates an AppConfig with the given endpoint and Options,
+// same as New with the corresponding Option(s).
+// It returns error if the endpoint is not an absolute URL,
+// or the options are invalid, e.g. the poll interval is negative.
+func NewFromOptions(endpoint string, options Options) (*AppConfig, error) {
+	if err := options.validate(endpoint); err != nil {
+		return nil, err
+	}
+	if options.Credential == nil {
+		options.Credential = &azidentity.DefaultAzureCredential{}
+	}
+
+	return newAppConfig(endpoint, options), nil
+}
+
+func newAppConfig(endpoint string, options Options) *AppConfig {
+	return &AppConfig{
+		splitter:     options.KeySplitter,
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		changedCh:    make(chan struct{}, 1),
+		client: clientProxy{
+			endpoint:    endpoint,
+			keyFilter:   options.KeyFilter,
+			labelFilter: options.LabelFilter,
+			credential:  options.Credential,
+			timeout:     options.PollInterval / 2, //nolint:mnd
+		},
+	}
 }
 
 var errNil = errors.New("nil AppConfig")
@@ -91,12 +118,12 @@ func (a *AppConfig) Watch(ctx context.Context, onChange func(map[string]any)) er
 	if a.pollInterval > 0 {
 		pollInterval = a.pollInterval
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := a.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticks:
 			a.changed()
 		case <-a.changedCh:
 			values, changed, err := a.load(ctx)
@@ -169,6 +196,15 @@ func (a *AppConfig) String() string {
 	return a.client.endpoint
 }
 
+func (a *AppConfig) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if a.clock != nil {
+		return a.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	endpoint    string
 	keyFilter   string
@@ -327,3 +327,17 @@ func httpServer() *httptest.Server {
 
 	return httptest.NewServer(handler)
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := azappconfig.NewFromOptions(
+		"https://appconfig.azconfig.io", azappconfig.Options{KeyFilter: "app:*", PollInterval: time.Second},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://appconfig.azconfig.io", loader.String())
+
+	_, err = azappconfig.NewFromOptions("appconfig", azappconfig.Options{PollInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: endpoint \"appconfig\" is not an absolute URL\n"+
+		"poll interval -1s is negative")
+}
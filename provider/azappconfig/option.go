@@ -4,6 +4,9 @@
 package azappconfig
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -14,7 +17,7 @@ import (
 // [key filter]: https://learn.microsoft.com/en-us/azure/azure-app-configuration/rest-api-key-value#supported-filters
 func WithKeyFilter(filter string) Option {
 	return func(options *options) {
-		options.client.keyFilter = filter
+		options.KeyFilter = filter
 	}
 }
 
@@ -23,7 +26,7 @@ func WithKeyFilter(filter string) Option {
 // [label filter]: https://learn.microsoft.com/en-us/azure/azure-app-configuration/rest-api-key-value#supported-filters
 func WithLabelFilter(filter string) Option {
 	return func(options *options) {
-		options.client.labelFilter = filter
+		options.LabelFilter = filter
 	}
 }
 
@@ -32,7 +35,7 @@ func WithLabelFilter(filter string) Option {
 // By default, it uses azidentity.DefaultAzureCredential.
 func WithCredential(credential azcore.TokenCredential) Option {
 	return func(options *options) {
-		options.client.credential = credential
+		options.Credential = credential
 	}
 }
 
@@ -43,7 +46,7 @@ func WithCredential(credential azcore.TokenCredential) Option {
 // would be split into "parent", "child", and "key".
 func WithKeySplitter(splitter func(string) []string) Option {
 	return func(options *options) {
-		options.splitter = splitter
+		options.KeySplitter = splitter
 	}
 }
 
@@ -52,12 +55,60 @@ func WithKeySplitter(splitter func(string) []string) Option {
 // The default interval is 1 minute.
 func WithPollInterval(interval time.Duration) Option {
 	return func(options *options) {
-		options.pollInterval = interval
+		options.PollInterval = interval
+	}
+}
+
+// Clock provides the ticker which drives polling of the App Configuration store.
+// It has the same method set as konf.Clock.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.Clock = clock
 	}
 }
 
 type (
 	// Option configures the AppConfig with specific options.
 	Option  func(options *options)
-	options AppConfig
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the AppConfig from generated configuration.
+	Options struct {
+		// KeyFilter is the same as WithKeyFilter.
+		KeyFilter string
+		// LabelFilter is the same as WithLabelFilter.
+		LabelFilter string
+		// Credential is the same as WithCredential, and nil uses azidentity.DefaultAzureCredential.
+		Credential azcore.TokenCredential
+		// KeySplitter is the same as WithKeySplitter.
+		KeySplitter func(string) []string
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+	}
 )
+
+func (o Options) validate(endpoint string) error {
+	var errs []error
+	if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("endpoint %q is not an absolute URL", endpoint)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -33,6 +33,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+
+	"github.com/nil-go/konf/provider/azblob/internal/maps"
 )
 
 // Blob is a Provider that loads configuration from Azure Blob Storage.
@@ -40,7 +42,9 @@ import (
 // To create a new Blob, call [New].
 type Blob struct {
 	pollInterval time.Duration
+	clock        Clock
 	unmarshal    func([]byte, any) error
+	rootPath     string
 
 	onStatus  func(bool, error)
 	changedCh chan struct{}
@@ -50,21 +54,46 @@ type Blob struct {
 // New creates an Blob with the given endpoint and Option(s).
 func New(endpoint, container, blob string, opts ...Option) *Blob {
 	option := &options{
+		// Place holder for the default credential.
+		Credential: &azidentity.DefaultAzureCredential{},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return newBlob(endpoint, container, blob, Options(*option))
+}
+
+// NewFromOptions creates a Blob with the given endpoint, container, blob name and Options,
+// same as New with the corresponding Option(s).
+// It returns error if the endpoint is not an absolute URL, the container or blob is empty,
+// or the options are invalid, e.g. the poll interval is negative.
+func NewFromOptions(endpoint, container, blob string, options Options) (*Blob, error) {
+	if err := options.validate(endpoint, container, blob); err != nil {
+		return nil, err
+	}
+	if options.Credential == nil {
+		options.Credential = &azidentity.DefaultAzureCredential{}
+	}
+
+	return newBlob(endpoint, container, blob, options), nil
+}
+
+func newBlob(endpoint, container, blob string, options Options) *Blob {
+	return &Blob{
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		unmarshal:    options.Unmarshal,
+		rootPath:     options.RootPath,
+		changedCh:    make(chan struct{}, 1),
 		client: clientProxy{
-			// Place holder for the default credential.
-			credential: &azidentity.DefaultAzureCredential{},
 			endpoint:   endpoint,
 			container:  container,
 			blob:       blob,
+			credential: options.Credential,
+			timeout:    options.PollInterval / 2, //nolint:mnd
 		},
-		changedCh: make(chan struct{}, 1),
-	}
-	for _, opt := range opts {
-		opt(option)
 	}
-	option.client.timeout = option.pollInterval / 2 //nolint:mnd
-
-	return (*Blob)(option)
 }
 
 var errNil = errors.New("nil Blob")
@@ -91,12 +120,12 @@ func (b *Blob) Watch(ctx context.Context, onChange func(map[string]any)) error {
 	if b.pollInterval > 0 {
 		pollInterval = b.pollInterval
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := b.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticks:
 			b.changed()
 		case <-b.changedCh:
 			values, changed, err := b.load(ctx)
@@ -134,6 +163,10 @@ func (b *Blob) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	values, err = maps.Descend(values, b.rootPath)
+	if err != nil {
+		return nil, false, err
+	}
 
 	return values, true, nil
 }
@@ -175,6 +208,15 @@ func (b *Blob) String() string {
 	return b.client.url()
 }
 
+func (b *Blob) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if b.clock != nil {
+		return b.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	endpoint   string
 	container  string
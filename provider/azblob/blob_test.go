@@ -265,3 +265,18 @@ func TestBlob_String(t *testing.T) {
 	loader := azblob.New("https://azblob.io", "container", "blob")
 	assert.Equal(t, "https://azblob.io/container/blob", loader.String())
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := azblob.NewFromOptions(
+		"https://azblob.io", "container", "blob", azblob.Options{PollInterval: time.Second, RootPath: "spec.config"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://azblob.io/container/blob", loader.String())
+
+	_, err = azblob.NewFromOptions("azblob.io", "", "blob", azblob.Options{PollInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: endpoint \"azblob.io\" is not an absolute URL\n"+
+		"empty container or blob\n"+
+		"poll interval -1s is negative")
+}
@@ -4,6 +4,10 @@
 package azblob
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -14,7 +18,7 @@ import (
 // By default, it uses azidentity.DefaultAzureCredential.
 func WithCredential(credential azcore.TokenCredential) Option {
 	return func(options *options) {
-		options.client.credential = credential
+		options.Credential = credential
 	}
 }
 
@@ -23,7 +27,23 @@ func WithCredential(credential azcore.TokenCredential) Option {
 // The default interval is 1 minute.
 func WithPollInterval(interval time.Duration) Option {
 	return func(options *options) {
-		options.pollInterval = interval
+		options.PollInterval = interval
+	}
+}
+
+// Clock provides the ticker which drives polling of the blob.
+// The same value passed to konf.WithClock can be reused.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.Clock = clock
 	}
 }
 
@@ -33,12 +53,59 @@ func WithPollInterval(interval time.Duration) Option {
 // The default function is json.Unmarshal.
 func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	return func(options *options) {
-		options.unmarshal = unmarshal
+		options.Unmarshal = unmarshal
+	}
+}
+
+// WithRootPath provides the path, separated by `.`, of the map used as the configuration
+// after parsing, e.g. `spec.config` for the configuration nested in a Kubernetes custom resource.
+// It returns error if the path is not found or is not a map.
+//
+// By default, the whole document is the configuration.
+func WithRootPath(path string) Option {
+	return func(options *options) {
+		options.RootPath = path
 	}
 }
 
 type (
 	// Option configures the Blob with specific options.
 	Option  func(options *options)
-	options Blob
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the Blob from generated configuration.
+	Options struct {
+		// Credential is the same as WithCredential, and nil uses azidentity.DefaultAzureCredential.
+		Credential azcore.TokenCredential
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+		// Unmarshal is the same as WithUnmarshal.
+		Unmarshal func([]byte, any) error
+		// RootPath is the same as WithRootPath.
+		RootPath string
+	}
 )
+
+func (o Options) validate(endpoint, container, blob string) error {
+	var errs []error
+	if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("endpoint %q is not an absolute URL", endpoint)) //nolint:err113
+	}
+	if container == "" || blob == "" {
+		errs = append(errs, errors.New("empty container or blob")) //nolint:err113
+	}
+	if o.RootPath != "" && strings.Contains("."+o.RootPath+".", "..") {
+		errs = append(errs, fmt.Errorf("root path %q has empty key", o.RootPath)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
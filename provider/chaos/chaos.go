@@ -0,0 +1,162 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package chaos wraps a loader to inject delays and failures for testing.
+//
+// Chaos delegates to the inner loader, and injects the faults configured by Option(s):
+// latency before each load and change delivery, failures of load and watch,
+// and dropped or duplicated change notifications.
+// The faults are driven by a seedable random number generator, so a soak test is reproducible
+// with the same seed.
+//
+// It's designed for proving the retry/backoff, last-good retention and staleness alerts
+// of the consumers, and should not be used in production.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+// ErrInjected is the error injected by Chaos.
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Chaos is a Provider that wraps the inner loader and injects delays and failures.
+//
+// To create a new Chaos, call [New].
+type Chaos struct {
+	inner          konf.Loader
+	seed           *uint64
+	minLatency     time.Duration
+	maxLatency     time.Duration
+	loadErrorRate  float64
+	watchErrorRate float64
+	dropRate       float64
+	duplicateRate  float64
+	clock          konf.Clock
+	onStatus       func(bool, error)
+	rand           *rand.Rand
+	mutex          sync.Mutex
+}
+
+// New creates a Chaos with the given inner loader and Option(s).
+// Without any Option, it behaves the same as the inner loader.
+func New(inner konf.Loader, opts ...Option) *Chaos {
+	option := &options{inner: inner}
+	for _, opt := range opts {
+		opt(option)
+	}
+	seed := rand.Uint64() //nolint:gosec
+	if option.seed != nil {
+		seed = *option.seed
+	}
+	option.rand = rand.New(rand.NewPCG(seed, seed)) //nolint:gosec
+	if option.clock == nil {
+		option.clock = clock.Real{}
+	}
+
+	return (*Chaos)(option)
+}
+
+var errNil = errors.New("nil Chaos")
+
+func (c *Chaos) Load() (map[string]any, error) {
+	if c == nil {
+		return nil, errNil
+	}
+
+	_ = c.delay(context.Background())
+	if c.hit(c.loadErrorRate) {
+		return nil, fmt.Errorf("load %v: %w", c.inner, ErrInjected)
+	}
+
+	return c.inner.Load() //nolint:wrapcheck
+}
+
+// Watch delegates to the inner loader if it's a konf.Watcher, and injects faults to each change:
+// the change is delayed by the latency, fails with the watch error rate and reports ErrInjected via Status,
+// or is dropped or delivered twice with the drop and duplicate rates.
+// It returns immediately if the inner loader is not a konf.Watcher.
+func (c *Chaos) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if c == nil {
+		return errNil
+	}
+
+	watcher, ok := c.inner.(konf.Watcher)
+	if !ok {
+		return nil
+	}
+
+	return watcher.Watch(ctx, func(values map[string]any) { //nolint:wrapcheck
+		if c.delay(ctx) != nil {
+			return
+		}
+		switch {
+		case c.hit(c.watchErrorRate):
+			if c.onStatus != nil {
+				c.onStatus(false, fmt.Errorf("watch %v: %w", c.inner, ErrInjected))
+			}
+		case c.hit(c.dropRate):
+		case c.hit(c.duplicateRate):
+			onChange(values)
+			onChange(values)
+		default:
+			onChange(values)
+		}
+	})
+}
+
+// Status passes the status of the inner loader if it's a konf.Statuser,
+// and also reports ErrInjected by the watch error rate.
+func (c *Chaos) Status(onStatus func(bool, error)) {
+	c.onStatus = onStatus
+	if statuser, ok := c.inner.(konf.Statuser); ok {
+		statuser.Status(onStatus)
+	}
+}
+
+func (c *Chaos) String() string {
+	return fmt.Sprint(c.inner) + " (chaos)"
+}
+
+// hit reports whether the fault with the given rate happens.
+func (c *Chaos) hit(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.rand.Float64() < rate
+}
+
+// delay waits for the random latency in the range of latency, or until ctx is done.
+func (c *Chaos) delay(ctx context.Context) error {
+	if c.maxLatency <= 0 {
+		return nil
+	}
+
+	latency := c.minLatency
+	if c.maxLatency > c.minLatency {
+		c.mutex.Lock()
+		latency += time.Duration(c.rand.Int64N(int64(c.maxLatency - c.minLatency)))
+		c.mutex.Unlock()
+	}
+	timer, stop := c.clock.NewTimer(latency)
+	defer stop()
+
+	select {
+	case <-timer:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
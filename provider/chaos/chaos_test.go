@@ -0,0 +1,180 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package chaos_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/provider/chaos"
+)
+
+func TestChaos_Load(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []chaos.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "pass through",
+			expected:    map[string]any{"k": "v"},
+		},
+		{
+			description: "error",
+			opts:        []chaos.Option{chaos.WithLoadErrorRate(1)},
+			err:         "load map: chaos: injected failure",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := chaos.New(mapWatcher{values: map[string]any{"k": "v"}}, testcase.opts...).Load()
+			if testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testcase.err)
+				assert.True(t, errors.Is(err, chaos.ErrInjected))
+			}
+			assert.Equal(t, testcase.expected, values)
+		})
+	}
+}
+
+func TestChaos_seed(t *testing.T) {
+	t.Parallel()
+
+	outcomes := func(seed uint64) []bool {
+		loader := chaos.New(mapWatcher{}, chaos.WithSeed(seed), chaos.WithLoadErrorRate(0.5))
+		results := make([]bool, 0, 32)
+		for range 32 {
+			_, err := loader.Load()
+			results = append(results, err == nil)
+		}
+
+		return results
+	}
+	assert.Equal(t, outcomes(42), outcomes(42))
+	assert.True(t, !slices.Equal(outcomes(42), outcomes(43)))
+}
+
+func TestChaos_latency(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Time{})
+	loader := chaos.New(mapWatcher{values: map[string]any{"k": "v"}},
+		chaos.WithLatency(time.Second, time.Second), chaos.WithClock(fake))
+
+	loaded := make(chan map[string]any)
+	go func() {
+		values, err := loader.Load()
+		assert.NoError(t, err)
+		loaded <- values
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	assert.Equal(t, map[string]any{"k": "v"}, <-loaded)
+}
+
+func TestChaos_Watch(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []chaos.Option
+		deliveries  int
+		err         string
+	}{
+		{
+			description: "pass through",
+			deliveries:  2,
+		},
+		{
+			description: "duplicate",
+			opts:        []chaos.Option{chaos.WithDuplicateRate(1)},
+			deliveries:  4,
+		},
+		{
+			description: "drop",
+			opts:        []chaos.Option{chaos.WithDropRate(1)},
+		},
+		{
+			description: "error",
+			opts:        []chaos.Option{chaos.WithWatchErrorRate(1)},
+			err:         "watch map: chaos: injected failure",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			watcher := mapWatcher{change: make(chan map[string]any)}
+			loader := chaos.New(watcher, testcase.opts...)
+			var errs []error
+			loader.Status(func(_ bool, err error) {
+				errs = append(errs, err)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			var deliveries int
+			go func() {
+				defer close(stopped)
+				assert.NoError(t, loader.Watch(ctx, func(map[string]any) { deliveries++ }))
+			}()
+			watcher.change <- map[string]any{"k": "v1"}
+			watcher.change <- map[string]any{"k": "v2"}
+			cancel()
+			<-stopped
+
+			assert.Equal(t, testcase.deliveries, deliveries)
+			if testcase.err == "" {
+				assert.Equal(t, 0, len(errs))
+			} else {
+				assert.Equal(t, 2, len(errs))
+				assert.EqualError(t, errs[0], testcase.err)
+			}
+		})
+	}
+}
+
+func TestChaos_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "map (chaos)", chaos.New(mapWatcher{}).String())
+}
+
+type mapWatcher struct {
+	values map[string]any
+	change chan map[string]any
+}
+
+func (m mapWatcher) Load() (map[string]any, error) {
+	return m.values, nil
+}
+
+func (m mapWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	for {
+		select {
+		case values := <-m.change:
+			onChange(values)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m mapWatcher) String() string {
+	return "map"
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package chaos
+
+import (
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+// WithSeed provides the seed of the random number generator, so that the faults are reproducible.
+//
+// By default, it uses a random seed.
+func WithSeed(seed uint64) Option {
+	return func(options *options) {
+		options.seed = &seed
+	}
+}
+
+// WithLatency provides the range of latency injected before each load and change delivery.
+// The latency is picked uniformly in [minimum, maximum), or it's minimum if maximum is not greater than minimum.
+//
+// By default, there is no latency.
+func WithLatency(minimum, maximum time.Duration) Option {
+	return func(options *options) {
+		options.minLatency = minimum
+		options.maxLatency = max(minimum, maximum)
+	}
+}
+
+// WithLoadErrorRate provides the rate in [0, 1] of Load returning ErrInjected.
+func WithLoadErrorRate(rate float64) Option {
+	return func(options *options) {
+		options.loadErrorRate = rate
+	}
+}
+
+// WithWatchErrorRate provides the rate in [0, 1] of the change failing with ErrInjected,
+// which is reported via Status instead of delivering the change.
+func WithWatchErrorRate(rate float64) Option {
+	return func(options *options) {
+		options.watchErrorRate = rate
+	}
+}
+
+// WithDropRate provides the rate in [0, 1] of the change notification being dropped silently.
+func WithDropRate(rate float64) Option {
+	return func(options *options) {
+		options.dropRate = rate
+	}
+}
+
+// WithDuplicateRate provides the rate in [0, 1] of the change notification being delivered twice.
+func WithDuplicateRate(rate float64) Option {
+	return func(options *options) {
+		options.duplicateRate = rate
+	}
+}
+
+// WithClock provides the Clock for latency.
+// It's useful for tests to drive latency deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock konf.Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+type (
+	// Option configures the Chaos with specific options.
+	Option  func(options *options)
+	options Chaos
+)
@@ -33,33 +33,58 @@ func New(opts ...Option) Env {
 		opt(option)
 	}
 
-	return Env(*option)
+	return newEnv(Options(*option))
 }
 
-func (e Env) Load() (map[string]any, error) {
-	splitter := e.splitter
-	if splitter == nil {
-		splitter = func(s string) []string { return strings.Split(s, "_") }
+// NewFromOptions creates an Env with the given Options, same as New with the corresponding Option(s).
+// It returns error if the options are invalid, e.g. the prefix never matches any environment variable.
+func NewFromOptions(options Options) (Env, error) {
+	if err := options.validate(); err != nil {
+		return Env{}, err
 	}
 
+	return newEnv(options), nil
+}
+
+func newEnv(options Options) Env {
+	return Env{prefix: options.Prefix, splitter: options.NameSplitter}
+}
+
+func (e Env) Load() (map[string]any, error) {
 	values := make(map[string]any)
 	for _, env := range os.Environ() {
-		if e.prefix == "" || strings.HasPrefix(env, e.prefix) {
-			key, value, _ := strings.Cut(env, "=")
-			if value == "" {
-				// The environment variable with empty value is treated as unset.
-				continue
-			}
-
-			if keys := splitter(key); len(keys) > 1 || len(keys) == 1 && keys[0] != "" {
-				maps.Insert(values, keys, value)
-			}
+		key, value, _ := strings.Cut(env, "=")
+		if value == "" {
+			// The environment variable with empty value is treated as unset.
+			continue
+		}
+
+		if keys := e.Keys(key); keys != nil {
+			maps.Insert(values, keys, value)
 		}
 	}
 
 	return values, nil
 }
 
+// Keys returns the nested keys which the environment variable with the given name is loaded as,
+// or nil if the variable is not loaded, e.g. the name does not start with the prefix.
+func (e Env) Keys(name string) []string {
+	if e.prefix != "" && !strings.HasPrefix(name, e.prefix) {
+		return nil
+	}
+
+	splitter := e.splitter
+	if splitter == nil {
+		splitter = func(s string) []string { return strings.Split(s, "_") }
+	}
+	if keys := splitter(name); len(keys) > 1 || len(keys) == 1 && keys[0] != "" {
+		return keys
+	}
+
+	return nil
+}
+
 func (e Env) String() string {
 	return "env:" + e.prefix + "*"
 }
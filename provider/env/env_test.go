@@ -110,3 +110,47 @@ func TestEnv_String(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Setenv("P_K", "v")
+
+	loader, err := env.NewFromOptions(env.Options{
+		Prefix:       "P_",
+		NameSplitter: func(s string) []string { return strings.Split(s, "_") },
+	})
+	assert.NoError(t, err)
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"P": map[string]any{"K": "v"}}, values)
+	assert.Equal(t, "env:P_*", loader.String())
+
+	_, err = env.NewFromOptions(env.Options{Prefix: "P=K"})
+	assert.EqualError(t, err, `invalid options: prefix "P=K" never matches any environment variable`)
+}
+
+func FuzzNewFromOptions(f *testing.F) {
+	f.Add("P_", "_")
+	f.Add("", "")
+	f.Add("P=", ".")
+
+	f.Fuzz(func(t *testing.T, prefix, delimiter string) {
+		options := env.Options{Prefix: prefix}
+		if delimiter != "" {
+			options.NameSplitter = func(s string) []string { return strings.Split(s, delimiter) }
+		}
+
+		if loader, err := env.NewFromOptions(options); err == nil {
+			_, err = loader.Load()
+			assert.NoError(t, err)
+		}
+	})
+}
+
+func TestEnv_Keys(t *testing.T) {
+	t.Parallel()
+
+	loader := env.New(env.WithPrefix("APP_"))
+	assert.Equal(t, []string{"APP", "DB", "URL"}, loader.Keys("APP_DB_URL"))
+	assert.Equal(t, nil, loader.Keys("DB_URL"))
+	assert.Equal(t, nil, env.New(env.WithNameSplitter(func(string) []string { return nil })).Keys("DB_URL"))
+}
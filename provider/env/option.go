@@ -3,6 +3,11 @@
 
 package env
 
+import (
+	"fmt"
+	"strings"
+)
+
 // WithPrefix provides the prefix used when loading environment variables.
 // Only environment variables with names that start with the prefix will be loaded.
 //
@@ -10,7 +15,7 @@ package env
 // By default, it has no prefix which loads all environment variables.
 func WithPrefix(prefix string) Option {
 	return func(options *options) {
-		options.prefix = prefix
+		options.Prefix = prefix
 	}
 }
 
@@ -21,12 +26,30 @@ func WithPrefix(prefix string) Option {
 // would be split into "PARENT", "CHILD", and "KEY".
 func WithNameSplitter(splitter func(string) []string) Option {
 	return func(options *options) {
-		options.splitter = splitter
+		options.NameSplitter = splitter
 	}
 }
 
 type (
 	// Option configures an Env with specific options.
 	Option  func(*options)
-	options Env
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the Env from generated configuration.
+	Options struct {
+		// Prefix is the same as WithPrefix.
+		Prefix string
+		// NameSplitter is the same as WithNameSplitter.
+		NameSplitter func(string) []string
+	}
 )
+
+func (o Options) validate() error {
+	if strings.Contains(o.Prefix, "=") {
+		// The name of environment variable never contains '='.
+		return fmt.Errorf("invalid options: prefix %q never matches any environment variable", o.Prefix) //nolint:err113
+	}
+
+	return nil
+}
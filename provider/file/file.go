@@ -8,62 +8,173 @@
 //
 // The unmarshal function must be able to unmarshal the file content into a map[string]any.
 // For example, with the default json.Unmarshal, the file is parsed as JSON.
+// The file with extension of the well-known formats which are never JSON, i.e. `.yaml`, `.yml` and `.toml`,
+// fails to load unless its unmarshal function is provided, instead of being parsed as JSON,
+// so that the parsers of YAML and TOML are opted in, e.g. file.WithExtensionUnmarshal(".yaml", yaml.Unmarshal).
+//
+// The file with extension `.gz` is decompressed with gzip before unmarshal, and the unmarshal function
+// is picked by the extension before `.gz`, e.g. `.yaml` for `config.yaml.gz`.
+// WithGzip decompresses the file with gzip regardless of its extension.
+//
+// Glob loads all files matching the given glob pattern, picks the unmarshal function
+// by the extension of each file, and deep-merges them in lexical order of the paths.
 package file
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nil-go/konf/provider/file/internal/maps"
 )
 
 // File is a Provider that loads configuration from a OS file.
 //
 // To create a new File, call [New].
 type File struct {
-	path      string
-	unmarshal func([]byte, any) error
+	path       string
+	unmarshal  func([]byte, any) error
+	unmarshals map[string]func([]byte, any) error
+	rootPath   string
+	debounce   time.Duration
+	poll       time.Duration
+	clock      Clock
+	ignore     bool // Ignore the file does not exist.
+	gzip       bool // Decompress the file with gzip regardless of its extension.
+	force      bool // Reload the file on every event even if its content is identical.
 
 	onStatus func(bool, error)
+	// The digest of the content last loaded by File.Load. It's a pointer since Glob copies the File for each path.
+	loaded *atomic.Pointer[digest]
 }
 
 // New creates a File with the given path and Option(s).
 func New(path string, opts ...Option) *File {
-	option := &options{
-		path: path,
-	}
+	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
 
-	return (*File)(option)
+	return newFile(path, Options(*option))
+}
+
+// NewFromOptions creates a File with the given path and Options, same as New with the corresponding Option(s).
+// It returns error if the path is empty, or the options are invalid or contradictory,
+// e.g. both Unmarshal and ExtensionUnmarshals are provided.
+func NewFromOptions(path string, options Options) (*File, error) {
+	if path == "" {
+		return nil, errors.New("invalid options: empty path") //nolint:err113
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	return newFile(path, options), nil
+}
+
+func newFile(path string, options Options) *File {
+	file := &File{
+		path:      path,
+		unmarshal: options.Unmarshal,
+		rootPath:  options.RootPath,
+		debounce:  options.Debounce,
+		poll:      options.PollInterval,
+		clock:     options.Clock,
+		ignore:    options.IgnoreNotExist,
+		gzip:      options.Gzip,
+		force:     options.ForceReload,
+		loaded:    &atomic.Pointer[digest]{},
+	}
+	if len(options.ExtensionUnmarshals) > 0 {
+		file.unmarshals = make(map[string]func([]byte, any) error, len(options.ExtensionUnmarshals))
+		for extension, unmarshal := range options.ExtensionUnmarshals {
+			file.unmarshals[strings.ToLower(extension)] = unmarshal
+		}
+	}
+
+	return file
 }
 
 var errNil = errors.New("nil File")
 
+// Load loads the file, and reports the status via Status,
+// i.e. true with nil error if it succeeds, or false with the error if it fails to read or unmarshal.
 func (f *File) Load() (map[string]any, error) {
 	if f == nil {
 		return nil, errNil
 	}
 
-	bytes, err := os.ReadFile(f.path)
+	content, readErr := os.ReadFile(f.path)
+	values, err := f.decode(content, readErr)
+	if err == nil && f.loaded != nil {
+		f.loaded.Store(&digest{sum: sha256.Sum256(content), valid: readErr == nil})
+	}
+	if f.onStatus != nil {
+		f.onStatus(err == nil, err)
+	}
+
+	return values, err
+}
+
+// decode decodes the content read from the file, or returns the error of reading it.
+func (f *File) decode(content []byte, err error) (map[string]any, error) {
 	if err != nil {
+		if f.ignore && errors.Is(err, os.ErrNotExist) {
+			return map[string]any{}, nil
+		}
+
 		return nil, fmt.Errorf("read file: %w", err)
 	}
-
-	unmarshal := f.unmarshal
-	if unmarshal == nil {
-		unmarshal = json.Unmarshal
+	if f.gzip || isGzip(f.path) {
+		if content, err = gunzip(content); err != nil {
+			return nil, fmt.Errorf("gunzip: %w", err)
+		}
 	}
+
 	var out map[string]any
-	if err := unmarshal(bytes, &out); err != nil {
+	if err := f.unmarshalFunc(f.path)(content, &out); err != nil {
 		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
 
-	return out, nil
+	return maps.Descend(out, f.rootPath)
+}
+
+func (f *File) unmarshalFunc(path string) func([]byte, any) error {
+	if f.unmarshal != nil {
+		return f.unmarshal
+	}
+	if isGzip(path) {
+		path = path[:len(path)-len(gzipExtension)]
+	}
+	extension := strings.ToLower(filepath.Ext(path))
+	if unmarshal, ok := f.unmarshals[extension]; ok && unmarshal != nil {
+		return unmarshal
+	}
+	if slices.Contains(nonJSONExtensions, extension) {
+		return func([]byte, any) error {
+			return fmt.Errorf("%w for extension %s, provide it via WithExtensionUnmarshal", errNoUnmarshal, extension)
+		}
+	}
+
+	return json.Unmarshal
 }
 
+// nonJSONExtensions are the extensions of the well-known formats which are never JSON,
+// so that the file is not parsed as JSON with the confusing syntax error if no unmarshal function is provided.
+var nonJSONExtensions = []string{".yaml", ".yml", ".toml"}
+
+var errNoUnmarshal = errors.New("no unmarshal function")
+
 func (f *File) String() string {
 	path, err := filepath.Abs(f.path)
 	if err != nil {
@@ -72,3 +183,39 @@ func (f *File) String() string {
 
 	return "file://" + path
 }
+
+// SourceID returns the canonical URL of the file with symlinks of the parent directory resolved,
+// and the root path provided by WithRootPath as fragment if any, so that the Config watches the file once
+// for all Files of it. The Files with the same path are assumed to be parsed with the same unmarshal function.
+func (f *File) SourceID() string {
+	path, err := filepath.Abs(f.path)
+	if err != nil {
+		return ""
+	}
+	if dir, e := filepath.EvalSymlinks(filepath.Dir(path)); e == nil {
+		path = filepath.Join(dir, filepath.Base(path))
+	}
+	if f.rootPath != "" {
+		path += "#" + f.rootPath
+	}
+
+	return "file://" + path
+}
+
+const gzipExtension = ".gz"
+
+func isGzip(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), gzipExtension)
+}
+
+func gunzip(content []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return io.ReadAll(reader) //nolint:wrapcheck
+}
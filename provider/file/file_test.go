@@ -5,9 +5,12 @@ package file_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/nil-go/konf/provider/file"
 	"github.com/nil-go/konf/provider/file/internal/assert"
@@ -43,11 +46,95 @@ func TestFile_Load(t *testing.T) {
 				"k": "v",
 			},
 		},
+		{
+			description: "gzip file",
+			path:        "testdata/config.json.gz",
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "gzip file with unmarshal by extension",
+			path:        "testdata/config.json.gz",
+			opts: []file.Option{
+				file.WithExtensionUnmarshal(".json", func(_ []byte, v any) error {
+					*v.(*map[string]any) = map[string]any{"k": "json"}
+
+					return nil
+				}),
+			},
+			expected: map[string]any{
+				"k": "json",
+			},
+		},
+		{
+			description: "gzip file (truncated)",
+			path:        "testdata/truncated.json.gz",
+			err:         "gunzip: unexpected EOF",
+		},
+		{
+			description: "with gzip (not compressed)",
+			path:        "testdata/config.json",
+			opts:        []file.Option{file.WithGzip()},
+			err:         "gunzip: gzip: invalid header",
+		},
 		{
 			description: "file (not exist)",
 			path:        "not_found.json",
 			err:         "read file: open not_found.json: no such file or directory",
 		},
+		{
+			description: "file (not exist) with ignore",
+			path:        "not_found.json",
+			opts:        []file.Option{file.WithIgnoreNotExist()},
+			expected:    map[string]any{},
+		},
+		{
+			description: "directory with ignore",
+			path:        "testdata",
+			opts:        []file.Option{file.WithIgnoreNotExist()},
+			err:         "read file: read testdata: is a directory",
+		},
+		{
+			description: "unmarshal error with ignore",
+			path:        "testdata/config.json",
+			opts: []file.Option{
+				file.WithIgnoreNotExist(),
+				file.WithUnmarshal(func([]byte, any) error {
+					return errors.New("unmarshal error")
+				}),
+			},
+			err: "unmarshal: unmarshal error",
+		},
+		{
+			description: "yaml without unmarshal",
+			path:        "testdata/config.yaml",
+			err:         "unmarshal: no unmarshal function for extension .yaml, provide it via WithExtensionUnmarshal",
+		},
+		{
+			description: "yaml with extension unmarshal",
+			path:        "testdata/config.YML",
+			opts: []file.Option{
+				file.WithExtensionUnmarshal(".yml", func(_ []byte, v any) error {
+					*v.(*map[string]any) = map[string]any{"k": "yaml"}
+
+					return nil
+				}),
+			},
+			expected: map[string]any{"k": "yaml"},
+		},
+		{
+			description: "toml with unmarshal",
+			path:        "testdata/config.toml",
+			opts: []file.Option{
+				file.WithUnmarshal(func(_ []byte, v any) error {
+					*v.(*map[string]any) = map[string]any{"k": "toml"}
+
+					return nil
+				}),
+			},
+			expected: map[string]any{"k": "toml"},
+		},
 		{
 			description: "unmarshal error",
 			path:        "testdata/config.json",
@@ -58,6 +145,27 @@ func TestFile_Load(t *testing.T) {
 			},
 			err: "unmarshal: unmarshal error",
 		},
+		{
+			description: "root path",
+			path:        "testdata/config.json",
+			opts: []file.Option{
+				file.WithUnmarshal(func(_ []byte, v any) error {
+					*v.(*map[string]any) = map[string]any{"spec": map[string]any{"config": map[string]any{"k": "v"}}}
+
+					return nil
+				}),
+				file.WithRootPath("spec.config"),
+			},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "root path (not map)",
+			path:        "testdata/config.json",
+			opts:        []file.Option{file.WithRootPath("k")},
+			err:         `root path "k": string is not a map`,
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -75,6 +183,42 @@ func TestFile_Load(t *testing.T) {
 	}
 }
 
+func TestFile_Status(t *testing.T) {
+	t.Parallel()
+
+	var statuses []error
+	loader := file.New("testdata/config.json")
+	loader.Status(func(changed bool, err error) {
+		assert.Equal(t, err == nil, changed)
+		statuses = append(statuses, err)
+	})
+	_, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, []error{nil}, statuses)
+
+	loader = file.New("not_found.json")
+	loader.Status(func(changed bool, err error) {
+		assert.Equal(t, err == nil, changed)
+		statuses = append(statuses, err)
+	})
+	_, err = loader.Load()
+	assert.EqualError(t, err, "read file: open not_found.json: no such file or directory")
+	assert.Equal(t, 2, len(statuses))
+	assert.EqualError(t, statuses[1], "read file: open not_found.json: no such file or directory")
+}
+
+func TestGlob_Status(t *testing.T) {
+	t.Parallel()
+
+	var statuses []error
+	loader := file.NewGlob("testdata/config.*")
+	loader.Status(func(_ bool, err error) { statuses = append(statuses, err) })
+	_, err := loader.Load()
+	assert.EqualError(t, err, "load testdata/config.YML: unmarshal: "+
+		"no unmarshal function for extension .yml, provide it via WithExtensionUnmarshal")
+	assert.Equal(t, []error{err}, statuses) // Reported once for all files.
+}
+
 func TestFile_String(t *testing.T) {
 	t.Parallel()
 
@@ -82,3 +226,128 @@ func TestFile_String(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "file://"+path, file.New("config.json").String())
 }
+
+func TestFile_SourceID(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"k": "v"}`), 0o600))
+	link := filepath.Join(t.TempDir(), "link")
+	assert.NoError(t, os.Symlink(dir, link))
+
+	realDir, err := filepath.EvalSymlinks(dir)
+	assert.NoError(t, err)
+	expected := "file://" + filepath.Join(realDir, "config.json")
+	assert.Equal(t, expected, file.New(path).SourceID())
+	assert.Equal(t, expected, file.New(filepath.Join(link, "config.json"), file.WithDebounce(time.Second)).SourceID())
+	assert.Equal(t, expected+"#spec", file.New(path, file.WithRootPath("spec")).SourceID())
+}
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	unmarshal := func([]byte, any) error { return errors.New("unmarshal error") }
+	testcases := []struct {
+		description string
+		path        string
+		options     file.Options
+		err         string
+	}{
+		{
+			description: "empty options",
+			path:        "testdata/config.json",
+		},
+		{
+			description: "empty path",
+			err:         "invalid options: empty path",
+		},
+		{
+			description: "unmarshal and extension unmarshals",
+			path:        "testdata/config.json",
+			options: file.Options{
+				Unmarshal:           unmarshal,
+				ExtensionUnmarshals: map[string]func([]byte, any) error{".json": unmarshal},
+			},
+			err: "invalid options: extension unmarshals are never used since Unmarshal is provided",
+		},
+		{
+			description: "invalid extension unmarshals",
+			path:        "testdata/config.json",
+			options: file.Options{
+				ExtensionUnmarshals: map[string]func([]byte, any) error{".JSON": unmarshal, ".json": unmarshal, "yaml": nil},
+			},
+			err: "invalid options: extension \".json\" conflicts with \".JSON\" since extensions are case-insensitive\n" +
+				"extension \"yaml\" does not start with '.'\n" +
+				"unmarshal for extension \"yaml\" is nil",
+		},
+		{
+			description: "invalid root path",
+			path:        "testdata/config.json",
+			options:     file.Options{RootPath: "spec..config"},
+			err:         `invalid options: root path "spec..config" has empty key`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			loader, err := file.NewFromOptions(testcase.path, testcase.options)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			assert.NoError(t, err)
+			values, err := loader.Load()
+			assert.NoError(t, err)
+			expected, err := file.New(testcase.path).Load()
+			assert.NoError(t, err)
+			assert.Equal(t, expected, values)
+		})
+	}
+}
+
+func TestNewGlobFromOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := file.NewGlobFromOptions("[", file.Options{})
+	assert.EqualError(t, err, `invalid pattern "[": syntax error in pattern`)
+	_, err = file.NewGlobFromOptions("testdata/*.json", file.Options{RootPath: "."})
+	assert.EqualError(t, err, `invalid options: root path "." has empty key`)
+
+	loader, err := file.NewGlobFromOptions("testdata/*.json", file.Options{})
+	assert.NoError(t, err)
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+
+	loader, err = file.NewGlobFromOptions("testdata/*.ini", file.Options{RequireMatch: true})
+	assert.NoError(t, err)
+	_, err = loader.Load()
+	assert.EqualError(t, err, "glob testdata/*.ini: no file matches")
+}
+
+func FuzzNewFromOptions(f *testing.F) {
+	f.Add("testdata/config.json", false, ".json", true, "k")
+	f.Add("", true, "json", false, "..")
+	f.Add("testdata/*.json", true, ".JSON", true, "a.b")
+
+	f.Fuzz(func(t *testing.T, path string, withUnmarshal bool, extension string, withExtension bool, rootPath string) {
+		options := file.Options{RootPath: rootPath}
+		if withUnmarshal {
+			options.Unmarshal = json.Unmarshal
+		}
+		if withExtension {
+			options.ExtensionUnmarshals = map[string]func([]byte, any) error{extension: json.Unmarshal}
+		}
+
+		if loader, err := file.NewFromOptions(path, options); err == nil {
+			_, _ = loader.Load()
+		}
+		if loader, err := file.NewGlobFromOptions(path, options); err == nil {
+			_, _ = loader.Load()
+		}
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/nil-go/konf/provider/file/internal/maps"
+)
+
+// Glob is a Provider that loads configuration from all OS files matching a glob pattern.
+//
+// Each matched file is parsed with the unmarshal function picked by its extension
+// (see WithExtensionUnmarshal), so that fragments of different formats, e.g. `base.yaml`,
+// `overrides.json`, can live in the same directory.
+//
+// If no file matches the pattern, it loads empty configuration unless WithRequireMatch is provided.
+// The files are loaded in lexical order of their paths, and deep-merged into a single
+// configuration. Key conflicts are resolved by preferring the file loaded later,
+// or recursively descending, if both values are map. The merged configuration takes
+// a single precedence slot in the Config, same as any other loader.
+//
+// To create a new Glob, call [NewGlob].
+type Glob struct {
+	pattern string
+	file    File
+	require bool // Require at least one file matches the pattern.
+}
+
+// NewGlob creates a Glob with the given pattern and Option(s).
+// The pattern syntax is the same as in [filepath.Match].
+func NewGlob(pattern string, opts ...Option) *Glob {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return &Glob{pattern: pattern, file: *newFile("", Options(*option)), require: option.RequireMatch}
+}
+
+// NewGlobFromOptions creates a Glob with the given pattern and Options,
+// same as NewGlob with the corresponding Option(s).
+// It returns error if the pattern is malformed, or the options are invalid or contradictory.
+func NewGlobFromOptions(pattern string, options Options) (*Glob, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Glob{pattern: pattern, file: *newFile("", options), require: options.RequireMatch}, nil
+}
+
+var errNilGlob = errors.New("nil Glob")
+
+// Load loads all files matching the pattern, and reports the status via Status
+// once for all files instead of each file.
+func (g *Glob) Load() (map[string]any, error) {
+	if g == nil {
+		return nil, errNilGlob
+	}
+
+	current, err := g.read()
+	var values map[string]any
+	if err == nil {
+		values, err = current.decode()
+	}
+	if err == nil && g.file.loaded != nil {
+		g.file.loaded.Store(&current.digest)
+	}
+	if g.file.onStatus != nil {
+		g.file.onStatus(err == nil, err)
+	}
+
+	return values, err
+}
+
+// read reads all files matching the pattern in lexical order of their paths,
+// or returns the error if the pattern is malformed, or no file matches while WithRequireMatch is provided.
+func (g *Glob) read() (snapshot, error) {
+	paths, err := filepath.Glob(g.pattern)
+	if err != nil {
+		return snapshot{}, fmt.Errorf("glob %s: %w", g.pattern, err)
+	}
+	if len(paths) == 0 && g.require {
+		return snapshot{}, fmt.Errorf("glob %s: no file matches", g.pattern) //nolint:err113
+	}
+	slices.Sort(paths)
+
+	contents := make([][]byte, len(paths))
+	errs := make([]error, len(paths))
+	hash := sha256.New()
+	valid := true
+	for i, path := range paths {
+		contents[i], errs[i] = os.ReadFile(path)
+		valid = valid && errs[i] == nil
+		// The lengths are hashed as well, so that the paths and contents never run into each other.
+		_, _ = fmt.Fprintf(hash, "%d:%s%d:", len(path), path, len(contents[i]))
+		_, _ = hash.Write(contents[i])
+	}
+
+	return snapshot{
+		digest: digest{sum: [sha256.Size]byte(hash.Sum(nil)), valid: valid},
+		decode: func() (map[string]any, error) {
+			values := make(map[string]any)
+			for i, path := range paths {
+				file := g.file
+				file.path = path
+				value, err := file.decode(contents[i], errs[i])
+				if err != nil {
+					return nil, fmt.Errorf("load %s: %w", path, err)
+				}
+				maps.Merge(values, value)
+			}
+
+			return values, nil
+		},
+	}, nil
+}
+
+// stat returns the paths, modification times and sizes of all files matching the pattern.
+func (g *Glob) stat() (string, error) {
+	paths, err := filepath.Glob(g.pattern)
+	if err != nil {
+		return "", fmt.Errorf("glob %s: %w", g.pattern, err)
+	}
+	slices.Sort(paths)
+
+	var builder strings.Builder
+	for _, path := range paths {
+		// The file removed after globbing is left out, same as it does not match.
+		if info, err := os.Stat(path); err == nil {
+			builder.WriteString(path + ":" + fingerprint(info) + "\n")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (g *Glob) Status(onStatus func(bool, error)) {
+	g.file.onStatus = onStatus
+}
+
+// Watch watches the directory of the pattern, and reloads all matched files
+// after any file matching the pattern is created, written or removed,
+// so that the files added after Glob.Load are picked up.
+// The directory part of the pattern must not contain any pattern syntax.
+//
+// It shares the behaviors of File.Watch, e.g. WithDebounce, WithPollInterval and WithForceReload,
+// regarding all matched files as a whole. If no file matches while WithRequireMatch is provided,
+// onChange is called with nil, and it's reported via Status as error.
+func (g *Glob) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if g == nil {
+		return errNilGlob
+	}
+
+	if _, err := filepath.Match(g.pattern, ""); err != nil {
+		return fmt.Errorf("watch %s: %w", g.pattern, err)
+	}
+	dir := filepath.Dir(g.pattern)
+	if strings.ContainsAny(dir, "*?[") {
+		return fmt.Errorf("watch %s: pattern syntax in directory is not supported", g.pattern) //nolint:err113
+	}
+
+	target := watchTarget{dir: dir, read: g.read, stat: g.stat}
+	if g.file.poll > 0 {
+		return g.file.watchPoll(ctx, target, onChange)
+	}
+	pattern := filepath.Clean(g.pattern)
+	target.match = func(path string) bool {
+		matched, _ := filepath.Match(pattern, path)
+
+		return matched
+	}
+
+	return g.file.watchEvents(ctx, target, onChange)
+}
+
+func (g *Glob) String() string {
+	pattern, err := filepath.Abs(g.pattern)
+	if err != nil {
+		pattern = "/" + g.pattern
+	}
+
+	return "file://" + pattern
+}
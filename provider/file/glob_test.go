@@ -0,0 +1,214 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package file_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf/provider/file"
+	"github.com/nil-go/konf/provider/file/internal/assert"
+	"github.com/nil-go/konf/provider/file/internal/clock"
+)
+
+func TestGlob_empty(t *testing.T) {
+	var loader *file.Glob
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil Glob")
+	assert.Equal(t, nil, values)
+	err = loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "nil Glob")
+}
+
+func TestGlob_Watch_bad_pattern(t *testing.T) {
+	t.Parallel()
+
+	err := file.NewGlob("[").Watch(context.Background(), nil)
+	assert.EqualError(t, err, "watch [: syntax error in pattern")
+}
+
+func TestGlob_Load(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		files       map[string]string
+		pattern     string
+		opts        []file.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "no match",
+			pattern:     "*.json",
+			expected:    map[string]any{},
+		},
+		{
+			description: "no match with require",
+			pattern:     "*.json",
+			opts:        []file.Option{file.WithRequireMatch()},
+			err:         "glob {dir}/*.json: no file matches",
+		},
+		{
+			description: "match with require",
+			files:       map[string]string{"a.json": `{"k": "a"}`},
+			pattern:     "*.json",
+			opts:        []file.Option{file.WithRequireMatch()},
+			expected:    map[string]any{"k": "a"},
+		},
+		{
+			description: "merge in lexical order",
+			files: map[string]string{
+				"b.json": `{"p": {"k": "b", "b": "b"}}`,
+				"a.json": `{"p": {"k": "a", "a": "a"}}`,
+			},
+			pattern:  "*.json",
+			expected: map[string]any{"p": map[string]any{"k": "b", "a": "a", "b": "b"}},
+		},
+		{
+			description: "unmarshal by extension",
+			files: map[string]string{
+				"base.json":   `{"p": {"k": "json"}}`,
+				"secrets.env": "p.secret=env",
+			},
+			pattern: "*",
+			opts: []file.Option{
+				file.WithExtensionUnmarshal(".ENV", envUnmarshal),
+			},
+			expected: map[string]any{"p": map[string]any{"k": "json"}, "p.secret": "env"},
+		},
+		{
+			description: "unmarshal overrides extension",
+			files: map[string]string{
+				"base.json": `{"p": {"k": "json"}}`,
+			},
+			pattern: "*",
+			opts: []file.Option{
+				file.WithExtensionUnmarshal(".json", envUnmarshal),
+				file.WithUnmarshal(func([]byte, any) error {
+					return errors.New("unmarshal error")
+				}),
+			},
+			err: "load {dir}/base.json: unmarshal: unmarshal error",
+		},
+		{
+			description: "bad pattern",
+			pattern:     "[",
+			err:         "glob {dir}/[: syntax error in pattern",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for name, content := range testcase.files {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+			}
+
+			values, err := file.NewGlob(filepath.Join(dir, testcase.pattern), testcase.opts...).Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, strings.ReplaceAll(testcase.err, "{dir}", dir))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+			}
+		})
+	}
+}
+
+func TestGlob_Watch(t *testing.T) {
+	testcases := []struct {
+		description string
+		opts        []file.Option
+	}{
+		{description: "event"},
+		{description: "poll", opts: []file.Option{file.WithPollInterval(10 * time.Millisecond)}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			dir := t.TempDir()
+			write := func(name, content string) {
+				// Write via rename so that the poll never reads the partial content.
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".tmp"), []byte(content), 0o600))
+				assert.NoError(t, os.Rename(filepath.Join(dir, name+".tmp"), filepath.Join(dir, name)))
+			}
+			write("a.json", `{"p": {"k": "a"}}`)
+
+			fake := clock.NewFake(time.Time{})
+			loader := file.NewGlob(
+				filepath.Join(dir, "*.json"),
+				append(testcase.opts, file.WithRequireMatch(), file.WithClock(fake))...,
+			)
+			_, err := loader.Load()
+			assert.NoError(t, err)
+			statuses := make(chan error, 10)
+			loader.Status(func(_ bool, err error) { statuses <- err })
+			values := make(chan map[string]any, 10)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+			}()
+			fake.BlockUntil(1) // Wait for the watch to be established.
+
+			// The files added after Load are picked up, with a flurry of writes collapsed into one change.
+			write("b.json", `{"p": {"k": "b"}}`)
+			write("c.json", `{"p": {"k": "c"}}`)
+			assert.NoError(t, settle(fake, statuses))
+			assert.Equal(t, map[string]any{"p": map[string]any{"k": "c"}}, <-values)
+
+			// The other files are ignored.
+			write("other.yaml", `p: {k: other}`)
+			assert.NoError(t, os.Remove(filepath.Join(dir, "c.json")))
+			assert.NoError(t, settle(fake, statuses))
+			assert.Equal(t, map[string]any{"p": map[string]any{"k": "b"}}, <-values)
+
+			// The identical content is skipped.
+			write("b.json", `{"p": {"k": "b"}}`)
+			assert.NoError(t, settle(fake, statuses))
+
+			// No file matches while WithRequireMatch is provided.
+			assert.NoError(t, os.Remove(filepath.Join(dir, "a.json")))
+			assert.NoError(t, os.Remove(filepath.Join(dir, "b.json")))
+			assert.EqualError(t, settle(fake, statuses), "glob "+filepath.Join(dir, "*.json")+": no file matches")
+			assert.Equal(t, map[string]any(nil), <-values)
+			assert.Equal(t, 0, len(values))
+		})
+	}
+}
+
+func TestGlob_Watch_dir_pattern(t *testing.T) {
+	t.Parallel()
+
+	err := file.NewGlob("*/config.json").Watch(context.Background(), nil)
+	assert.EqualError(t, err, "watch */config.json: pattern syntax in directory is not supported")
+}
+
+func TestGlob_String(t *testing.T) {
+	t.Parallel()
+
+	path, err := filepath.Abs("*.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "file://"+path, file.NewGlob("*.json").String())
+}
+
+func envUnmarshal(bytes []byte, v any) error {
+	values := make(map[string]any)
+	for _, line := range strings.Split(string(bytes), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			values[key] = value
+		}
+	}
+	*v.(*map[string]any) = values
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf/provider/file/internal/assert"
+	"github.com/nil-go/konf/provider/file/internal/clock"
+)
+
+func TestFake_timer(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	timer, _ := fake.NewTimer(time.Minute)
+
+	fake.Advance(30 * time.Second)
+	select {
+	case <-timer:
+		t.Fatal("timer fired before due")
+	default:
+	}
+
+	fake.Advance(30 * time.Second)
+	assert.Equal(t, now.Add(time.Minute), <-timer)
+	assert.Equal(t, now.Add(time.Minute), fake.Now())
+}
+
+func TestFake_timer_stop(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Time{})
+	timer, stop := fake.NewTimer(time.Minute)
+	assert.True(t, stop())
+	assert.True(t, !stop())
+
+	fake.Advance(time.Minute)
+	select {
+	case <-timer:
+		t.Fatal("stopped timer fired")
+	default:
+	}
+}
+
+func TestFake_ticker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	ticker, stop := fake.NewTicker(time.Minute)
+
+	fake.Advance(time.Minute)
+	assert.Equal(t, now.Add(time.Minute), <-ticker)
+	fake.Advance(3 * time.Minute) // Drops missed ticks.
+	assert.Equal(t, now.Add(4*time.Minute), <-ticker)
+
+	stop()
+	fake.Advance(time.Minute)
+	select {
+	case <-ticker:
+		t.Fatal("stopped ticker ticked")
+	default:
+	}
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Time{})
+	created := make(chan struct{})
+	go func() {
+		defer close(created)
+		fake.NewTimer(time.Minute)
+	}()
+	fake.BlockUntil(1)
+	<-created
+}
+
+func TestReal(t *testing.T) {
+	t.Parallel()
+
+	var wall clock.Real
+	assert.True(t, !wall.Now().IsZero())
+
+	timer, _ := wall.NewTimer(time.Millisecond)
+	<-timer
+	ticker, stop := wall.NewTicker(time.Millisecond)
+	<-ticker
+	stop()
+}
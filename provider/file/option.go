@@ -3,18 +3,191 @@
 
 package file
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
 // WithUnmarshal provides the function used to parses the configuration file.
 // The unmarshal function must be able to unmarshal the file content into a map[string]any.
 //
-// The default function is json.Unmarshal.
+// It takes precedence over the functions provided by WithExtensionUnmarshal.
+// The default function is json.Unmarshal, except for `.yaml`, `.yml` and `.toml` files
+// which fail to load unless the function for the extension is provided by WithExtensionUnmarshal.
 func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	return func(options *options) {
-		options.unmarshal = unmarshal
+		options.Unmarshal = unmarshal
+	}
+}
+
+// WithExtensionUnmarshal provides the function used to parses the configuration file
+// with the given extension (e.g. ".yaml"). The extension is matched case-insensitively.
+//
+// It's used when no unmarshal function is provided by WithUnmarshal.
+// The files whose extension has no function are parsed as described in WithUnmarshal.
+func WithExtensionUnmarshal(extension string, unmarshal func([]byte, any) error) Option {
+	return func(options *options) {
+		if options.ExtensionUnmarshals == nil {
+			options.ExtensionUnmarshals = make(map[string]func([]byte, any) error)
+		}
+		options.ExtensionUnmarshals[strings.ToLower(extension)] = unmarshal
+	}
+}
+
+// WithRootPath provides the path, separated by `.`, of the map used as the configuration
+// after parsing, e.g. `spec.config` for the configuration nested in a Kubernetes custom resource.
+// It returns error if the path is not found or is not a map.
+//
+// By default, the whole document is the configuration.
+func WithRootPath(path string) Option {
+	return func(options *options) {
+		options.RootPath = path
+	}
+}
+
+// WithDebounce provides the window for File.Watch to wait for the events of the file to settle,
+// so that a flurry of writes is collapsed into one reload.
+//
+// The default window is 5ms.
+func WithDebounce(window time.Duration) Option {
+	return func(options *options) {
+		options.Debounce = window
+	}
+}
+
+// WithPollInterval makes File.Watch poll the file on the given interval instead of watching file events,
+// and reload it if its modification time or size has changed, e.g. for NFS, some overlay filesystems
+// and Kubernetes subPath mounts where file events are not reliable. WithDebounce has no effect while polling.
+//
+// By default, it's 0 which watches file events.
+func WithPollInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.PollInterval = interval
+	}
+}
+
+// Clock provides the timers and tickers which drive File.Watch.
+// It only uses built-in types, so that the konf.Clock, e.g. provided to konf.WithClock, can be used as well.
+type Clock interface {
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for the debounce window and polling of File.Watch.
+// It's useful for tests to drive watching deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.Clock = clock
+	}
+}
+
+// WithIgnoreNotExist makes File.Load return empty configuration instead of error if the file does not exist,
+// e.g. for the optional local override. Other errors, e.g. permission denied, are still returned.
+// File.Watch keeps watching while the file does not exist, and reloads it once it's created.
+func WithIgnoreNotExist() Option {
+	return func(options *options) {
+		options.IgnoreNotExist = true
+	}
+}
+
+// WithGzip decompresses the file with gzip before unmarshal regardless of its extension,
+// e.g. for the compressed file without extension `.gz`, which is always decompressed.
+func WithGzip() Option {
+	return func(options *options) {
+		options.Gzip = true
+	}
+}
+
+// WithForceReload makes File.Watch reload the file on every event,
+// even if its content is identical to the last loaded one.
+func WithForceReload() Option {
+	return func(options *options) {
+		options.ForceReload = true
+	}
+}
+
+// WithRequireMatch makes Glob.Load return error if no file matches the pattern.
+// By default, Glob.Load returns empty configuration if no file matches, same as WithIgnoreNotExist for File.
+// It has no effect on File.
+func WithRequireMatch() Option {
+	return func(options *options) {
+		options.RequireMatch = true
 	}
 }
 
 type (
 	// Option configures the a File with specific options.
 	Option  func(options *options)
-	options File
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the File from generated configuration.
+	Options struct {
+		// Unmarshal is the same as WithUnmarshal.
+		Unmarshal func([]byte, any) error
+		// ExtensionUnmarshals is the same as WithExtensionUnmarshal for each extension.
+		ExtensionUnmarshals map[string]func([]byte, any) error
+		// RootPath is the same as WithRootPath.
+		RootPath string
+		// Debounce is the same as WithDebounce.
+		Debounce time.Duration
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+		// IgnoreNotExist is the same as WithIgnoreNotExist.
+		IgnoreNotExist bool
+		// RequireMatch is the same as WithRequireMatch.
+		RequireMatch bool
+		// Gzip is the same as WithGzip.
+		Gzip bool
+		// ForceReload is the same as WithForceReload.
+		ForceReload bool
+	}
 )
+
+func (o Options) validate() error {
+	var errs []error
+	if o.Unmarshal != nil && len(o.ExtensionUnmarshals) > 0 {
+		errs = append(errs, errors.New("extension unmarshals are never used since Unmarshal is provided")) //nolint:err113
+	}
+	sorted := make([]string, 0, len(o.ExtensionUnmarshals))
+	for extension := range o.ExtensionUnmarshals {
+		sorted = append(sorted, extension)
+	}
+	slices.Sort(sorted)
+	extensions := make(map[string]string, len(sorted))
+	for _, extension := range sorted {
+		if other, ok := extensions[strings.ToLower(extension)]; ok {
+			errs = append(errs, fmt.Errorf( //nolint:err113
+				"extension %q conflicts with %q since extensions are case-insensitive", extension, other,
+			))
+		}
+		extensions[strings.ToLower(extension)] = extension
+		if !strings.HasPrefix(extension, ".") {
+			errs = append(errs, fmt.Errorf("extension %q does not start with '.'", extension)) //nolint:err113
+		}
+		if o.ExtensionUnmarshals[extension] == nil {
+			errs = append(errs, fmt.Errorf("unmarshal for extension %q is nil", extension)) //nolint:err113
+		}
+	}
+	if o.RootPath != "" && strings.Contains("."+o.RootPath+".", "..") {
+		errs = append(errs, fmt.Errorf("root path %q has empty key", o.RootPath)) //nolint:err113
+	}
+	if o.Debounce < 0 {
+		errs = append(errs, fmt.Errorf("debounce %s is negative", o.Debounce)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
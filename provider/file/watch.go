@@ -5,92 +5,338 @@ package file
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
 
+// Status registers the callback which is called with the status of File.Load and File.Watch.
 func (f *File) Status(onStatus func(bool, error)) {
 	f.onStatus = onStatus
 }
 
-//nolint:cyclop,funlen
-func (f *File) Watch(ctx context.Context, onChange func(map[string]any)) (err error) { //nolint:gocognit,nonamedreturns
+// defaultDebounce is the debounce window if WithDebounce is not provided,
+// since certain events fire multiple times on some platforms.
+const defaultDebounce = 5 * time.Millisecond
+
+// Watch watches the file, and reloads it after the events of the file have settled
+// for the debounce window provided by WithDebounce.
+//
+// It watches the parent directory instead of the file, so that it keeps watching
+// while the file is replaced via rename, e.g. the atomic write of editors,
+// or the symlink of the file is swapped, e.g. the ConfigMap mounted in Kubernetes.
+// If the file is removed, it's reported via Status as error and onChange is called with nil,
+// or onChange is called with the empty values if WithIgnoreNotExist is provided, the same as File.Load.
+// It's only reported once until the file is created again.
+// If the file fails to reload, e.g. the content is transiently invalid, the error is reported via Status
+// and onChange is not called, so the last values are kept.
+// If the content of the file is byte-identical to the last loaded one by File.Load or File.Watch,
+// e.g. the file is touched or replaced with the same content, it's not unmarshalled and onChange is not called,
+// but it's reported via Status as unchanged, unless WithForceReload is provided.
+// Without File.Load before, the first reload always calls onChange.
+//
+// The file is also checked once after the debounce window when the watch is established,
+// so that the change between File.Load and File.Watch is not missed.
+//
+// If WithPollInterval is provided, it polls the file on the interval instead of watching the events.
+func (f *File) Watch(ctx context.Context, onChange func(map[string]any)) error {
 	if f == nil {
 		return errNil
 	}
 
+	// Although only a single file is being watched, fsnotify has to watch
+	// the whole parent directory to pick up all events such as symlink changes.
+	dir, _ := filepath.Split(f.path)
+	if dir == "" {
+		dir = "."
+	}
+	target := watchTarget{dir: dir, read: f.read, stat: f.stat}
+	if f.poll > 0 {
+		return f.watchPoll(ctx, target, onChange)
+	}
+
+	// Resolve symlinks and save the original path so that changes to symlinks
+	// can be detected.
+	realPath, err := f.resolve()
+	if err != nil && (!f.ignore || !errors.Is(err, os.ErrNotExist)) {
+		return fmt.Errorf("eval symlike: %w", err)
+	}
+	target.match = func(path string) bool {
+		// Since the event is triggered on a directory, is this
+		// one on the file being watched, or does the symlink point to another file?
+		newRealPath, _ := f.resolve()
+		if path != realPath && path != filepath.Clean(f.path) && newRealPath == realPath {
+			return false
+		}
+		realPath = newRealPath
+
+		return true
+	}
+
+	return f.watchEvents(ctx, target, onChange)
+}
+
+// watchTarget is what is watched, i.e. the file of File.Watch, or all files matching the pattern of Glob.Watch.
+type watchTarget struct {
+	dir string
+	// match reports whether the event of the path in the dir is on the target.
+	match func(path string) bool
+	// read reads the content of the target, or returns the error if the target is missing.
+	read func() (snapshot, error)
+	// stat returns the fingerprint of the target for polling, which changes if the target is modified.
+	stat func() (string, error)
+}
+
+// snapshot is the content of the target read at once.
+type snapshot struct {
+	digest digest
+	// decode decodes the content. It's only called if the digest differs from the last loaded one.
+	decode func() (map[string]any, error)
+}
+
+// read reads the file, or returns the error if the file does not exist.
+func (f *File) read() (snapshot, error) {
+	if _, err := os.Stat(f.path); errors.Is(err, os.ErrNotExist) {
+		return snapshot{}, fmt.Errorf("stat file %s: %w", f.path, err)
+	}
+	content, err := os.ReadFile(f.path)
+
+	return snapshot{
+		digest: digest{sum: sha256.Sum256(content), valid: err == nil},
+		decode: func() (map[string]any, error) { return f.decode(content, err) },
+	}, nil
+}
+
+// stat returns the modification time and the size of the file, or empty if the file does not exist.
+func (f *File) stat() (string, error) {
+	info, err := os.Stat(f.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("stat file %s: %w", f.path, err)
+	default:
+		return fingerprint(info), nil
+	}
+}
+
+func fingerprint(info os.FileInfo) string {
+	return strconv.FormatInt(info.ModTime().UnixNano(), 10) + ":" + strconv.FormatInt(info.Size(), 10)
+}
+
+// watchEvents watches the events of the dir of the target until ctx is done,
+// and checks the target after the events on it have settled for the debounce window.
+//
+//nolint:cyclop,nonamedreturns
+func (f *File) watchEvents(ctx context.Context, target watchTarget, onChange func(map[string]any)) (err error) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
-		return fmt.Errorf("create file watcher for %s: %w", f.path, err)
+		return fmt.Errorf("create file watcher for %s: %w", target.dir, err)
 	}
 	defer func() {
 		if e := watcher.Close(); e != nil {
 			err = errors.Join(err, e)
 		}
 	}()
-
-	// Although only a single file is being watched, fsnotify has to watch
-	// the whole parent directory to pick up all events such as symlink changes.
-	dir, _ := filepath.Split(f.path)
-	if e := watcher.Add(dir); e != nil {
-		return fmt.Errorf("watch dir %s: %w", dir, e)
+	if e := watcher.Add(target.dir); e != nil {
+		return fmt.Errorf("watch dir %s: %w", target.dir, e)
 	}
 
-	// Resolve symlinks and save the original path so that changes to symlinks
-	// can be detected.
-	realPath, err := filepath.EvalSymlinks(f.path)
-	if err != nil {
-		return fmt.Errorf("eval symlike: %w", err)
+	state := f.newWatchState()
+	debounce := f.debounce
+	if debounce == 0 {
+		debounce = defaultDebounce
 	}
-	realPath = filepath.Clean(realPath)
+	// The timer is armed for the initial check, and then only within the debounce window.
+	// Otherwise it's nil, and the nil channel blocks forever.
+	timer, stop := f.newTimer(debounce)
+	defer func() { stop() }()
 
-	var (
-		lastEvent     string
-		lastEventTime time.Time
-	)
 	for {
 		select {
 		case event := <-watcher.Events:
-			// Use a simple timer to buffer events as certain events fire
-			// multiple times on some platforms.
-			if event.String() == lastEvent && time.Since(lastEventTime) < 5*time.Millisecond {
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) &&
+				!event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
 				continue
 			}
-			lastEvent = event.String()
-			lastEventTime = time.Now()
-
-			// Since the event is triggered on a directory, is this
-			// one on the file being watched?
-			evFile := filepath.Clean(event.Name)
-			if evFile != realPath && evFile != f.path {
+			if !target.match(filepath.Clean(event.Name)) {
 				continue
 			}
-
-			switch {
-			case event.Has(fsnotify.Remove):
-				if f.onStatus != nil {
-					f.onStatus(true, nil)
-				}
-				onChange(nil)
-			case event.Has(fsnotify.Create) || event.Has(fsnotify.Write):
-				values, err := f.Load()
-				if f.onStatus != nil {
-					f.onStatus(true, err)
-				}
-				onChange(values)
+			// Collapse the flurry of events into one reload by restarting the timer.
+			// If the timer has fired but not been received, the pending reload covers this event.
+			if timer == nil || stop() {
+				timer, stop = f.newTimer(debounce)
 			}
 
-		case err := <-watcher.Errors:
+		case <-timer:
+			timer = nil
+			f.check(target, &state, onChange)
+
+		case e := <-watcher.Errors:
 			if f.onStatus != nil {
-				f.onStatus(false, err)
+				f.onStatus(false, e)
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// resolve returns the cleaned path of the file with symlinks resolved.
+// If the file does not exist, the directory and the base name are resolved separately,
+// so that it's still the path where the file is created, along with the error of the file.
+func (f *File) resolve() (string, error) {
+	realPath, err := filepath.EvalSymlinks(f.path)
+	if err == nil {
+		return filepath.Clean(realPath), nil
+	}
+
+	dir, base := filepath.Split(f.path)
+	if dir == "" {
+		dir = "."
+	}
+	if realDir, e := filepath.EvalSymlinks(dir); e == nil {
+		dir = realDir
+	}
+
+	return filepath.Join(dir, base), err
+}
+
+// watchPoll stats the target on the interval provided by WithPollInterval until ctx is done,
+// and checks it if its fingerprint, e.g. the modification time or size of the file, has changed.
+// The error of stat is reported via Status once until it's changed.
+func (f *File) watchPoll(ctx context.Context, target watchTarget, onChange func(map[string]any)) error {
+	last, lastErr := target.stat()
+	state := f.newWatchState()
+	ticker, stop := f.newTicker(f.poll)
+	defer stop()
+
+	for {
+		select {
+		case <-ticker:
+			current, err := target.stat()
+			switch {
+			case err != nil:
+				if lastErr == nil || err.Error() != lastErr.Error() {
+					if f.onStatus != nil {
+						f.onStatus(false, err)
+					}
+				}
+			case lastErr != nil || current != last:
+				f.check(target, &state, onChange)
 			}
+			last, lastErr = current, err
 
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
+
+func (f *File) newTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	if f.clock != nil {
+		return f.clock.NewTimer(d)
+	}
+	timer := time.NewTimer(d)
+
+	return timer.C, timer.Stop
+}
+
+func (f *File) newTicker(d time.Duration) (<-chan time.Time, func()) {
+	if f.clock != nil {
+		return f.clock.NewTicker(d)
+	}
+	ticker := time.NewTicker(d)
+
+	return ticker.C, ticker.Stop
+}
+
+// digest is the sha256 hash of the content last loaded by File.Load or File.Watch.
+type digest struct {
+	sum   [sha256.Size]byte
+	valid bool // False if the file has not been loaded, e.g. it does not exist.
+}
+
+// watchState is the state of the target while watching.
+type watchState struct {
+	loaded  digest // The digest of the content last loaded.
+	missing bool   // The target is missing, and it has been reported.
+}
+
+// newWatchState returns the state with the digest of the content last loaded by Load,
+// or the invalid digest if it has not been loaded, so that the first reload is never skipped.
+// The target is regarded as missing if it has not been loaded, so that it's not reported as removed.
+func (f *File) newWatchState() watchState {
+	var loaded digest
+	if f.loaded != nil {
+		if last := f.loaded.Load(); last != nil {
+			loaded = *last
+		}
+	}
+
+	return watchState{loaded: loaded, missing: !loaded.valid}
+}
+
+// check reads the target, and reloads it, or reports it's removed if it's missing and it has not been reported.
+func (f *File) check(target watchTarget, state *watchState, onChange func(map[string]any)) {
+	current, err := target.read()
+	if err != nil {
+		if !state.missing {
+			f.remove(err, &state.loaded, onChange)
+		}
+		state.missing = true
+
+		return
+	}
+	state.missing = false
+	f.reload(current, &state.loaded, onChange)
+}
+
+// remove reports the target is missing via Status, and calls onChange with nil.
+// Same as Load, the missing file is not an error if WithIgnoreNotExist is provided,
+// and onChange is called with the empty values instead.
+func (f *File) remove(err error, loaded *digest, onChange func(map[string]any)) {
+	*loaded = digest{}
+	if f.ignore && errors.Is(err, os.ErrNotExist) {
+		if f.onStatus != nil {
+			f.onStatus(true, nil)
+		}
+		onChange(map[string]any{})
+
+		return
+	}
+
+	if f.onStatus != nil {
+		f.onStatus(false, err)
+	}
+	onChange(nil)
+}
+
+// reload decodes the content and calls onChange with the values if it succeeds, and reports the status via Status.
+// It skips the content which is identical to the last loaded one unless WithForceReload is provided,
+// and reports it via Status as unchanged.
+func (f *File) reload(current snapshot, loaded *digest, onChange func(map[string]any)) {
+	if !f.force && current.digest.valid && current.digest == *loaded {
+		if f.onStatus != nil {
+			f.onStatus(false, nil)
+		}
+
+		return
+	}
+
+	values, err := current.decode()
+	if f.onStatus != nil {
+		f.onStatus(err == nil, err)
+	}
+	if err == nil {
+		*loaded = current.digest
+		onChange(values)
+	}
+}
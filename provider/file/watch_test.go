@@ -7,11 +7,13 @@ import (
 	"context"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/nil-go/konf/provider/file"
 	"github.com/nil-go/konf/provider/file/internal/assert"
+	"github.com/nil-go/konf/provider/file/internal/clock"
 )
 
 func TestFile_Watch(t *testing.T) {
@@ -19,27 +21,19 @@ func TestFile_Watch(t *testing.T) {
 		description string
 		action      func(string) error
 		expected    map[string]any
+		err         string
 	}{
 		{
 			description: "write",
 			action: func(path string) error {
-				err := os.WriteFile(path, []byte(`{"p": {"k": "c"}}`), 0o600)
-				time.Sleep(time.Second) // wait for the file to be written
-
-				return err
+				return os.WriteFile(path, []byte(`{"p": {"k": "c"}}`), 0o600)
 			},
 			expected: map[string]any{"p": map[string]any{"k": "c"}},
 		},
 		{
 			description: "remove",
-			action: func(path string) error {
-				err := os.Remove(path)
-				for _, e := os.Stat(path); os.IsExist(e); _, e = os.Stat(path) { //nolint:revive
-					// wait for the file to be removed
-				}
-
-				return err
-			},
+			action:      os.Remove,
+			err:         "no such file or directory",
 		},
 	}
 
@@ -47,28 +41,308 @@ func TestFile_Watch(t *testing.T) {
 		t.Run(testcase.description, func(t *testing.T) {
 			tmpFile := path.Join(t.TempDir(), "watch.json")
 			assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"p": {"k": "v"}}`), 0o600))
-			for _, e := os.Stat(tmpFile); os.IsNotExist(e); _, e = os.Stat(tmpFile) { //nolint:revive
-				// wait for the file to be written
+
+			fake := clock.NewFake(time.Time{})
+			loader := file.New(tmpFile, file.WithClock(fake))
+			_, err := loader.Load()
+			assert.NoError(t, err)
+			statuses := make(chan error, 10)
+			loader.Status(func(_ bool, err error) { statuses <- err })
+			values := make(chan map[string]any, 10)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+			}()
+			fake.BlockUntil(1) // Wait for the watch to be established.
+
+			assert.NoError(t, testcase.action(tmpFile))
+			if err := settle(fake, statuses); testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, strings.HasSuffix(err.Error(), testcase.err))
 			}
+			assert.Equal(t, testcase.expected, <-values)
+		})
+	}
+}
+
+// settle fires the debounce timer armed by File.Watch with the fake clock,
+// and returns the status reported by the following check of the file.
+func settle(fake *clock.Fake, statuses <-chan error) error {
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+
+	return <-statuses
+}
+
+func TestFile_Watch_replace(t *testing.T) {
+	dir := t.TempDir()
+	tmpFile := path.Join(dir, "watch.json")
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "v"}`), 0o600))
+
+	fake := clock.NewFake(time.Time{})
+	loader := file.New(tmpFile, file.WithDebounce(100*time.Millisecond), file.WithClock(fake))
+	_, err := loader.Load()
+	assert.NoError(t, err)
+	statuses := make(chan error, 10)
+	loader.Status(func(_ bool, err error) { statuses <- err })
+	values := make(chan map[string]any, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+	}()
+	fake.BlockUntil(1) // Wait for the watch to be established.
+
+	// The transient invalid content is reported, and does not stop watching.
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": `), 0o600))
+	assert.EqualError(t, settle(fake, statuses), "unmarshal: unexpected end of JSON input")
+
+	// The atomic write via rename, with a flurry of writes collapsed into one change.
+	for _, value := range []string{"a", "b", "c"} {
+		tmp := path.Join(dir, "watch.json.tmp")
+		assert.NoError(t, os.WriteFile(tmp, []byte(`{"k": "`+value+`"}`), 0o600))
+		assert.NoError(t, os.Rename(tmp, tmpFile))
+	}
+	assert.NoError(t, settle(fake, statuses))
+	assert.Equal(t, map[string]any{"k": "c"}, <-values)
+
+	// Keep watching after the file is replaced.
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "d"}`), 0o600))
+	assert.NoError(t, settle(fake, statuses))
+	assert.Equal(t, map[string]any{"k": "d"}, <-values)
+	assert.Equal(t, 0, len(values))
+}
 
-			values := make(chan map[string]any)
+func TestFile_Watch_identical(t *testing.T) {
+	testcases := []struct {
+		description string
+		opts        []file.Option
+		unloaded    bool
+		expected    map[string]any
+	}{
+		{
+			description: "skip identical",
+			expected:    map[string]any{"k": "changed"},
+		},
+		{
+			description: "force reload",
+			opts:        []file.Option{file.WithForceReload()},
+			expected:    map[string]any{"k": "v"},
+		},
+		{
+			description: "not loaded",
+			unloaded:    true,
+			expected:    map[string]any{"k": "v"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			tmpFile := path.Join(t.TempDir(), "watch.json")
+			assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "v"}`), 0o600))
 
-			started := make(chan struct{})
+			fake := clock.NewFake(time.Time{})
+			loader := file.New(tmpFile, append(testcase.opts, file.WithClock(fake))...)
+			if !testcase.unloaded {
+				_, err := loader.Load()
+				assert.NoError(t, err)
+			}
+			statuses := make(chan error, 10)
+			loader.Status(func(_ bool, err error) { statuses <- err })
+			values := make(chan map[string]any, 10)
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
-			loader := file.New(tmpFile)
 			go func() {
-				close(started)
-				err := loader.Watch(ctx, func(changed map[string]any) {
-					values <- changed
-				})
-				assert.NoError(t, err)
+				assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
 			}()
-			<-started
-			time.Sleep(time.Second) // wait for the watcher to start
+			fake.BlockUntil(1) // Wait for the watch to be established.
 
-			assert.NoError(t, testcase.action(tmpFile))
+			assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "v"}`), 0o600))
+			assert.NoError(t, settle(fake, statuses))
+			assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "changed"}`), 0o600))
+			assert.NoError(t, settle(fake, statuses))
 			assert.Equal(t, testcase.expected, <-values)
 		})
 	}
 }
+
+func TestNewFromOptions_debounce(t *testing.T) {
+	_, err := file.NewFromOptions("config.json", file.Options{Debounce: -time.Second})
+	assert.EqualError(t, err, "invalid options: debounce -1s is negative")
+}
+
+func TestFile_Watch_poll(t *testing.T) {
+	tmpFile := path.Join(t.TempDir(), "watch.json")
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "v"}`), 0o600))
+
+	values := make(chan map[string]any, 10)
+	statuses := make(chan error, 10)
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	fake := clock.NewFake(time.Time{})
+	loader := file.New(tmpFile, file.WithPollInterval(10*time.Millisecond), file.WithClock(fake))
+	loader.Status(func(_ bool, err error) { statuses <- err })
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+	}()
+	fake.BlockUntil(1) // Wait for the ticker of polling.
+
+	// Write via rename so that the poll never reads the partial content.
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(tmpFile+".tmp", []byte(content), 0o600))
+		assert.NoError(t, os.Rename(tmpFile+".tmp", tmpFile))
+	}
+	write(`{"k": "changed"}`)
+	assert.NoError(t, settle(fake, statuses))
+	assert.Equal(t, map[string]any{"k": "changed"}, <-values)
+
+	// The missing file is reported via Status once.
+	assert.NoError(t, os.Remove(tmpFile))
+	assert.EqualError(t, settle(fake, statuses), "stat file "+tmpFile+": stat "+tmpFile+": no such file or directory")
+	assert.Equal(t, map[string]any(nil), <-values)
+	fake.BlockUntil(1)
+	fake.Advance(time.Second) // The missing file is not reported again.
+
+	write(`{"k": "back"}`)
+	assert.NoError(t, settle(fake, statuses))
+	assert.Equal(t, map[string]any{"k": "back"}, <-values)
+	assert.Equal(t, 0, len(statuses))
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("watch does not stop after context is canceled")
+	}
+}
+
+func TestNewFromOptions_pollInterval(t *testing.T) {
+	_, err := file.NewFromOptions("config.json", file.Options{PollInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: poll interval -1s is negative")
+}
+
+func TestFile_Watch_ignoreNotExist(t *testing.T) {
+	testcases := []struct {
+		description string
+		opts        []file.Option
+	}{
+		{description: "event"},
+		{description: "poll", opts: []file.Option{file.WithPollInterval(10 * time.Millisecond)}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			tmpFile := path.Join(t.TempDir(), "watch.json")
+			fake := clock.NewFake(time.Time{})
+			loader := file.New(tmpFile, append(testcase.opts, file.WithIgnoreNotExist(), file.WithClock(fake))...)
+			values, err := loader.Load()
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]any{}, values)
+
+			statuses := make(chan error, 10)
+			loader.Status(func(_ bool, err error) { statuses <- err })
+			changes := make(chan map[string]any, 10)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { changes <- changed }))
+			}()
+			fake.BlockUntil(1) // Wait for the watch to be established.
+
+			assert.NoError(t, os.WriteFile(tmpFile+".tmp", []byte(`{"k": "v"}`), 0o600))
+			assert.NoError(t, os.Rename(tmpFile+".tmp", tmpFile))
+			assert.NoError(t, settle(fake, statuses))
+			assert.Equal(t, map[string]any{"k": "v"}, <-changes)
+		})
+	}
+}
+
+func TestFile_Watch_notExistOtherFile(t *testing.T) {
+	dir := t.TempDir()
+	tmpFile := path.Join(dir, "watch.json")
+	fake := clock.NewFake(time.Time{})
+	loader := file.New(tmpFile, file.WithIgnoreNotExist(), file.WithClock(fake))
+
+	statuses := make(chan error, 10)
+	loader.Status(func(_ bool, err error) { statuses <- err })
+	changes := make(chan map[string]any, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { changes <- changed }))
+	}()
+	fake.BlockUntil(1) // Wait for the watch to be established.
+
+	// The events of other files in the directory are ignored while the file does not exist.
+	assert.NoError(t, os.WriteFile(path.Join(dir, "other.json"), []byte(`{"k": "v"}`), 0o600))
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "v"}`), 0o600))
+	assert.NoError(t, settle(fake, statuses))
+	assert.Equal(t, map[string]any{"k": "v"}, <-changes)
+	assert.Equal(t, 0, len(changes))
+	assert.Equal(t, 0, len(statuses))
+}
+
+func TestFile_Watch_removeStatus(t *testing.T) {
+	testcases := []struct {
+		description string
+		opts        []file.Option
+		poll        bool
+		ignore      bool
+	}{
+		{description: "event"},
+		{description: "poll", opts: []file.Option{file.WithPollInterval(10 * time.Millisecond)}, poll: true},
+		{description: "event with ignore", opts: []file.Option{file.WithIgnoreNotExist()}, ignore: true},
+		{
+			description: "poll with ignore",
+			opts:        []file.Option{file.WithPollInterval(10 * time.Millisecond), file.WithIgnoreNotExist()},
+			poll:        true,
+			ignore:      true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			tmpFile := path.Join(t.TempDir(), "watch.json")
+			assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"k": "v"}`), 0o600))
+			fake := clock.NewFake(time.Time{})
+			loader := file.New(tmpFile, append(testcase.opts, file.WithClock(fake))...)
+			_, err := loader.Load()
+			assert.NoError(t, err)
+
+			statuses := make(chan error, 10)
+			loader.Status(func(_ bool, err error) { statuses <- err })
+			changes := make(chan map[string]any, 10)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { changes <- changed }))
+			}()
+			fake.BlockUntil(1) // Wait for the watch to be established.
+
+			assert.NoError(t, os.Remove(tmpFile))
+			err = settle(fake, statuses)
+			if testcase.ignore {
+				assert.NoError(t, err)
+				assert.Equal(t, map[string]any{}, <-changes) // Same as File.Load.
+			} else {
+				assert.EqualError(t, err, "stat file "+tmpFile+": stat "+tmpFile+": no such file or directory")
+				assert.Equal(t, map[string]any(nil), <-changes)
+			}
+
+			// The missing file is reported once until it's created again.
+			if testcase.poll {
+				fake.BlockUntil(1)
+				fake.Advance(time.Second)
+			}
+			assert.NoError(t, os.WriteFile(tmpFile+".tmp", []byte(`{"k": "back"}`), 0o600))
+			assert.NoError(t, os.Rename(tmpFile+".tmp", tmpFile))
+			assert.NoError(t, settle(fake, statuses))
+			assert.Equal(t, map[string]any{"k": "back"}, <-changes)
+			assert.Equal(t, 0, len(changes))
+			assert.Equal(t, 0, len(statuses))
+		})
+	}
+}
@@ -36,14 +36,27 @@ type Flag struct {
 // have been set by other providers. If not, default flag values are merged.
 // If they exist, flag values are merged only if explicitly set in the command line.
 func New(konf konf, opts ...Option) Flag {
-	option := &options{
-		konf: konf,
-	}
+	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
 
-	return Flag(*option)
+	return newFlag(konf, Options(*option))
+}
+
+// NewFromOptions creates a Flag with the given konf Config instance and Options,
+// same as New with the corresponding Option(s).
+// It returns error if the options are invalid, e.g. the prefix never matches any flag name.
+func NewFromOptions(konf konf, options Options) (Flag, error) {
+	if err := options.validate(); err != nil {
+		return Flag{}, err
+	}
+
+	return newFlag(konf, options), nil
+}
+
+func newFlag(konf konf, options Options) Flag {
+	return Flag{konf: konf, prefix: options.Prefix, set: options.FlagSet, splitter: options.NameSplitter}
 }
 
 func (f Flag) Load() (map[string]any, error) { //nolint:cyclop
@@ -63,7 +76,7 @@ func (f Flag) Load() (map[string]any, error) { //nolint:cyclop
 	}
 
 	var exists func([]string) bool
-	if f.konf != nil && !reflect.ValueOf(f.konf).IsNil() {
+	if f.konf != nil && !isNil(f.konf) {
 		exists = f.konf.Exists
 	} else {
 		exists = func([]string) bool {
@@ -98,6 +111,17 @@ func (f Flag) Load() (map[string]any, error) { //nolint:cyclop
 	return values, nil
 }
 
+// isNil reports whether the konf is a typed nil, e.g. (*konf.Config)(nil).
+func isNil(konf konf) bool {
+	value := reflect.ValueOf(konf)
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
 // isZeroValue is copied from flag/flag.go.
 func isZeroValue(flg *flag.Flag) bool {
 	// Build a zero value of the flag's Value type, and see if the
@@ -149,6 +149,44 @@ func TestFlag_String(t *testing.T) {
 	}
 }
 
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := kflag.NewFromOptions(&konfStub{}, kflag.Options{
+		Prefix:       "k",
+		FlagSet:      set,
+		NameSplitter: func(s string) []string { return []string{s} },
+	})
+	assert.NoError(t, err)
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+	assert.Equal(t, "flag:k*", loader.String())
+
+	_, err = kflag.NewFromOptions(nil, kflag.Options{Prefix: "-k"})
+	assert.EqualError(t, err, `invalid options: prefix "-k" never matches any flag name`)
+}
+
+func FuzzNewFromOptions(f *testing.F) {
+	f.Add("k", ".", true)
+	f.Add("", "", false)
+	f.Add("-k=", "_", true)
+
+	f.Fuzz(func(t *testing.T, prefix, delimiter string, exists bool) {
+		set := &flag.FlagSet{}
+		set.String("k.v", "v", "")
+		options := kflag.Options{Prefix: prefix, FlagSet: set}
+		if delimiter != "" {
+			options.NameSplitter = func(s string) []string { return strings.Split(s, delimiter) }
+		}
+
+		if loader, err := kflag.NewFromOptions(konfStub{exists: exists}, options); err == nil {
+			_, err = loader.Load()
+			assert.NoError(t, err)
+		}
+	})
+}
+
 var (
 	parse = sync.OnceFunc(flag.Parse)
 	set   = &flag.FlagSet{}
@@ -15,6 +15,8 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+
+	"github.com/nil-go/konf/internal/maps"
 )
 
 // FS is a Provider that loads configuration from file system.
@@ -24,22 +26,36 @@ type FS struct {
 	fs        fs.FS
 	path      string
 	unmarshal func([]byte, any) error
+	rootPath  string
 }
 
 // New creates a FS with the given fs.FS, path and Option(s).
 func New(fs fs.FS, path string, opts ...Option) FS {
-	option := &options{
-		fs:   fs,
-		path: path,
-	}
+	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
-	if option.unmarshal == nil {
-		option.unmarshal = json.Unmarshal
+
+	return newFS(fs, path, Options(*option))
+}
+
+// NewFromOptions creates a FS with the given fs.FS, path and Options, same as New with the corresponding Option(s).
+// It returns error if the path is not valid for fs.FS (see fs.ValidPath), or the options are invalid.
+func NewFromOptions(fs fs.FS, path string, options Options) (FS, error) {
+	if err := options.validate(path); err != nil {
+		return FS{}, err
+	}
+
+	return newFS(fs, path, options), nil
+}
+
+func newFS(fs fs.FS, path string, options Options) FS {
+	loader := FS{fs: fs, path: path, unmarshal: options.Unmarshal, rootPath: options.RootPath}
+	if loader.unmarshal == nil {
+		loader.unmarshal = json.Unmarshal
 	}
 
-	return FS(*option)
+	return loader
 }
 
 func (f FS) Load() (map[string]any, error) {
@@ -60,7 +76,7 @@ func (f FS) Load() (map[string]any, error) {
 		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
 
-	return out, nil
+	return maps.Descend(out, f.rootPath)
 }
 
 func (f FS) String() string {
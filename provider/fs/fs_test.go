@@ -9,7 +9,9 @@ import (
 	"testing"
 	"testing/fstest"
 
+	"github.com/nil-go/konf"
 	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/konftest"
 	kfs "github.com/nil-go/konf/provider/fs"
 )
 
@@ -88,6 +90,39 @@ func TestFS_Load(t *testing.T) {
 			},
 			err: "unmarshal: unmarshal error",
 		},
+		{
+			description: "root path",
+			fs: fstest.MapFS{
+				"crd.json": {
+					Data: []byte(`{"kind":"App","spec":{"config":{"k":"v"}}}`),
+				},
+			},
+			path:     "crd.json",
+			opts:     []kfs.Option{kfs.WithRootPath("spec.config")},
+			expected: map[string]any{"k": "v"},
+		},
+		{
+			description: "root path (not found)",
+			fs: fstest.MapFS{
+				"crd.json": {
+					Data: []byte(`{"kind":"App","spec":{}}`),
+				},
+			},
+			path: "crd.json",
+			opts: []kfs.Option{kfs.WithRootPath("spec.config")},
+			err:  `root path "spec.config" is not found`,
+		},
+		{
+			description: "root path (not map)",
+			fs: fstest.MapFS{
+				"crd.json": {
+					Data: []byte(`{"kind":"App","spec":"config"}`),
+				},
+			},
+			path: "crd.json",
+			opts: []kfs.Option{kfs.WithRootPath("spec.config")},
+			err:  `root path "spec.config": string is not a map`,
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -110,3 +145,40 @@ func TestFS_String(t *testing.T) {
 
 	assert.Equal(t, "fs:///config.json", kfs.New(fstest.MapFS{}, "config.json").String())
 }
+
+func TestFS_conformance(t *testing.T) {
+	t.Parallel()
+
+	fs := fstest.MapFS{
+		"config.json": {Data: []byte(`{"server":{"port":8080,"hosts":["a",{"name":"b"}]}}`)},
+	}
+	konftest.ConformanceSuite(t, func() konf.Loader { return kfs.New(fs, "config.json") })
+}
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	fs := fstest.MapFS{"config.json": {Data: []byte(`{"spec":{"config":{"k":"v"}}}`)}}
+	loader, err := kfs.NewFromOptions(fs, "config.json", kfs.Options{RootPath: "spec.config"})
+	assert.NoError(t, err)
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+
+	_, err = kfs.NewFromOptions(fs, "/config.json", kfs.Options{RootPath: "spec..config"})
+	assert.EqualError(t, err, "invalid options: path \"/config.json\" is not valid for fs.FS\n"+
+		"root path \"spec..config\" has empty key")
+}
+
+func FuzzNewFromOptions(f *testing.F) {
+	f.Add("config.json", "spec.config")
+	f.Add("", "")
+	f.Add("../config.json", "spec..config")
+
+	fs := fstest.MapFS{"config.json": {Data: []byte(`{"spec":{"config":{"k":"v"}}}`)}}
+	f.Fuzz(func(t *testing.T, path, rootPath string) {
+		if loader, err := kfs.NewFromOptions(fs, path, kfs.Options{RootPath: rootPath}); err == nil {
+			_, _ = loader.Load()
+		}
+	})
+}
@@ -3,18 +3,60 @@
 
 package fs
 
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
 // WithUnmarshal provides the function used to parses the configuration file.
 // The unmarshal function must be able to unmarshal the file content into a map[string]any.
 //
 // The default function is json.Unmarshal.
 func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	return func(options *options) {
-		options.unmarshal = unmarshal
+		options.Unmarshal = unmarshal
+	}
+}
+
+// WithRootPath provides the path, separated by `.`, of the map used as the configuration
+// after parsing, e.g. `spec.config` for the configuration nested in a Kubernetes custom resource.
+// It returns error if the path is not found or is not a map.
+//
+// By default, the whole document is the configuration.
+func WithRootPath(path string) Option {
+	return func(options *options) {
+		options.RootPath = path
 	}
 }
 
 type (
 	// Option configures the a FS with specific options.
 	Option  func(file *options)
-	options FS
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the FS from generated configuration.
+	Options struct {
+		// Unmarshal is the same as WithUnmarshal.
+		Unmarshal func([]byte, any) error
+		// RootPath is the same as WithRootPath.
+		RootPath string
+	}
 )
+
+func (o Options) validate(path string) error {
+	var errs []error
+	if !fs.ValidPath(path) {
+		errs = append(errs, fmt.Errorf("path %q is not valid for fs.FS", path)) //nolint:err113
+	}
+	if o.RootPath != "" && strings.Contains("."+o.RootPath+".", "..") {
+		errs = append(errs, fmt.Errorf("root path %q has empty key", o.RootPath)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -31,6 +31,8 @@ import (
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+
+	"github.com/nil-go/konf/provider/gcs/internal/maps"
 )
 
 // GCS is a Provider that loads configuration from GCP Cloud Storage.
@@ -38,7 +40,9 @@ import (
 // To create a new GCS, call [New].
 type GCS struct {
 	pollInterval time.Duration
+	clock        Clock
 	unmarshal    func([]byte, any) error
+	rootPath     string
 
 	onStatus  func(bool, error)
 	changedCh chan struct{}
@@ -47,27 +51,52 @@ type GCS struct {
 
 // New creates a GCS with the given endpoint and Option(s).
 func New(uri string, opts ...Option) *GCS {
-	uri = strings.TrimPrefix(uri, "gs:")
-	uri = strings.TrimLeft(uri, "/")
-	bucket, object, _ := strings.Cut(uri, "/")
-
-	option := &options{
-		client: clientProxy{
-			bucket: bucket,
-			object: object,
-		},
-		changedCh: make(chan struct{}, 1),
-	}
+	option := &options{}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case *optionFunc:
 			o.fn(option)
 		default:
-			option.client.opts = append(option.client.opts, o)
+			option.ClientOptions = append(option.ClientOptions, o)
 		}
 	}
 
-	return (*GCS)(option)
+	return newGCS(uri, Options(*option))
+}
+
+// NewFromOptions creates a GCS with the given uri and Options, same as New with the corresponding Option(s).
+// It returns error if the uri has no bucket or object, or the options are invalid, e.g. the poll interval is negative.
+func NewFromOptions(uri string, options Options) (*GCS, error) {
+	if err := options.validate(uri); err != nil {
+		return nil, err
+	}
+
+	return newGCS(uri, options), nil
+}
+
+func newGCS(uri string, options Options) *GCS {
+	bucket, object := parseURI(uri)
+
+	return &GCS{
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		unmarshal:    options.Unmarshal,
+		rootPath:     options.RootPath,
+		changedCh:    make(chan struct{}, 1),
+		client: clientProxy{
+			bucket: bucket,
+			object: object,
+			opts:   options.ClientOptions,
+		},
+	}
+}
+
+func parseURI(uri string) (string, string) {
+	uri = strings.TrimPrefix(uri, "gs:")
+	uri = strings.TrimLeft(uri, "/")
+	bucket, object, _ := strings.Cut(uri, "/")
+
+	return bucket, object
 }
 
 var errNil = errors.New("nil GCS")
@@ -100,12 +129,12 @@ func (g *GCS) Watch(ctx context.Context, onChange func(map[string]any)) error {
 	if g.pollInterval > 0 {
 		pollInterval = g.pollInterval
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := g.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticks:
 			g.changed()
 		case <-g.changedCh:
 			values, changed, err := g.load(ctx)
@@ -144,6 +173,10 @@ func (g *GCS) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	values, err = maps.Descend(values, g.rootPath)
+	if err != nil {
+		return nil, false, err
+	}
 
 	return values, true, nil
 }
@@ -173,6 +206,15 @@ func (g *GCS) String() string {
 	return "gs://" + g.client.bucket + "/" + g.client.object
 }
 
+func (g *GCS) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if g.clock != nil {
+		return g.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	bucket string
 	object string
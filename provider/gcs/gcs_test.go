@@ -253,3 +253,18 @@ type roundTripFunc func(*http.Request) *http.Response
 func (r roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return r(req), nil
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := gcs.NewFromOptions(
+		"gs://bucket/object", gcs.Options{PollInterval: time.Second, RootPath: "spec.config"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "gs://bucket/object", loader.String())
+
+	_, err = gcs.NewFromOptions("gs://bucket", gcs.Options{PollInterval: -time.Second, RootPath: "spec..config"})
+	assert.EqualError(t, err, "invalid options: uri \"gs://bucket\" has no bucket or object\n"+
+		"root path \"spec..config\" has empty key\n"+
+		"poll interval -1s is negative")
+}
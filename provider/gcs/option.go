@@ -5,6 +5,9 @@
 package gcs
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/api/option"
@@ -17,7 +20,25 @@ import (
 func WithPollInterval(interval time.Duration) Option {
 	return &optionFunc{
 		fn: func(options *options) {
-			options.pollInterval = interval
+			options.PollInterval = interval
+		},
+	}
+}
+
+// Clock provides the ticker which drives polling of the GCS object.
+// A konf.Clock can be passed as is.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.Clock = clock
 		},
 	}
 }
@@ -29,7 +50,20 @@ func WithPollInterval(interval time.Duration) Option {
 func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	return &optionFunc{
 		fn: func(options *options) {
-			options.unmarshal = unmarshal
+			options.Unmarshal = unmarshal
+		},
+	}
+}
+
+// WithRootPath provides the path, separated by `.`, of the map used as the configuration
+// after parsing, e.g. `spec.config` for the configuration nested in a Kubernetes custom resource.
+// It returns error if the path is not found or is not a map.
+//
+// By default, the whole document is the configuration.
+func WithRootPath(path string) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.RootPath = path
 		},
 	}
 }
@@ -40,5 +74,38 @@ type (
 		internaloption.EmbeddableAdapter
 		fn func(options *options)
 	}
-	options GCS
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the GCS from generated configuration.
+	Options struct {
+		// ClientOptions are passed to the GCS client as is.
+		ClientOptions []option.ClientOption
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+		// Unmarshal is the same as WithUnmarshal.
+		Unmarshal func([]byte, any) error
+		// RootPath is the same as WithRootPath.
+		RootPath string
+	}
 )
+
+func (o Options) validate(uri string) error {
+	var errs []error
+	if bucket, object := parseURI(uri); bucket == "" || object == "" {
+		errs = append(errs, fmt.Errorf("uri %q has no bucket or object", uri)) //nolint:err113
+	}
+	if o.RootPath != "" && strings.Contains("."+o.RootPath+".", "..") {
+		errs = append(errs, fmt.Errorf("root path %q has empty key", o.RootPath)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package ipc loads configuration from another process which serves it via konf.Serve.
+//
+// IPC connects to the given address (unix socket by default) and returns
+// the nested map[string]any of the configuration served by konf.Serve.
+//
+// # Change notification
+//
+// It keeps the connection while watching, and notifies each change sent by the server.
+// If the connection is lost, it reconnects after the retry interval and resyncs the whole configuration,
+// and notifies the change only if it's different from the last one.
+package ipc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/ipc"
+)
+
+// IPC is a Provider that loads configuration from another process via konf.Serve.
+//
+// To create a new IPC, call [New].
+type IPC struct {
+	network       string
+	addr          string
+	retryInterval time.Duration
+	clock         konf.Clock
+
+	onStatus func(bool, error)
+	last     atomic.Pointer[map[string]any]
+}
+
+// New creates an IPC with the given address of the server and Option(s).
+func New(addr string, opts ...Option) *IPC {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return newIPC(addr, Options(*option))
+}
+
+// NewFromOptions creates an IPC with the given address of the server and Options,
+// same as New with the corresponding Option(s).
+// It returns error if the address is empty, or the options are invalid, e.g. the network is udp.
+func NewFromOptions(addr string, options Options) (*IPC, error) {
+	if addr == "" {
+		return nil, errors.New("invalid options: empty address") //nolint:err113
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	return newIPC(addr, options), nil
+}
+
+func newIPC(addr string, options Options) *IPC {
+	loader := &IPC{network: options.Network, addr: addr, retryInterval: options.RetryInterval, clock: options.Clock}
+	if loader.network == "" {
+		loader.network = "unix"
+	}
+
+	return loader
+}
+
+var errNil = errors.New("nil IPC")
+
+func (i *IPC) Load() (map[string]any, error) {
+	if i == nil {
+		return nil, errNil
+	}
+
+	conn, err := i.dial(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	values, err := ipc.Read(conn)
+	if err != nil {
+		return nil, fmt.Errorf("read configuration: %w", err)
+	}
+	i.last.Store(&values)
+
+	return values, nil
+}
+
+func (i *IPC) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if i == nil {
+		return errNil
+	}
+
+	retryInterval := time.Second
+	if i.retryInterval > 0 {
+		retryInterval = i.retryInterval
+	}
+	for {
+		err := i.watch(ctx, onChange)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if i.onStatus != nil {
+			i.onStatus(false, err)
+		}
+
+		ticks, stop := i.newTicker(retryInterval)
+		select {
+		case <-ctx.Done():
+			stop()
+
+			return nil
+		case <-ticks:
+			stop()
+		}
+	}
+}
+
+// watch notifies the changes until the connection is lost.
+func (i *IPC) watch(ctx context.Context, onChange func(map[string]any)) error {
+	conn, err := i.dial(ctx)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer func() {
+		stop()
+		_ = conn.Close()
+	}()
+
+	for {
+		values, e := ipc.Read(conn)
+		if e != nil {
+			return fmt.Errorf("read configuration: %w", e)
+		}
+		last := i.last.Swap(&values)
+		changed := last == nil || !reflect.DeepEqual(*last, values)
+		if i.onStatus != nil {
+			i.onStatus(changed, nil)
+		}
+		if changed {
+			onChange(values)
+		}
+	}
+}
+
+func (i *IPC) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, i.network, i.addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", i.addr, err)
+	}
+
+	return conn, nil
+}
+
+func (i *IPC) Status(onStatus func(bool, error)) {
+	i.onStatus = onStatus
+}
+
+func (i *IPC) String() string {
+	return i.network + "://" + i.addr
+}
+
+func (i *IPC) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if i.clock != nil {
+		return i.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package ipc_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/konftest"
+	"github.com/nil-go/konf/provider/ipc"
+)
+
+func TestIPC(t *testing.T) {
+	t.Parallel()
+
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"port": 8080}, "password": "secret"},
+		change: make(chan map[string]any),
+	}
+	server := konf.New()
+	assert.NoError(t, server.Load(watcher))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, server.Watch(ctx))
+	}()
+
+	addr := filepath.Join(t.TempDir(), "konf.sock")
+	stopServe := serve(t, server, addr, konf.RevealSecrets())
+
+	fake := clock.NewFake(time.Time{})
+	loader := ipc.New(addr, ipc.WithClock(fake))
+	// The status is reported once the snapshot is read from the connection.
+	connected := make(chan struct{}, 1)
+	client := konf.New(konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) {
+		if err == nil {
+			select {
+			case connected <- struct{}{}:
+			default:
+			}
+		}
+	}))
+	assert.NoError(t, client.Load(loader))
+	assert.Equal(t, 8080, get[int](t, client, "server.port"))
+	assert.Equal(t, "secret", get[string](t, client, "password"))
+
+	changed := make(chan struct{})
+	client.OnChange(func(*konf.Config) { changed <- struct{}{} }, "server.port")
+	go func() {
+		assert.NoError(t, client.Watch(ctx))
+	}()
+	<-connected
+
+	watcher.change <- map[string]any{"server": map[string]any{"port": 9090}, "password": "secret"}
+	<-changed
+	assert.Equal(t, 9090, get[int](t, client, "server.port"))
+
+	// Reconnect and resync after the connection is lost.
+	stopServe()
+	watcher.change <- map[string]any{"server": map[string]any{"port": 7070}, "password": "secret"}
+	fake.BlockUntil(1)
+	serve(t, server, addr, konf.RevealSecrets())
+	fake.Advance(time.Second)
+	<-changed
+	assert.Equal(t, 7070, get[int](t, client, "server.port"))
+}
+
+func TestIPC_conformance(t *testing.T) {
+	t.Parallel()
+
+	watcher := mapWatcher{values: map[string]any{"server": map[string]any{"port": 8080}}, change: make(chan map[string]any)}
+	server := konf.New()
+	assert.NoError(t, server.Load(watcher))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, server.Watch(ctx))
+	}()
+
+	addr := filepath.Join(t.TempDir(), "konf.sock")
+	serve(t, server, addr)
+	port := 8080
+	konftest.ConformanceSuite(t,
+		func() konf.Loader { return ipc.New(addr) },
+		konftest.WithMutation(func(testing.TB) {
+			port++
+			watcher.change <- map[string]any{"server": map[string]any{"port": port}}
+		}),
+	)
+}
+
+func TestIPC_Load_error(t *testing.T) {
+	t.Parallel()
+
+	loader := ipc.New(filepath.Join(t.TempDir(), "konf.sock"))
+	_, err := loader.Load()
+	assert.True(t, err != nil)
+}
+
+func TestIPC_nil(t *testing.T) {
+	t.Parallel()
+
+	var loader *ipc.IPC
+	_, err := loader.Load()
+	assert.EqualError(t, err, "nil IPC")
+	assert.EqualError(t, loader.Watch(context.Background(), nil), "nil IPC")
+}
+
+func TestIPC_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "unix:///tmp/konf.sock", ipc.New("/tmp/konf.sock").String())
+	assert.Equal(t, "tcp://localhost:8080", ipc.New("localhost:8080", ipc.WithNetwork("tcp")).String())
+}
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := ipc.NewFromOptions("localhost:8080", ipc.Options{Network: "tcp", RetryInterval: time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, ipc.New("localhost:8080", ipc.WithNetwork("tcp"), ipc.WithRetryInterval(time.Second)), loader)
+
+	_, err = ipc.NewFromOptions("", ipc.Options{})
+	assert.EqualError(t, err, "invalid options: empty address")
+	_, err = ipc.NewFromOptions("localhost:8080", ipc.Options{Network: "udp", RetryInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: network \"udp\" is not a stream-oriented network\n"+
+		"retry interval -1s is negative")
+}
+
+func FuzzNewFromOptions(f *testing.F) {
+	f.Add("/tmp/konf.sock", "", int64(0))
+	f.Add("localhost:8080", "tcp", int64(time.Second))
+	f.Add("", "udp", int64(-1))
+
+	f.Fuzz(func(t *testing.T, addr, network string, interval int64) {
+		loader, err := ipc.NewFromOptions(addr, ipc.Options{Network: network, RetryInterval: time.Duration(interval)})
+		if err == nil {
+			assert.True(t, loader.String() != "")
+		}
+	})
+}
+
+// serve serves the config on the given unix socket address, and returns the function to stop serving.
+func serve(t *testing.T, config *konf.Config, addr string, opts ...konf.ServeOption) func() {
+	t.Helper()
+
+	listener, err := net.Listen("unix", addr)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, konf.Serve(ctx, config, listener, opts...))
+	}()
+	stop := func() {
+		cancel()
+		<-stopped
+	}
+	t.Cleanup(stop)
+
+	return stop
+}
+
+func get[T any](t *testing.T, config *konf.Config, path string) T {
+	t.Helper()
+
+	var value T
+	assert.NoError(t, config.Unmarshal(path, &value))
+
+	return value
+}
+
+type mapWatcher struct {
+	values map[string]any
+	change chan map[string]any
+}
+
+func (m mapWatcher) Load() (map[string]any, error) {
+	return m.values, nil
+}
+
+func (m mapWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	for {
+		select {
+		case values := <-m.change:
+			onChange(values)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
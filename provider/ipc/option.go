@@ -0,0 +1,74 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package ipc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+// WithNetwork provides the network of the address, e.g. tcp.
+//
+// The default network is unix.
+func WithNetwork(network string) Option {
+	return func(options *options) {
+		options.Network = network
+	}
+}
+
+// WithRetryInterval provides the interval for reconnecting after the connection is lost.
+//
+// The default interval is 1 second.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.RetryInterval = interval
+	}
+}
+
+// WithClock provides the Clock for reconnecting.
+// It's useful for tests to drive reconnecting deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock konf.Clock) Option {
+	return func(options *options) {
+		options.Clock = clock
+	}
+}
+
+type (
+	// Option configures the IPC with specific options.
+	Option  func(options *options)
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the IPC from generated configuration.
+	Options struct {
+		// Network is the same as WithNetwork.
+		Network string
+		// RetryInterval is the same as WithRetryInterval.
+		RetryInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock konf.Clock
+	}
+)
+
+func (o Options) validate() error {
+	var errs []error
+	switch o.Network {
+	case "", "unix", "unixpacket", "tcp", "tcp4", "tcp6":
+	default:
+		errs = append(errs, fmt.Errorf("network %q is not a stream-oriented network", o.Network)) //nolint:err113
+	}
+	if o.RetryInterval < 0 {
+		errs = append(errs, fmt.Errorf("retry interval %s is negative", o.RetryInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -4,6 +4,9 @@
 package parameterstore
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -19,7 +22,7 @@ import (
 // By default, the path is "/" for all parameters.
 func WithPath(path string) Option {
 	return func(options *options) {
-		options.client.path = path
+		options.Path = path
 	}
 }
 
@@ -30,7 +33,7 @@ func WithPath(path string) Option {
 // aren't supported for GetParametersByPath : tag, DataType, Name, Path, and Tier .
 func WithFilter(filters ...types.ParameterStringFilter) Option {
 	return func(options *options) {
-		options.client.filters = append(options.client.filters, filters...)
+		options.Filters = append(options.Filters, filters...)
 	}
 }
 
@@ -41,7 +44,7 @@ func WithFilter(filters ...types.ParameterStringFilter) Option {
 // would be split into "PARENT", "CHILD", and "KEY".
 func WithNameSplitter(splitter func(string) []string) Option {
 	return func(options *options) {
-		options.splitter = splitter
+		options.NameSplitter = splitter
 	}
 }
 
@@ -50,7 +53,23 @@ func WithNameSplitter(splitter func(string) []string) Option {
 // The default interval is 1 minute.
 func WithPollInterval(interval time.Duration) Option {
 	return func(options *options) {
-		options.pollInterval = interval
+		options.PollInterval = interval
+	}
+}
+
+// Clock provides the ticker which drives polling of the parameters.
+// Tests can pass a fake clock to control when the parameters are reloaded.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.Clock = clock
 	}
 }
 
@@ -59,12 +78,44 @@ func WithPollInterval(interval time.Duration) Option {
 // By default, it loads the default AWS Config.
 func WithAWSConfig(config aws.Config) Option {
 	return func(options *options) {
-		options.client.config = config
+		options.AWSConfig = config
 	}
 }
 
 type (
 	// Option configures the a ParameterStore with specific options.
 	Option  func(options *options)
-	options ParameterStore
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the ParameterStore from generated configuration.
+	Options struct {
+		// Path is the same as WithPath.
+		Path string
+		// Filters is the same as WithFilter.
+		Filters []types.ParameterStringFilter
+		// NameSplitter is the same as WithNameSplitter.
+		NameSplitter func(string) []string
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+		// AWSConfig is the same as WithAWSConfig.
+		AWSConfig aws.Config
+	}
 )
+
+func (o Options) validate() error {
+	var errs []error
+	if o.Path != "" && !strings.HasPrefix(o.Path, "/") {
+		errs = append(errs, fmt.Errorf("path %q does not start with '/'", o.Path)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -40,6 +40,7 @@ import (
 
 type ParameterStore struct {
 	pollInterval time.Duration
+	clock        Clock
 	splitter     func(string) []string
 
 	onStatus  func(bool, error)
@@ -49,18 +50,41 @@ type ParameterStore struct {
 
 // New creates a ParameterStore with the given endpoint and Option(s).
 func New(opts ...Option) *ParameterStore {
-	option := &options{
-		client:    clientProxy{},
-		changedCh: make(chan struct{}, 1),
-	}
+	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
-	if option.client.path == "" {
-		option.client.path = "/"
+
+	return newParameterStore(Options(*option))
+}
+
+// NewFromOptions creates a ParameterStore with the given Options, same as New with the corresponding Option(s).
+// It returns error if the options are invalid, e.g. the path does not start with '/'.
+func NewFromOptions(options Options) (*ParameterStore, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
 	}
 
-	return (*ParameterStore)(option)
+	return newParameterStore(options), nil
+}
+
+func newParameterStore(options Options) *ParameterStore {
+	path := options.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return &ParameterStore{
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		splitter:     options.NameSplitter,
+		changedCh:    make(chan struct{}, 1),
+		client: clientProxy{
+			path:    path,
+			filters: options.Filters,
+			config:  options.AWSConfig,
+		},
+	}
 }
 
 var errNil = errors.New("nil ParameterStore")
@@ -87,12 +111,12 @@ func (p *ParameterStore) Watch(ctx context.Context, onChange func(map[string]any
 	if p.pollInterval > 0 {
 		pollInterval = p.pollInterval
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := p.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticks:
 			p.changed()
 		case <-p.changedCh:
 			values, changed, err := p.load(ctx)
@@ -181,6 +205,15 @@ func (p *ParameterStore) String() string {
 	return "parameter-store:" + p.client.path
 }
 
+func (p *ParameterStore) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if p.clock != nil {
+		return p.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	path    string
 	filters []types.ParameterStringFilter
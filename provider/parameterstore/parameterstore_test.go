@@ -387,3 +387,15 @@ func TestS3_String(t *testing.T) {
 	loader = parameterstore.New(parameterstore.WithPath("/path"))
 	assert.Equal(t, "parameter-store:/path", loader.String())
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := parameterstore.NewFromOptions(parameterstore.Options{Path: "/path", PollInterval: time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, "parameter-store:/path", loader.String())
+
+	_, err = parameterstore.NewFromOptions(parameterstore.Options{Path: "path", PollInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: path \"path\" does not start with '/'\n"+
+		"poll interval -1s is negative")
+}
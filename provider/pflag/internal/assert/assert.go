@@ -23,3 +23,14 @@ func NoError(tb testing.TB, err error) {
 		tb.Errorf("unexpected error: %v", err)
 	}
 }
+
+func EqualError(tb testing.TB, err error, message string) {
+	tb.Helper()
+
+	switch {
+	case err == nil:
+		tb.Errorf("\n  actual: <nil>\nexpected: %v", message)
+	case err.Error() != message:
+		tb.Errorf("\n  actual: %v\nexpected: %v", err.Error(), message)
+	}
+}
@@ -4,6 +4,9 @@
 package pflag
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/pflag"
 )
 
@@ -14,7 +17,7 @@ import (
 // By default, it has no prefix which loads all flags.
 func WithPrefix(prefix string) Option {
 	return func(options *options) {
-		options.prefix = prefix
+		options.Prefix = prefix
 	}
 }
 
@@ -23,7 +26,7 @@ func WithPrefix(prefix string) Option {
 // The default flag set is [pflag.CommandLine] plus [flag.CommandLine].
 func WithFlagSet(set *pflag.FlagSet) Option {
 	return func(options *options) {
-		options.set = set
+		options.FlagSet = set
 	}
 }
 
@@ -34,12 +37,32 @@ func WithFlagSet(set *pflag.FlagSet) Option {
 // would be split into "parent", "child", and "key".
 func WithNameSplitter(splitter func(string) []string) Option {
 	return func(options *options) {
-		options.splitter = splitter
+		options.NameSplitter = splitter
 	}
 }
 
 type (
 	// Option configures the a PFlag with specific options.
 	Option  func(*options)
-	options PFlag
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the PFlag from generated configuration.
+	Options struct {
+		// Prefix is the same as WithPrefix.
+		Prefix string
+		// FlagSet is the same as WithFlagSet.
+		FlagSet *pflag.FlagSet
+		// NameSplitter is the same as WithNameSplitter.
+		NameSplitter func(string) []string
+	}
 )
+
+func (o Options) validate() error {
+	// The flag name never starts with '-' or contains '='.
+	if strings.HasPrefix(o.Prefix, "-") || strings.Contains(o.Prefix, "=") {
+		return fmt.Errorf("invalid options: prefix %q never matches any flag name", o.Prefix) //nolint:err113
+	}
+
+	return nil
+}
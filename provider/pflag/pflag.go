@@ -38,14 +38,27 @@ type PFlag struct {
 // have been set by other providers. If not, default flag values are merged.
 // If they exist, flag values are merged only if explicitly set in the command line.
 func New(konf konf, opts ...Option) PFlag {
-	option := &options{
-		konf: konf,
-	}
+	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
 
-	return PFlag(*option)
+	return newPFlag(konf, Options(*option))
+}
+
+// NewFromOptions creates a PFlag with the given konf Config instance and Options,
+// same as New with the corresponding Option(s).
+// It returns error if the options are invalid, e.g. the prefix never matches any flag name.
+func NewFromOptions(konf konf, options Options) (PFlag, error) {
+	if err := options.validate(); err != nil {
+		return PFlag{}, err
+	}
+
+	return newPFlag(konf, options), nil
+}
+
+func newPFlag(konf konf, options Options) PFlag {
+	return PFlag{konf: konf, prefix: options.Prefix, set: options.FlagSet, splitter: options.NameSplitter}
 }
 
 func (f PFlag) Load() (map[string]any, error) { //nolint:cyclop
@@ -154,6 +154,24 @@ func TestPFlag_String(t *testing.T) {
 	}
 }
 
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := kflag.NewFromOptions(&konfStub{}, kflag.Options{
+		Prefix:       "k",
+		FlagSet:      set,
+		NameSplitter: func(s string) []string { return []string{s} },
+	})
+	assert.NoError(t, err)
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+	assert.Equal(t, "pflag:k*", loader.String())
+
+	_, err = kflag.NewFromOptions(nil, kflag.Options{Prefix: "k="})
+	assert.EqualError(t, err, `invalid options: prefix "k=" never matches any flag name`)
+}
+
 var set = &pflag.FlagSet{}
 
 func init() {
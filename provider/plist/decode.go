@@ -0,0 +1,447 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Unmarshal parses the property list in either XML or binary format,
+// and stores the result in the value pointed to by v, which must be *map[string]any or *any.
+//
+// The native types are mapped as following:
+//   - dict: map[string]any
+//   - array: []any
+//   - string: string
+//   - integer: int64, or uint64 if it overflows int64
+//   - real: float64
+//   - true/false: bool
+//   - date: time.Time
+//   - data: []byte
+//
+// The binary format is rejected if it has too many objects, or expands to too many objects
+// via the shared references, so that the crafted file can not exhaust memory.
+//
+// It can be used as the unmarshal function of other providers, e.g. fs.WithUnmarshal(plist.Unmarshal).
+func Unmarshal(data []byte, v any) error {
+	var (
+		value any
+		err   error
+	)
+	if bytes.HasPrefix(data, []byte(binaryMagic)) {
+		value, err = decodeBinary(data)
+	} else {
+		value, err = decodeXML(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch target := v.(type) {
+	case *map[string]any:
+		dict, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: root is %T, not dict", errInvalid, value)
+		}
+		*target = dict
+	case *any:
+		*target = value
+	default:
+		return fmt.Errorf("%w: %T", errTarget, v)
+	}
+
+	return nil
+}
+
+func decodeXML(data []byte) (any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: no plist element: %w", errInvalid, err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "plist" {
+			break
+		}
+	}
+
+	start, err := nextStart(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeXMLValue(decoder, start)
+}
+
+func decodeXMLValue(decoder *xml.Decoder, start xml.StartElement) (any, error) { //nolint:cyclop,funlen
+	switch start.Name.Local {
+	case "dict":
+		dict := make(map[string]any)
+		for {
+			keyStart, err := nextStart(decoder)
+			if errors.Is(err, errEnd) {
+				return dict, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if keyStart.Name.Local != "key" {
+				return nil, fmt.Errorf("%w: expect key in dict, got %s", errInvalid, keyStart.Name.Local)
+			}
+			var key string
+			if err := decoder.DecodeElement(&key, &keyStart); err != nil {
+				return nil, fmt.Errorf("%w: %w", errInvalid, err)
+			}
+			valueStart, err := nextStart(decoder)
+			if err != nil {
+				return nil, fmt.Errorf("%w: no value for key %s", errInvalid, key)
+			}
+			value, err := decodeXMLValue(decoder, valueStart)
+			if err != nil {
+				return nil, err
+			}
+			dict[key] = value
+		}
+	case "array":
+		array := []any{}
+		for {
+			elemStart, err := nextStart(decoder)
+			if errors.Is(err, errEnd) {
+				return array, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeXMLValue(decoder, elemStart)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+
+		return start.Name.Local == "true", nil
+	}
+
+	var text string
+	if err := decoder.DecodeElement(&text, &start); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalid, err)
+	}
+	switch start.Name.Local {
+	case "string":
+		return text, nil
+	case "integer":
+		return parseInteger(strings.TrimSpace(text))
+	case "real":
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+
+		return value, nil
+	case "date":
+		value, err := time.Parse(time.RFC3339, strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+
+		return value, nil
+	case "data":
+		value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+
+		return value, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown element %s", errInvalid, start.Name.Local)
+	}
+}
+
+func parseInteger(text string) (any, error) {
+	if value, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return value, nil
+	}
+	value, err := strconv.ParseUint(text, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalid, err)
+	}
+
+	return value, nil
+}
+
+// nextStart returns the next start element, or errEnd if it reaches the end element first.
+func nextStart(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return xml.StartElement{}, fmt.Errorf("%w: %w", errInvalid, err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			return token, nil
+		case xml.EndElement:
+			return xml.StartElement{}, errEnd
+		}
+	}
+}
+
+const (
+	binaryMagic   = "bplist00"
+	binaryTrailer = 32
+	maxDepth      = 512
+	// The limits of the binary plist are far beyond the configuration files,
+	// which reject the crafted file exhausting memory.
+	maxObjects  = 1 << 20
+	maxExpanded = 1 << 22 // The objects decoded, and the shared object is counted for each reference.
+)
+
+// The reference date of binary plist dates.
+var binaryEpoch = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC) //nolint:gochecknoglobals
+
+type binaryDecoder struct {
+	data     []byte
+	offsets  []uint64
+	refSize  int
+	expanded int
+}
+
+func decodeBinary(data []byte) (any, error) {
+	if len(data) < len(binaryMagic)+binaryTrailer {
+		return nil, fmt.Errorf("%w: binary plist is too short", errInvalid)
+	}
+	trailer := data[len(data)-binaryTrailer:]
+	offsetSize, refSize := int(trailer[6]), int(trailer[7])
+	numObjects := binary.BigEndian.Uint64(trailer[8:16])
+	topObject := binary.BigEndian.Uint64(trailer[16:24])
+	offsetTable := binary.BigEndian.Uint64(trailer[24:32])
+	if offsetSize == 0 || offsetSize > 8 || refSize == 0 || refSize > 8 ||
+		topObject >= numObjects || offsetTable > uint64(len(data)-binaryTrailer) ||
+		numObjects > (uint64(len(data)-binaryTrailer)-offsetTable)/uint64(offsetSize) {
+		return nil, fmt.Errorf("%w: invalid binary plist trailer", errInvalid)
+	}
+	if numObjects > maxObjects {
+		return nil, fmt.Errorf("%w: too many objects %d, exceeds %d", errInvalid, numObjects, maxObjects)
+	}
+
+	decoder := &binaryDecoder{data: data, offsets: make([]uint64, numObjects), refSize: refSize}
+	for i := range decoder.offsets {
+		pos := offsetTable + uint64(i*offsetSize)
+		decoder.offsets[i] = readUint(data[pos : pos+uint64(offsetSize)])
+	}
+
+	return decoder.object(topObject, 0)
+}
+
+func (d *binaryDecoder) object(ref uint64, depth int) (any, error) { //nolint:cyclop,funlen,gocognit
+	if ref >= uint64(len(d.offsets)) {
+		return nil, fmt.Errorf("%w: object reference %d out of range", errInvalid, ref)
+	}
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: too deep nesting", errInvalid)
+	}
+	if d.expanded++; d.expanded > maxExpanded {
+		return nil, fmt.Errorf("%w: too many objects expanded from shared references, exceeds %d", errInvalid, maxExpanded)
+	}
+	pos := d.offsets[ref]
+	if pos >= uint64(len(d.data)) {
+		return nil, fmt.Errorf("%w: object offset %d out of range", errInvalid, pos)
+	}
+	marker := d.data[pos]
+	typ, info := marker>>4, marker&0x0F //nolint:mnd
+
+	switch typ {
+	case 0x0:
+		switch info {
+		case 0x0:
+			return nil, nil //nolint:nilnil
+		case 0x8:
+			return false, nil
+		case 0x9:
+			return true, nil
+		}
+	case 0x1:
+		buf, err := d.bytes(pos+1, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+		switch len(buf) {
+		case 1, 2, 4, 8: //nolint:mnd
+			return int64(readUint(buf)), nil //nolint:gosec // 8 bytes integer is signed.
+		case 16: //nolint:mnd
+			return readUint(buf[8:]), nil
+		}
+	case 0x2:
+		buf, err := d.bytes(pos+1, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+		switch len(buf) {
+		case 4: //nolint:mnd
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(buf))), nil
+		case 8: //nolint:mnd
+			return math.Float64frombits(binary.BigEndian.Uint64(buf)), nil
+		}
+	case 0x3:
+		buf, err := d.bytes(pos+1, 8) //nolint:mnd
+		if err != nil {
+			return nil, err
+		}
+		seconds := math.Float64frombits(binary.BigEndian.Uint64(buf))
+
+		return binaryEpoch.Add(time.Duration(seconds * float64(time.Second))), nil
+	case 0x4, 0x5:
+		length, start, err := d.length(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := d.bytes(start, length)
+		if err != nil {
+			return nil, err
+		}
+		if typ == 0x4 {
+			return bytes.Clone(buf), nil
+		}
+
+		return string(buf), nil
+	case 0x6:
+		length, start, err := d.length(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := d.bytes(start, length*2) //nolint:mnd
+		if err != nil {
+			return nil, err
+		}
+		units := make([]uint16, length)
+		for i := range units {
+			units[i] = binary.BigEndian.Uint16(buf[i*2:])
+		}
+
+		return string(utf16.Decode(units)), nil
+	case 0x8:
+		buf, err := d.bytes(pos+1, int(info)+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return readUint(buf), nil
+	case 0xA:
+		length, start, err := d.length(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := d.bytes(start, length*d.refSize)
+		if err != nil {
+			return nil, err
+		}
+		array := make([]any, 0, length)
+		for i := range length {
+			value, err := d.object(readUint(refs[i*d.refSize:(i+1)*d.refSize]), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+
+		return array, nil
+	case 0xD:
+		length, start, err := d.length(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := d.bytes(start, 2*length*d.refSize) //nolint:mnd
+		if err != nil {
+			return nil, err
+		}
+		dict := make(map[string]any, length)
+		for i := range length {
+			key, err := d.object(readUint(refs[i*d.refSize:(i+1)*d.refSize]), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: dict key is %T, not string", errInvalid, key)
+			}
+			valueRef := refs[(length+i)*d.refSize : (length+i+1)*d.refSize]
+			value, err := d.object(readUint(valueRef), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			dict[keyString] = value
+		}
+
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown object marker 0x%02x", errInvalid, marker)
+}
+
+// length returns the length of the object at pos and the start position of its content.
+func (d *binaryDecoder) length(pos uint64, info byte) (int, uint64, error) {
+	if info != 0x0F { //nolint:mnd
+		return int(info), pos + 1, nil
+	}
+
+	buf, err := d.bytes(pos+1, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if buf[0]>>4 != 0x1 {
+		return 0, 0, fmt.Errorf("%w: invalid length marker 0x%02x", errInvalid, buf[0])
+	}
+	size := 1 << (buf[0] & 0x0F)    //nolint:mnd
+	buf, err = d.bytes(pos+2, size) //nolint:mnd
+	if err != nil {
+		return 0, 0, err
+	}
+	length := readUint(buf)
+	if length > uint64(len(d.data)) {
+		return 0, 0, fmt.Errorf("%w: length %d out of range", errInvalid, length)
+	}
+
+	return int(length), pos + 2 + uint64(size), nil //nolint:mnd
+}
+
+func (d *binaryDecoder) bytes(pos uint64, length int) ([]byte, error) {
+	if length < 0 || pos > uint64(len(d.data)) || uint64(length) > uint64(len(d.data))-pos {
+		return nil, fmt.Errorf("%w: object out of range", errInvalid)
+	}
+
+	return d.data[pos : pos+uint64(length)], nil
+}
+
+func readUint(buf []byte) uint64 {
+	var value uint64
+	for _, b := range buf {
+		value = value<<8 | uint64(b) //nolint:mnd
+	}
+
+	return value
+}
+
+var (
+	errInvalid = errors.New("invalid plist")
+	errTarget  = errors.New("unsupported target type, must be *map[string]any or *any")
+	errEnd     = errors.New("end of element")
+)
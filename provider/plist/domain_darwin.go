@@ -0,0 +1,19 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package plist
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+func exportDomain(domain string) ([]byte, error) {
+	// It exports the preferences as XML property list to stdout.
+	bytes, err := exec.Command("defaults", "export", domain, "-").Output()
+	if err != nil {
+		return nil, fmt.Errorf("export defaults domain %s: %w", domain, err)
+	}
+
+	return bytes, nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+//go:build !darwin
+
+package plist
+
+import (
+	"errors"
+	"fmt"
+)
+
+func exportDomain(domain string) ([]byte, error) {
+	return nil, fmt.Errorf("export defaults domain %s: %w", domain, errUnsupported)
+}
+
+var errUnsupported = errors.New("defaults domain is only supported on macOS")
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package plist
+
+import (
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+// WithPollInterval provides the interval for polling the configuration.
+//
+// The default interval is 1 minute.
+func WithPollInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.pollInterval = interval
+	}
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock konf.Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+type (
+	// Option configures the Plist with specific options.
+	Option  func(options *options)
+	options Plist
+)
@@ -0,0 +1,149 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package plist loads configuration from macOS property list.
+//
+// Plist loads a property list file in either XML or binary format with the given path,
+// or the preferences of the `defaults` domain (macOS only), and returns a nested map[string]any.
+// See [Unmarshal] for how the native types are mapped.
+//
+// # Change notification
+//
+// It periodically polls the configuration, and notifies the change
+// only if the parsed configuration is different from the last one.
+package plist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+// Plist is a Provider that loads configuration from property list.
+//
+// To create a new Plist, call [New] or [NewDomain].
+type Plist struct {
+	path         string
+	domain       string
+	pollInterval time.Duration
+	clock        konf.Clock
+
+	onStatus func(bool, error)
+	last     atomic.Pointer[map[string]any]
+}
+
+// New creates a Plist with the given path of the property list file and Option(s).
+func New(path string, opts ...Option) *Plist {
+	option := &options{path: path}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Plist)(option)
+}
+
+// NewDomain creates a Plist with the given `defaults` domain (e.g. com.example.app) and Option(s),
+// which reads the preferences via `defaults export`.
+//
+// It's only supported on macOS, and returns error when loading on other platforms.
+func NewDomain(domain string, opts ...Option) *Plist {
+	option := &options{domain: domain}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Plist)(option)
+}
+
+var errNil = errors.New("nil Plist")
+
+func (p *Plist) Load() (map[string]any, error) {
+	if p == nil {
+		return nil, errNil
+	}
+
+	values, _, err := p.load()
+
+	return values, err
+}
+
+func (p *Plist) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if p == nil {
+		return errNil
+	}
+
+	pollInterval := time.Minute
+	if p.pollInterval > 0 {
+		pollInterval = p.pollInterval
+	}
+	ticks, stop := p.newTicker(pollInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+			values, changed, err := p.load()
+			if p.onStatus != nil {
+				p.onStatus(changed, err)
+			}
+			if changed {
+				onChange(values)
+			}
+		}
+	}
+}
+
+func (p *Plist) load() (map[string]any, bool, error) {
+	var (
+		bytes []byte
+		err   error
+	)
+	if p.domain != "" {
+		bytes, err = exportDomain(p.domain)
+	} else {
+		bytes, err = os.ReadFile(p.path)
+		if err != nil {
+			err = fmt.Errorf("read file: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var values map[string]any
+	if e := Unmarshal(bytes, &values); e != nil {
+		return nil, false, fmt.Errorf("unmarshal: %w", e)
+	}
+	last := p.last.Swap(&values)
+
+	return values, last == nil || !reflect.DeepEqual(*last, values), nil
+}
+
+func (p *Plist) Status(onStatus func(bool, error)) {
+	p.onStatus = onStatus
+}
+
+func (p *Plist) String() string {
+	if p.domain != "" {
+		return "defaults://" + p.domain
+	}
+
+	return "plist://" + p.path
+}
+
+func (p *Plist) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if p.clock != nil {
+		return p.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package plist_test
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/provider/plist"
+)
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]any{
+		"server": map[string]any{
+			"host":  "example.com",
+			"port":  int64(8080),
+			"tls":   true,
+			"ratio": 0.5,
+		},
+		"tags":    []any{"a", "b"},
+		"created": time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		"token":   []byte("hello"),
+		"name":    "héllo",
+		"debug":   false,
+	}
+
+	testcases := []struct {
+		description string
+		path        string
+	}{
+		{
+			description: "xml",
+			path:        "testdata/config.plist",
+		},
+		{
+			description: "binary",
+			path:        "testdata/config.binary.plist",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(testcase.path)
+			assert.NoError(t, err)
+			var values map[string]any
+			assert.NoError(t, plist.Unmarshal(data, &values))
+			assert.Equal(t, expected, values)
+		})
+	}
+}
+
+func TestUnmarshal_error(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		data        string
+		target      func() any
+		err         string
+	}{
+		{
+			description: "not plist",
+			data:        `<dict></dict>`,
+			err:         "invalid plist: no plist element: EOF",
+		},
+		{
+			description: "root not dict",
+			data:        `<plist><array><string>a</string></array></plist>`,
+			err:         "invalid plist: root is []interface {}, not dict",
+		},
+		{
+			description: "invalid integer",
+			data:        `<plist><dict><key>a</key><integer>x</integer></dict></plist>`,
+			err:         `invalid plist: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			description: "missing key",
+			data:        `<plist><dict><string>a</string></dict></plist>`,
+			err:         "invalid plist: expect key in dict, got string",
+		},
+		{
+			description: "unknown element",
+			data:        `<plist><dict><key>a</key><unknown/></dict></plist>`,
+			err:         "invalid plist: unknown element unknown",
+		},
+		{
+			description: "truncated binary",
+			data:        "bplist00",
+			err:         "invalid plist: binary plist is too short",
+		},
+		{
+			description: "too many binary objects",
+			data:        binaryPlist(1<<20+1, []byte{0x08}),
+			err:         "invalid plist: too many objects 1048577, exceeds 1048576",
+		},
+		{
+			description: "expanded shared references",
+			data:        binaryPlist(0, sharedArrays(40)...),
+			err:         "invalid plist: too many objects expanded from shared references, exceeds 4194304",
+		},
+		{
+			description: "unsupported target",
+			data:        `<plist><dict/></plist>`,
+			target:      func() any { return &[]any{} },
+			err:         "unsupported target type, must be *map[string]any or *any: *[]interface {}",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var target any = &map[string]any{}
+			if testcase.target != nil {
+				target = testcase.target()
+			}
+			assert.EqualError(t, plist.Unmarshal([]byte(testcase.data), target), testcase.err)
+		})
+	}
+}
+
+// binaryPlist builds the binary plist with the given objects, and the first object is the top object.
+// The offset table is padded to numObjects entries with the offset of the first object.
+func binaryPlist(numObjects int, objects ...[]byte) string {
+	data := []byte("bplist00")
+	var offsets []byte
+	for _, object := range objects {
+		offsets = append(offsets, byte(len(data)))
+		data = append(data, object...)
+	}
+	for len(offsets) < numObjects {
+		offsets = append(offsets, offsets[0])
+	}
+	trailer := make([]byte, 32)
+	trailer[6], trailer[7] = 1, 1 // The sizes of offset and reference.
+	binary.BigEndian.PutUint64(trailer[8:16], uint64(len(offsets)))
+	binary.BigEndian.PutUint64(trailer[24:32], uint64(len(data)))
+
+	return string(slices.Concat(data, offsets, trailer))
+}
+
+// sharedArrays returns the objects of the arrays, each of which refers to the next one twice.
+func sharedArrays(count int) [][]byte {
+	objects := make([][]byte, 0, count+1)
+	for i := range count {
+		objects = append(objects, []byte{0xA2, byte(i + 1), byte(i + 1)})
+	}
+
+	return append(objects, []byte{0x51, 'a'})
+}
+
+func TestPlist_Load(t *testing.T) {
+	t.Parallel()
+
+	loader := plist.New("testdata/config.plist")
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", values["server"].(map[string]any)["host"])
+	assert.Equal(t, "plist://testdata/config.plist", loader.String())
+
+	_, err = plist.New("testdata/not_found.plist").Load()
+	assert.EqualError(t, err, "read file: open testdata/not_found.plist: no such file or directory")
+}
+
+func TestPlist_nil(t *testing.T) {
+	t.Parallel()
+
+	var loader *plist.Plist
+	_, err := loader.Load()
+	assert.EqualError(t, err, "nil Plist")
+	assert.EqualError(t, loader.Watch(context.Background(), nil), "nil Plist")
+}
+
+func TestNewDomain(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "darwin" {
+		t.Skip("defaults domain is supported on macOS")
+	}
+
+	loader := plist.NewDomain("com.example.app")
+	assert.Equal(t, "defaults://com.example.app", loader.String())
+	_, err := loader.Load()
+	assert.EqualError(t, err, "export defaults domain com.example.app: defaults domain is only supported on macOS")
+}
+
+func TestPlist_Watch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.plist")
+	write := func(value string) {
+		assert.NoError(t, os.WriteFile(path,
+			[]byte(`<plist><dict><key>key</key><string>`+value+`</string></dict></plist>`), 0o600))
+	}
+	write("old")
+
+	fake := clock.NewFake(time.Time{})
+	loader := plist.New(path, plist.WithPollInterval(time.Second), plist.WithClock(fake))
+	statuses := make(chan bool, 1)
+	loader.Status(func(changed bool, err error) {
+		assert.NoError(t, err)
+		statuses <- changed
+	})
+	_, err := loader.Load()
+	assert.NoError(t, err)
+
+	values := make(chan map[string]any, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+	}()
+	fake.BlockUntil(1)
+
+	fake.Advance(time.Second)
+	assert.True(t, !<-statuses)
+
+	write("new")
+	fake.Advance(time.Second)
+	assert.True(t, <-statuses)
+	assert.Equal(t, map[string]any{"key": "new"}, <-values)
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package reader
+
+// WithName provides the name of the Reader shown in its string representation, e.g. `reader://template`,
+// which is used by Config.Explain and logs.
+//
+// By default, it's the name of the io.Reader if it has Name method, e.g. os.File, otherwise empty.
+func WithName(name string) Option {
+	return func(options *options) {
+		options.name = name
+	}
+}
+
+type (
+	// Option configures the a Reader with specific options.
+	Option  func(options *options)
+	options Reader
+)
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package reader loads configuration from io.Reader.
+//
+// Reader reads the whole stream of the given io.Reader once and returns
+// a nested map[string]any that is parsed with the given unmarshal function,
+// e.g. for the configuration generated in memory from a template or decrypted from a secret.
+//
+// The unmarshal function must be able to unmarshal the content into a map[string]any.
+// For example, with the default json.Unmarshal, the content is parsed as JSON.
+package reader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Reader is a Provider that loads configuration from io.Reader.
+//
+// To create a new Reader, call [New].
+type Reader struct {
+	reader    io.Reader
+	unmarshal func([]byte, any) error
+	name      string
+
+	once  sync.Once
+	bytes []byte
+	err   error
+}
+
+// New creates a Reader with the given io.Reader, unmarshal function and Option(s).
+// If unmarshal is nil, the content is parsed with json.Unmarshal.
+func New(reader io.Reader, unmarshal func([]byte, any) error, opts ...Option) *Reader {
+	option := &options{
+		reader:    reader,
+		unmarshal: unmarshal,
+	}
+	if named, ok := reader.(interface{ Name() string }); ok {
+		option.name = named.Name() // e.g. os.File.
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.unmarshal == nil {
+		option.unmarshal = json.Unmarshal
+	}
+
+	return (*Reader)(option)
+}
+
+var errNil = errors.New("nil Reader")
+
+// Load reads the whole stream at the first call, and parses the content.
+// Since io.Reader can only be read once, the subsequent calls parse the same content again,
+// or return the same error if reading fails.
+func (r *Reader) Load() (map[string]any, error) {
+	if r == nil || r.reader == nil {
+		return nil, errNil
+	}
+
+	r.once.Do(func() {
+		r.bytes, r.err = io.ReadAll(r.reader)
+	})
+	if r.err != nil {
+		return nil, fmt.Errorf("read: %w", r.err)
+	}
+
+	var out map[string]any
+	if err := r.unmarshal(r.bytes, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *Reader) String() string {
+	return "reader://" + r.name
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package reader_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/konftest"
+	"github.com/nil-go/konf/provider/reader"
+)
+
+func TestReader_empty(t *testing.T) {
+	var loader *reader.Reader
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil Reader")
+	assert.Equal(t, nil, values)
+	_, err = reader.New(nil, nil).Load()
+	assert.EqualError(t, err, "nil Reader")
+}
+
+func TestReader_Load(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		reader      func() *reader.Reader
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "default unmarshal",
+			reader: func() *reader.Reader {
+				return reader.New(strings.NewReader(`{"p":{"k":"v"}}`), nil)
+			},
+			expected: map[string]any{"p": map[string]any{"k": "v"}},
+		},
+		{
+			description: "custom unmarshal",
+			reader: func() *reader.Reader {
+				return reader.New(strings.NewReader("k=v"), func(bytes []byte, target any) error {
+					key, value, _ := strings.Cut(string(bytes), "=")
+					*target.(*map[string]any) = map[string]any{key: value}
+
+					return nil
+				})
+			},
+			expected: map[string]any{"k": "v"},
+		},
+		{
+			description: "unmarshal error",
+			reader: func() *reader.Reader {
+				return reader.New(strings.NewReader(`{}`), func([]byte, any) error {
+					return errors.New("unmarshal error")
+				})
+			},
+			err: "unmarshal: unmarshal error",
+		},
+		{
+			description: "read error",
+			reader: func() *reader.Reader {
+				return reader.New(iotest.ErrReader(errors.New("read error")), nil)
+			},
+			err: "read: read error",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			loader := testcase.reader()
+			// Load twice to verify the content is cached since the reader can only be read once.
+			for range 2 {
+				values, err := loader.Load()
+				if testcase.err != "" {
+					assert.EqualError(t, err, testcase.err)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, testcase.expected, values)
+				}
+			}
+		})
+	}
+}
+
+func TestReader_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "reader://", reader.New(strings.NewReader(""), nil).String())
+	assert.Equal(t, "reader://template", reader.New(strings.NewReader(""), nil, reader.WithName("template")).String())
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{}`), 0o600))
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	assert.Equal(t, "reader://"+path, reader.New(file, nil).String())
+}
+
+func TestReader_conformance(t *testing.T) {
+	t.Parallel()
+
+	konftest.ConformanceSuite(t, func() konf.Loader {
+		return reader.New(strings.NewReader(`{"server":{"port":8080,"hosts":["a",{"name":"b"}]}}`), nil)
+	})
+}
@@ -0,0 +1,5 @@
+module github.com/nil-go/konf/provider/registry
+
+go 1.22
+
+require golang.org/x/sys v0.28.0
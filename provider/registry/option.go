@@ -0,0 +1,36 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package registry
+
+import "time"
+
+// WithPollInterval provides the interval for polling the configuration.
+//
+// The default interval is 1 minute.
+func WithPollInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.pollInterval = interval
+	}
+}
+
+// Clock provides the ticker which drives polling of the service registry.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+type (
+	// Option configures the Registry with specific options.
+	Option  func(options *options)
+	options Registry
+)
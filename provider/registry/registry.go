@@ -0,0 +1,122 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package registry loads configuration from Windows registry.
+//
+// Registry reads the values of the key with the given path and its subkeys recursively,
+// and returns a nested map[string]any where subkeys are nested maps and values are leaves.
+// The path starts with the root key, either in full name or abbreviation,
+// e.g. `HKEY_CURRENT_USER\Software\Example` or `HKCU\Software\Example`.
+// The default value of a key (with empty name) is ignored.
+//
+// The native types are mapped as following:
+//   - REG_SZ: string
+//   - REG_EXPAND_SZ: string with environment variables expanded
+//   - REG_MULTI_SZ: []any of strings
+//   - REG_DWORD, REG_QWORD: uint64
+//   - REG_BINARY: []byte
+//
+// It's only supported on Windows, and returns error when loading on other platforms.
+//
+// # Change notification
+//
+// It periodically polls the configuration, and notifies the change
+// only if the read configuration is different from the last one.
+package registry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Registry is a Provider that loads configuration from Windows registry.
+//
+// To create a new Registry, call [New].
+type Registry struct {
+	path         string
+	pollInterval time.Duration
+	clock        Clock
+
+	onStatus func(bool, error)
+	last     atomic.Pointer[map[string]any]
+}
+
+// New creates a Registry with the given path of registry key and Option(s).
+func New(path string, opts ...Option) *Registry {
+	option := &options{path: path}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Registry)(option)
+}
+
+var errNil = errors.New("nil Registry")
+
+func (r *Registry) Load() (map[string]any, error) {
+	if r == nil {
+		return nil, errNil
+	}
+
+	values, _, err := r.load()
+
+	return values, err
+}
+
+func (r *Registry) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if r == nil {
+		return errNil
+	}
+
+	pollInterval := time.Minute
+	if r.pollInterval > 0 {
+		pollInterval = r.pollInterval
+	}
+	ticks, stop := r.newTicker(pollInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+			values, changed, err := r.load()
+			if r.onStatus != nil {
+				r.onStatus(changed, err)
+			}
+			if changed {
+				onChange(values)
+			}
+		}
+	}
+}
+
+func (r *Registry) load() (map[string]any, bool, error) {
+	values, err := loadKey(r.path)
+	if err != nil {
+		return nil, false, err
+	}
+	last := r.last.Swap(&values)
+
+	return values, last == nil || !reflect.DeepEqual(*last, values), nil
+}
+
+func (r *Registry) Status(onStatus func(bool, error)) {
+	r.onStatus = onStatus
+}
+
+func (r *Registry) String() string {
+	return "registry://" + r.path
+}
+
+func (r *Registry) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if r.clock != nil {
+		return r.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+//go:build !windows
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+)
+
+func loadKey(path string) (map[string]any, error) {
+	return nil, fmt.Errorf("open key %s: %w", path, errUnsupported)
+}
+
+var errUnsupported = errors.New("registry is only supported on Windows")
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package registry_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/nil-go/konf/provider/registry"
+	"github.com/nil-go/konf/provider/registry/internal/assert"
+)
+
+func TestRegistry_nil(t *testing.T) {
+	t.Parallel()
+
+	var loader *registry.Registry
+	_, err := loader.Load()
+	assert.EqualError(t, err, "nil Registry")
+	assert.EqualError(t, loader.Watch(context.Background(), nil), "nil Registry")
+}
+
+func TestRegistry_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `registry://HKCU\Software\Example`, registry.New(`HKCU\Software\Example`).String())
+}
+
+func TestRegistry_unsupported(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("registry is supported on Windows")
+	}
+
+	_, err := registry.New(`HKCU\Software\Example`).Load()
+	assert.EqualError(t, err, `open key HKCU\Software\Example: registry is only supported on Windows`)
+}
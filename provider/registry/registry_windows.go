@@ -0,0 +1,128 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+//nolint:gochecknoglobals
+var roots = map[string]registry.Key{
+	"HKEY_CLASSES_ROOT":   registry.CLASSES_ROOT,
+	"HKCR":                registry.CLASSES_ROOT,
+	"HKEY_CURRENT_USER":   registry.CURRENT_USER,
+	"HKCU":                registry.CURRENT_USER,
+	"HKEY_LOCAL_MACHINE":  registry.LOCAL_MACHINE,
+	"HKLM":                registry.LOCAL_MACHINE,
+	"HKEY_USERS":          registry.USERS,
+	"HKU":                 registry.USERS,
+	"HKEY_CURRENT_CONFIG": registry.CURRENT_CONFIG,
+	"HKCC":                registry.CURRENT_CONFIG,
+}
+
+func loadKey(path string) (map[string]any, error) {
+	rootName, subPath, _ := strings.Cut(path, `\`)
+	root, ok := roots[strings.ToUpper(rootName)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errRoot, rootName)
+	}
+
+	key, err := registry.OpenKey(root, subPath, registry.READ)
+	if err != nil {
+		return nil, fmt.Errorf("open key %s: %w", path, err)
+	}
+	defer func() {
+		_ = key.Close()
+	}()
+
+	return readKey(key, path)
+}
+
+func readKey(key registry.Key, path string) (map[string]any, error) {
+	names, err := key.ReadValueNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("read value names of %s: %w", path, err)
+	}
+	values := make(map[string]any, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue // Ignore the default value.
+		}
+		value, err := readValue(key, name)
+		if err != nil {
+			return nil, fmt.Errorf("read value %s of %s: %w", name, path, err)
+		}
+		values[name] = value
+	}
+
+	subKeys, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("read subkey names of %s: %w", path, err)
+	}
+	for _, name := range subKeys {
+		subKey, err := registry.OpenKey(key, name, registry.READ)
+		if err != nil {
+			return nil, fmt.Errorf("open key %s: %w", path+`\`+name, err)
+		}
+		value, err := readKey(subKey, path+`\`+name)
+		_ = subKey.Close()
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+func readValue(key registry.Key, name string) (any, error) {
+	_, typ, err := key.GetValue(name, nil)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	switch typ {
+	case registry.SZ:
+		value, _, err := key.GetStringValue(name)
+
+		return value, err //nolint:wrapcheck
+	case registry.EXPAND_SZ:
+		value, _, err := key.GetStringValue(name)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return registry.ExpandString(value) //nolint:wrapcheck
+	case registry.MULTI_SZ:
+		value, _, err := key.GetStringsValue(name)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		values := make([]any, 0, len(value))
+		for _, v := range value {
+			values = append(values, v)
+		}
+
+		return values, nil
+	case registry.DWORD, registry.QWORD:
+		value, _, err := key.GetIntegerValue(name)
+
+		return value, err //nolint:wrapcheck
+	case registry.BINARY:
+		value, _, err := key.GetBinaryValue(name)
+
+		return value, err //nolint:wrapcheck
+	default:
+		return nil, fmt.Errorf("%w: %d", errType, typ)
+	}
+}
+
+var (
+	errRoot = errors.New("unknown root key")
+	errType = errors.New("unsupported value type")
+)
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package registry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows/registry"
+
+	kregistry "github.com/nil-go/konf/provider/registry"
+	"github.com/nil-go/konf/provider/registry/internal/assert"
+)
+
+func TestRegistry_Load(t *testing.T) {
+	t.Parallel()
+
+	path := createKey(t, `Software\konf\TestRegistry_Load`)
+	values, err := kregistry.New(`HKCU\` + path).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"host":  "example.com",
+		"port":  uint64(8080),
+		"tags":  []any{"a", "b"},
+		"token": []byte("hello"),
+		"server": map[string]any{
+			"timeout": uint64(30),
+		},
+	}, values)
+}
+
+func TestRegistry_Load_error(t *testing.T) {
+	t.Parallel()
+
+	_, err := kregistry.New(`HKXX\Software`).Load()
+	assert.EqualError(t, err, "unknown root key: HKXX")
+}
+
+func TestRegistry_Watch(t *testing.T) {
+	t.Parallel()
+
+	path := createKey(t, `Software\konf\TestRegistry_Watch`)
+	ticks := make(ticker)
+	loader := kregistry.New(`HKEY_CURRENT_USER\`+path, kregistry.WithClock(ticks))
+	_, err := loader.Load()
+	assert.NoError(t, err)
+
+	values := make(chan map[string]any, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+	}()
+
+	key, err := registry.OpenKey(registry.CURRENT_USER, path, registry.SET_VALUE)
+	assert.NoError(t, err)
+	defer func() { _ = key.Close() }()
+	assert.NoError(t, key.SetStringValue("host", "changed.example.com"))
+	ticks <- time.Now()
+	assert.Equal(t, "changed.example.com", (<-values)["host"])
+}
+
+func createKey(t *testing.T, path string) string {
+	t.Helper()
+
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, path, registry.ALL_ACCESS)
+	assert.NoError(t, err)
+	defer func() { _ = key.Close() }()
+	assert.NoError(t, key.SetStringValue("host", "example.com"))
+	assert.NoError(t, key.SetDWordValue("port", 8080))
+	assert.NoError(t, key.SetStringsValue("tags", []string{"a", "b"}))
+	assert.NoError(t, key.SetBinaryValue("token", []byte("hello")))
+
+	subKey, _, err := registry.CreateKey(key, "server", registry.ALL_ACCESS)
+	assert.NoError(t, err)
+	defer func() { _ = subKey.Close() }()
+	assert.NoError(t, subKey.SetQWordValue("timeout", 30))
+
+	t.Cleanup(func() {
+		_ = registry.DeleteKey(registry.CURRENT_USER, path+`\server`)
+		_ = registry.DeleteKey(registry.CURRENT_USER, path)
+	})
+
+	return path
+}
+
+type ticker chan time.Time
+
+func (t ticker) NewTicker(time.Duration) (<-chan time.Time, func()) {
+	return t, func() {}
+}
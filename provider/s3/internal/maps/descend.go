@@ -0,0 +1,34 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package maps
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Descend returns the map under the given root path delimited by dot, e.g. for WithRootPath of providers.
+// It returns the values directly if the root path is empty.
+func Descend(values map[string]any, rootPath string) (map[string]any, error) {
+	if rootPath == "" {
+		return values, nil
+	}
+
+	var value any = values
+	for _, key := range strings.Split(rootPath, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("root path %q: %T is not a map", rootPath, value) //nolint:err113
+		}
+		if value, ok = m[key]; !ok {
+			return nil, fmt.Errorf("root path %q is not found", rootPath) //nolint:err113
+		}
+	}
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("root path %q: %T is not a map", rootPath, value) //nolint:err113
+	}
+
+	return root, nil
+}
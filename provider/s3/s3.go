@@ -36,6 +36,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go"
+
+	"github.com/nil-go/konf/provider/s3/internal/maps"
 )
 
 // S3 is a Provider that loads configuration from AWS S3.
@@ -43,7 +45,9 @@ import (
 // To create a new S3, call [New].
 type S3 struct {
 	unmarshal    func([]byte, any) error
+	rootPath     string
 	pollInterval time.Duration
+	clock        Clock
 
 	onStatus  func(bool, error)
 	changedCh chan struct{}
@@ -52,23 +56,48 @@ type S3 struct {
 
 // New creates an S3 with the given uri and Option(s).
 func New(uri string, opts ...Option) *S3 {
-	uri = strings.TrimPrefix(uri, "s3:")
-	uri = strings.TrimLeft(uri, "/")
-	bucket, key, _ := strings.Cut(uri, "/")
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
 
-	option := &options{
+	return newS3(uri, Options(*option))
+}
+
+// NewFromOptions creates an S3 with the given uri and Options, same as New with the corresponding Option(s).
+// It returns error if the uri has no bucket or key, or the options are invalid, e.g. the poll interval is negative.
+func NewFromOptions(uri string, options Options) (*S3, error) {
+	if err := options.validate(uri); err != nil {
+		return nil, err
+	}
+
+	return newS3(uri, options), nil
+}
+
+func newS3(uri string, options Options) *S3 {
+	bucket, key := parseURI(uri)
+
+	return &S3{
+		unmarshal:    options.Unmarshal,
+		rootPath:     options.RootPath,
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		changedCh:    make(chan struct{}, 1),
 		client: clientProxy{
-			bucket: bucket,
-			key:    key,
+			config:  options.AWSConfig,
+			bucket:  bucket,
+			key:     key,
+			timeout: options.PollInterval / 2, //nolint:mnd
 		},
-		changedCh: make(chan struct{}, 1),
 	}
-	for _, opt := range opts {
-		opt(option)
-	}
-	option.client.timeout = option.pollInterval / 2 //nolint:mnd
+}
+
+func parseURI(uri string) (string, string) {
+	uri = strings.TrimPrefix(uri, "s3:")
+	uri = strings.TrimLeft(uri, "/")
+	bucket, key, _ := strings.Cut(uri, "/")
 
-	return (*S3)(option)
+	return bucket, key
 }
 
 var errNil = errors.New("nil S3")
@@ -95,14 +124,14 @@ func (a *S3) Watch(ctx context.Context, onChange func(map[string]any)) error {
 	if a.pollInterval > 0 {
 		pollInterval = a.pollInterval
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := a.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-ticker.C:
+		case <-ticks:
 			a.changed()
 		case <-a.changedCh:
 			values, changed, err := a.load(ctx)
@@ -130,6 +159,10 @@ func (a *S3) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	values, err = maps.Descend(values, a.rootPath)
+	if err != nil {
+		return nil, false, err
+	}
 
 	return values, true, nil
 }
@@ -213,6 +246,15 @@ func (a *S3) String() string {
 	return "s3://" + path.Join(a.client.bucket, a.client.key)
 }
 
+func (a *S3) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if a.clock != nil {
+		return a.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	config aws.Config
 	bucket string
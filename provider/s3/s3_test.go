@@ -228,6 +228,33 @@ func testcases() []testcase {
 			},
 			err: "unmarshal: unmarshal error",
 		},
+		{
+			description: "root path",
+			opts: []ks3.Option{
+				ks3.WithPollInterval(10 * time.Millisecond),
+				ks3.WithRootPath("spec.config"),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body: io.NopCloser(strings.NewReader(`{"spec":{"config":{"k":"v"}}}`)),
+							ETag: aws.String("k42"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
 	}
 }
 
@@ -440,3 +467,16 @@ func TestS3_String(t *testing.T) {
 	loader = ks3.New("s3://bucket/key")
 	assert.Equal(t, "s3://bucket/key", loader.String())
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := ks3.NewFromOptions("s3://bucket/key", ks3.Options{PollInterval: time.Second, RootPath: "spec.config"})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket/key", loader.String())
+
+	_, err = ks3.NewFromOptions("s3://bucket", ks3.Options{PollInterval: -time.Second, RootPath: "spec..config"})
+	assert.EqualError(t, err, "invalid options: uri \"s3://bucket\" has no bucket or key\n"+
+		"root path \"spec..config\" has empty key\n"+
+		"poll interval -1s is negative")
+}
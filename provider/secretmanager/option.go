@@ -5,6 +5,9 @@
 package secretmanager
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/api/option"
@@ -17,7 +20,7 @@ import (
 func WithProject(project string) Option {
 	return &optionFunc{
 		fn: func(options *options) {
-			options.client.project = project
+			options.Project = project
 		},
 	}
 }
@@ -28,7 +31,7 @@ func WithProject(project string) Option {
 func WithFilter(filter string) Option {
 	return &optionFunc{
 		fn: func(options *options) {
-			options.client.filter = filter
+			options.Filter = filter
 		},
 	}
 }
@@ -41,7 +44,7 @@ func WithFilter(filter string) Option {
 func WithNameSplitter(splitter func(string) []string) Option {
 	return &optionFunc{
 		fn: func(options *options) {
-			options.splitter = splitter
+			options.NameSplitter = splitter
 		},
 	}
 }
@@ -52,7 +55,25 @@ func WithNameSplitter(splitter func(string) []string) Option {
 func WithPollInterval(interval time.Duration) Option {
 	return &optionFunc{
 		fn: func(options *options) {
-			options.pollInterval = interval
+			options.PollInterval = interval
+		},
+	}
+}
+
+// Clock provides the ticker which drives polling of the secrets.
+// Any konf.Clock implementation works here.
+type Clock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock Clock) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.Clock = clock
 		},
 	}
 }
@@ -63,5 +84,38 @@ type (
 		internaloption.EmbeddableAdapter
 		fn func(options *options)
 	}
-	options SecretManager
+	options Options
+
+	// Options is the struct form of Option(s) for NewFromOptions,
+	// e.g. for building the SecretManager from generated configuration.
+	Options struct {
+		// ClientOptions are passed to the Secret Manager client as is.
+		ClientOptions []option.ClientOption
+		// Project is the same as WithProject.
+		Project string
+		// Filter is the same as WithFilter.
+		Filter string
+		// NameSplitter is the same as WithNameSplitter.
+		NameSplitter func(string) []string
+		// PollInterval is the same as WithPollInterval.
+		PollInterval time.Duration
+		// Clock is the same as WithClock.
+		Clock Clock
+	}
 )
+
+func (o Options) validate() error {
+	var errs []error
+	if strings.Contains(o.Project, "/") {
+		// The project ID never contains '/', and it's not the resource name "projects/<id>".
+		errs = append(errs, fmt.Errorf("project %q is not a project ID", o.Project)) //nolint:err113
+	}
+	if o.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll interval %s is negative", o.PollInterval)) //nolint:err113
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid options: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -6,6 +6,9 @@
 // It requires following roles on the target project:
 //   - roles/secretmanager.viewer
 //
+// The secrets are loaded as string, except the binary payloads which are not valid UTF-8
+// are loaded as []byte, e.g. DER encoded keys.
+//
 // # Change notification
 //
 // By default, it periodically polls the configuration only.
@@ -30,6 +33,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	"cloud.google.com/go/compute/metadata"
@@ -46,6 +50,7 @@ import (
 // To create a new SecretManager, call [New].
 type SecretManager struct {
 	pollInterval time.Duration
+	clock        Clock
 	splitter     func(string) []string
 
 	onStatus  func(bool, error)
@@ -55,20 +60,41 @@ type SecretManager struct {
 
 // New creates a SecretManager with the given endpoint and Option(s).
 func New(opts ...Option) *SecretManager {
-	option := &options{
-		client:    clientProxy{},
-		changedCh: make(chan struct{}, 1),
-	}
+	option := &options{}
 	for _, opt := range opts {
 		switch o := opt.(type) {
 		case *optionFunc:
 			o.fn(option)
 		default:
-			option.client.opts = append(option.client.opts, o)
+			option.ClientOptions = append(option.ClientOptions, o)
 		}
 	}
 
-	return (*SecretManager)(option)
+	return newSecretManager(Options(*option))
+}
+
+// NewFromOptions creates a SecretManager with the given Options, same as New with the corresponding Option(s).
+// It returns error if the options are invalid, e.g. the project is the resource name instead of the ID.
+func NewFromOptions(options Options) (*SecretManager, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	return newSecretManager(options), nil
+}
+
+func newSecretManager(options Options) *SecretManager {
+	return &SecretManager{
+		pollInterval: options.PollInterval,
+		clock:        options.Clock,
+		splitter:     options.NameSplitter,
+		changedCh:    make(chan struct{}, 1),
+		client: clientProxy{
+			project: options.Project,
+			filter:  options.Filter,
+			opts:    options.ClientOptions,
+		},
+	}
 }
 
 var errNil = errors.New("nil SecretManager")
@@ -101,12 +127,12 @@ func (m *SecretManager) Watch(ctx context.Context, onChange func(map[string]any)
 	if m.pollInterval > 0 {
 		pollInterval = m.pollInterval
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	ticks, stop := m.newTicker(pollInterval)
+	defer stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticks:
 			m.changed()
 		case <-m.changedCh:
 			values, changed, err := m.load(ctx)
@@ -184,6 +210,15 @@ func (m *SecretManager) String() string {
 	return "secret-manager://" + m.client.project
 }
 
+func (m *SecretManager) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if m.clock != nil {
+		return m.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
 type clientProxy struct {
 	project    string
 	namePrefix string
@@ -194,7 +229,7 @@ type clientProxy struct {
 	lastETags atomic.Pointer[map[string]string]
 }
 
-func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error) { //nolint:cyclop,funlen
+func (p *clientProxy) load(ctx context.Context) (map[string]any, bool, error) { //nolint:cyclop,funlen
 	if p.project == "" {
 		var err error
 		if p.project, err = metadata.ProjectIDWithContext(ctx); err != nil {
@@ -268,9 +303,14 @@ func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error)
 		return nil, false, err //nolint:wrapcheck
 	}
 
-	values := make(map[string]string, len(eTags))
+	values := make(map[string]any, len(eTags))
 	for resp := range secretChan {
 		data := resp.GetPayload().GetData()
+		if !utf8.Valid(data) {
+			values[strings.Split(resp.GetName(), "/")[3]] = data
+
+			continue
+		}
 		values[strings.Split(resp.GetName(), "/")[3]] = unsafe.String(unsafe.SliceData(data), len(data))
 	}
 
@@ -63,6 +63,19 @@ func TestSecretManager_Load(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "binary secret",
+			service: &secretManagerService{
+				values: map[string]string{
+					"projects/test/secrets/p-k": "\x30\x82\xff",
+				},
+			},
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": []byte{0x30, 0x82, 0xff},
+				},
+			},
+		},
 		{
 			description: "with filter",
 			opts: []option.ClientOption{
@@ -406,3 +419,15 @@ func (f *faultySecretManagerService) AccessSecretVersion(
 
 	return &pb.AccessSecretVersionResponse{}, nil
 }
+
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	loader, err := secretmanager.NewFromOptions(secretmanager.Options{Project: "test", PollInterval: time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, "secret-manager://test", loader.String())
+
+	_, err = secretmanager.NewFromOptions(secretmanager.Options{Project: "projects/test", PollInterval: -time.Second})
+	assert.EqualError(t, err, "invalid options: project \"projects/test\" is not a project ID\n"+
+		"poll interval -1s is negative")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package systemdcreds
+
+import (
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+// WithDirectory provides the credentials directory, e.g. for the credentials decrypted by `systemd-creds`
+// into the directory other than $CREDENTIALS_DIRECTORY.
+//
+// By default, it uses the directory in the environment variable $CREDENTIALS_DIRECTORY.
+func WithDirectory(dir string) Option {
+	return func(options *options) {
+		options.dir = dir
+	}
+}
+
+// WithNameSplitter provides the function used to split credential names into nested keys.
+// If it returns an nil/[]string{}/[]string{""}, the credential will be ignored.
+//
+// For example, with the default splitter, a credential name like "db.password"
+// would be split into "db" and "password". Use the splitter like
+// `func(s string) []string { return strings.Split(s, "_") }` for the name like "db_password".
+func WithNameSplitter(splitter func(string) []string) Option {
+	return func(options *options) {
+		options.splitter = splitter
+	}
+}
+
+// WithOptional loads empty configuration instead of returning error
+// if the credentials directory is not set or does not exist, e.g. the service is not running under systemd,
+// so that the same binary runs outside systemd.
+func WithOptional() Option {
+	return func(options *options) {
+		options.optional = true
+	}
+}
+
+// WithPollInterval provides the interval for polling the configuration.
+//
+// The default interval is 1 minute.
+func WithPollInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.pollInterval = interval
+	}
+}
+
+// WithClock provides the Clock for polling the configuration.
+// It's useful for tests to drive polling deterministically.
+//
+// By default, it uses the wall clock.
+func WithClock(clock konf.Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+type (
+	// Option configures the Credentials with specific options.
+	Option  func(options *options)
+	options Credentials
+)
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package systemdcreds loads configuration from [systemd credentials].
+//
+// Credentials loads every file in the credentials directory, which systemd passes to the service
+// via $CREDENTIALS_DIRECTORY for LoadCredential= and SetCredential=, and returns a nested map[string]any.
+// The file name is the key and the file content with trailing newlines trimmed is the value.
+//
+// It splits the file names by delimiter. For example, with the default delimiter ".",
+// the credential `db.password` is loaded as `{db: {password: "..."}}`.
+// Since the credentials are sensitive, konf blurs all of their values in logs and Config.Explain,
+// see konf.SecretLoader.
+//
+// # Change notification
+//
+// It periodically polls the configuration, and notifies the change
+// only if the loaded configuration is different from the last one.
+//
+// [systemd credentials]: https://systemd.io/CREDENTIALS/
+package systemdcreds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Credentials is a Provider that loads configuration from systemd credentials.
+//
+// To create a new Credentials, call [New].
+type Credentials struct {
+	dir          string
+	splitter     func(string) []string
+	optional     bool
+	pollInterval time.Duration
+	clock        konf.Clock
+
+	onStatus func(bool, error)
+	last     atomic.Pointer[map[string]any]
+}
+
+// New creates a Credentials with the given Option(s).
+//
+// By default, it loads the directory in the environment variable $CREDENTIALS_DIRECTORY.
+func New(opts ...Option) *Credentials {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.dir == "" {
+		option.dir = os.Getenv("CREDENTIALS_DIRECTORY")
+	}
+
+	return (*Credentials)(option)
+}
+
+var (
+	errNil   = errors.New("nil Credentials")
+	errNoDir = errors.New("$CREDENTIALS_DIRECTORY is not set")
+)
+
+func (c *Credentials) Load() (map[string]any, error) {
+	if c == nil {
+		return nil, errNil
+	}
+
+	values, _, err := c.load()
+
+	return values, err
+}
+
+func (c *Credentials) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if c == nil {
+		return errNil
+	}
+
+	pollInterval := time.Minute
+	if c.pollInterval > 0 {
+		pollInterval = c.pollInterval
+	}
+	ticks, stop := c.newTicker(pollInterval)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+			values, changed, err := c.load()
+			if c.onStatus != nil {
+				c.onStatus(changed, err)
+			}
+			if changed {
+				onChange(values)
+			}
+		}
+	}
+}
+
+func (c *Credentials) load() (map[string]any, bool, error) {
+	values, err := c.read()
+	if err != nil {
+		return nil, false, err
+	}
+	last := c.last.Swap(&values)
+
+	return values, last == nil || !reflect.DeepEqual(*last, values), nil
+}
+
+func (c *Credentials) read() (map[string]any, error) {
+	values := make(map[string]any)
+	if c.dir == "" {
+		if c.optional {
+			return values, nil
+		}
+
+		return nil, fmt.Errorf("read credentials: %w", errNoDir)
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if c.optional && errors.Is(err, os.ErrNotExist) {
+			return values, nil
+		}
+
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		keys := c.keys(entry.Name())
+		if keys == nil {
+			continue
+		}
+		content, e := os.ReadFile(filepath.Join(c.dir, entry.Name()))
+		if e != nil {
+			return nil, fmt.Errorf("read credential %s: %w", entry.Name(), e)
+		}
+		maps.Insert(values, keys, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return values, nil
+}
+
+// keys returns the nested keys which the credential with the given name is loaded as,
+// or nil if the credential is ignored.
+func (c *Credentials) keys(name string) []string {
+	splitter := c.splitter
+	if splitter == nil {
+		splitter = func(s string) []string { return strings.Split(s, ".") }
+	}
+	if keys := splitter(name); len(keys) > 1 || len(keys) == 1 && keys[0] != "" {
+		return keys
+	}
+
+	return nil
+}
+
+func (c *Credentials) Status(onStatus func(bool, error)) {
+	c.onStatus = onStatus
+}
+
+// Secrets reports true since all credentials are sensitive.
+func (c *Credentials) Secrets() bool {
+	return true
+}
+
+func (c *Credentials) String() string {
+	return "systemd-creds://" + c.dir
+}
+
+func (c *Credentials) newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	if c.clock != nil {
+		return c.clock.NewTicker(interval)
+	}
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
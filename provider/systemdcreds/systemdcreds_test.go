@@ -0,0 +1,174 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package systemdcreds_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/provider/systemdcreds"
+)
+
+var (
+	_ konf.Loader       = (*systemdcreds.Credentials)(nil)
+	_ konf.Watcher      = (*systemdcreds.Credentials)(nil)
+	_ konf.Statuser     = (*systemdcreds.Credentials)(nil)
+	_ konf.SecretLoader = (*systemdcreds.Credentials)(nil)
+)
+
+func TestCredentials_Load(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write(t, dir, "db.password", "secret\n")
+	write(t, dir, "api_token", "token\r\n")
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o700))
+
+	testcases := []struct {
+		description string
+		opts        []systemdcreds.Option
+		expected    map[string]any
+	}{
+		{
+			description: "default",
+			expected: map[string]any{
+				"db":        map[string]any{"password": "secret"},
+				"api_token": "token",
+			},
+		},
+		{
+			description: "with name splitter",
+			opts: []systemdcreds.Option{
+				systemdcreds.WithNameSplitter(func(s string) []string {
+					if !strings.HasPrefix(s, "api_") {
+						return nil
+					}
+
+					return strings.Split(s, "_")
+				}),
+			},
+			expected: map[string]any{
+				"api": map[string]any{"token": "token"},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			loader := systemdcreds.New(append(testcase.opts, systemdcreds.WithDirectory(dir))...)
+			values, err := loader.Load()
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, values)
+			assert.Equal(t, "systemd-creds://"+dir, loader.String())
+		})
+	}
+}
+
+func TestCredentials_Load_env(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "key", "value")
+	t.Setenv("CREDENTIALS_DIRECTORY", dir)
+
+	values, err := systemdcreds.New().Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, values)
+}
+
+func TestCredentials_Load_missing(t *testing.T) {
+	t.Setenv("CREDENTIALS_DIRECTORY", "")
+
+	_, err := systemdcreds.New().Load()
+	assert.EqualError(t, err, "read credentials: $CREDENTIALS_DIRECTORY is not set")
+	values, err := systemdcreds.New(systemdcreds.WithOptional()).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{}, values)
+
+	dir := filepath.Join(t.TempDir(), "missing")
+	_, err = systemdcreds.New(systemdcreds.WithDirectory(dir)).Load()
+	assert.EqualError(t, err, "read credentials: open "+dir+": no such file or directory")
+	values, err = systemdcreds.New(systemdcreds.WithDirectory(dir), systemdcreds.WithOptional()).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{}, values)
+}
+
+func TestCredentials_secrets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write(t, dir, "db.dsn", "postgres://db:5432\n")
+
+	config := konf.New()
+	assert.NoError(t, config.Load(systemdcreds.New(systemdcreds.WithDirectory(dir))))
+	assert.Equal(t, "db.dsn has value[******] that is loaded by loader[systemd-creds://"+dir+"].\n\n",
+		config.Explain("db.dsn"))
+	var dsn string
+	assert.NoError(t, config.Unmarshal("db.dsn", &dsn))
+	assert.Equal(t, "postgres://db:5432", dsn)
+}
+
+func TestCredentials_nil(t *testing.T) {
+	t.Parallel()
+
+	var loader *systemdcreds.Credentials
+	_, err := loader.Load()
+	assert.EqualError(t, err, "nil Credentials")
+	assert.EqualError(t, loader.Watch(context.Background(), nil), "nil Credentials")
+}
+
+func TestCredentials_Watch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write(t, dir, "key", "old")
+
+	fake := clock.NewFake(time.Time{})
+	loader := systemdcreds.New(
+		systemdcreds.WithDirectory(dir),
+		systemdcreds.WithPollInterval(time.Second),
+		systemdcreds.WithClock(fake),
+	)
+	statuses := make(chan bool, 1)
+	loader.Status(func(changed bool, err error) {
+		assert.NoError(t, err)
+		statuses <- changed
+	})
+	_, err := loader.Load()
+	assert.NoError(t, err)
+
+	values := make(chan map[string]any, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) { values <- changed }))
+	}()
+	fake.BlockUntil(1)
+
+	fake.Advance(time.Second)
+	assert.True(t, !<-statuses)
+
+	write(t, dir, "key", "new")
+	fake.Advance(time.Second)
+	assert.True(t, <-statuses)
+	assert.Equal(t, map[string]any{"key": "new"}, <-values)
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
@@ -21,3 +21,20 @@ func TestConfig_Exists(t *testing.T) {
 	assert.True(t, config.Exists([]string{"config", "a"}))
 	assert.True(t, !config.Exists([]string{"other"}))
 }
+
+func TestConfig_Exists_zero(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"Feature": map[string]any{"enabled": false, "name": ""}}))
+	assert.True(t, config.Exists([]string{"feature", "enabled"}))
+	assert.True(t, config.Exists([]string{"feature", "name"}))
+	assert.True(t, !config.Exists([]string{"feature", "missing"}))
+	path, ok := config.ExistsAny("Feature.Enabled")
+	assert.Equal(t, "Feature.Enabled", path)
+	assert.True(t, ok)
+
+	sub := config.Sub("feature")
+	assert.True(t, sub.Exists([]string{"enabled"}))
+	assert.True(t, !sub.Exists([]string{"missing"}))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Raw is the value re-encoded as JSON for the destination which is not interpreted by konf,
+// e.g. the section passed through to third-party libraries verbatim, similar to json.RawMessage.
+//
+// The keys of maps are sorted, so the same values always have the same bytes,
+// and change can be detected by comparing bytes. They are lowercased the same as the paths,
+// unless konf.WithMapKeyCaseSensitive or konf.WithCaseSensitive keeps the original keys from loaders.
+type Raw []byte
+
+// MarshalJSON returns r as the JSON encoding of r.
+func (r Raw) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+
+	return r, nil
+}
+
+// rawJSON encodes the value as canonical JSON with the original keys if kept, and sorted keys.
+func rawJSON(from any) ([]byte, error) {
+	bytes, err := json.Marshal(original(from))
+	if err != nil {
+		return nil, fmt.Errorf("encode as JSON: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// original returns the deep copy of the value with the original keys of maps recursively.
+func original(value any) any {
+	_, value = maps.Unpack(value)
+	switch value := value.(type) {
+	case map[string]any:
+		values := make(map[string]any, len(value))
+		for key, val := range value {
+			originalKey, _ := maps.Unpack(val)
+			if originalKey == "" {
+				originalKey = key
+			}
+			values[originalKey] = original(val)
+		}
+
+		return values
+	case []any:
+		values := make([]any, 0, len(value))
+		for _, val := range value {
+			values = append(values, original(val))
+		}
+
+		return values
+	default:
+		if val := reflect.ValueOf(value); val.Kind() == reflect.Slice && !val.IsNil() {
+			// The typed slice, e.g. []string, is copied so that it's not shared with the caller.
+			values := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+			for i := range val.Len() {
+				if elem := original(val.Index(i).Interface()); elem != nil {
+					values.Index(i).Set(reflect.ValueOf(elem))
+				}
+			}
+
+			return values.Interface()
+		}
+
+		return value
+	}
+}
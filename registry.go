@@ -0,0 +1,172 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// RegisterOption configures konf.Register with specific options.
+	RegisterOption  func(*registerOptions)
+	registerOptions struct {
+		described bool
+	}
+)
+
+// Described registers the type as the schema of the path via Config.Describe when it's attached,
+// so that it's listed by Config.Schema and the invalid changes are rejected with konf.WithTagValidation.
+func Described() RegisterOption {
+	return func(options *registerOptions) {
+		options.described = true
+	}
+}
+
+// Register declares that the configuration under the given path decodes into T.
+// It's designed to be called in init of the packages which own the configuration structs.
+//
+// The registrations take effects once konf.Attach is called,
+// and the current value is retrieved by konf.Lookup.
+// The registrations after konf.Attach take effects on the next konf.Attach.
+//
+// This function is concurrent-safe.
+func Register[T any](path string, opts ...RegisterOption) {
+	option := &registerOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	var caller string
+	if _, file, line, ok := runtime.Caller(1); ok {
+		caller = file + ":" + strconv.Itoa(line)
+	}
+
+	target := &atomic.Pointer[T]{}
+	reg := &registration{
+		path:      path,
+		typ:       reflect.TypeFor[T](),
+		described: option.described,
+		caller:    caller,
+		target:    target,
+		bind: func(config *Config) (*subscription, error) {
+			return bind(config, target, path, 4) //nolint:mnd
+		},
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.registrations = append(registry.registrations, reg)
+}
+
+// Attach decodes the configuration for all registrations by konf.Register from the given Config,
+// and keeps them updated on changes the same as konf.Bind.
+// It requires Config.Watch has been called for changes.
+//
+// It returns error if the same type is registered more than once, since konf.Lookup is keyed by the type,
+// or decoding fails. The registrations without error are still attached.
+// Attaching a new Config stops updating from the Config attached before.
+//
+// This function is concurrent-safe.
+func Attach(config *Config) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	for _, attached := range registry.attached {
+		attached.config.unregisterOnChange(attached.sub)
+	}
+	registry.attached = nil
+
+	var (
+		errs  []error
+		types = make(map[reflect.Type]*registration, len(registry.registrations))
+	)
+	for _, reg := range registry.registrations {
+		if registered, ok := types[reg.typ]; ok {
+			errs = append(errs, fmt.Errorf( //nolint:err113
+				"register %s for %q at %s: already registered for %q at %s",
+				reg.typ, reg.path, reg.caller, registered.path, registered.caller,
+			))
+
+			continue
+		}
+		types[reg.typ] = reg
+	}
+
+	for _, reg := range registry.registrations {
+		if types[reg.typ] != reg {
+			continue // Skip the duplicate registration.
+		}
+		if reg.described {
+			if err := config.Describe(reg.path, reflect.New(reg.typ).Interface()); err != nil {
+				errs = append(errs, fmt.Errorf("attach %s: %w", reg.typ, err))
+
+				continue
+			}
+		}
+		sub, err := reg.bind(config)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attach %s: %w", reg.typ, err))
+
+			continue
+		}
+		if sub != nil {
+			registry.attached = append(registry.attached, attachment{config: config, sub: sub})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Lookup returns the current value of T registered by konf.Register.
+// It returns the zero value if T is not registered or attached yet.
+//
+// This function is concurrent-safe.
+func Lookup[T any]() T { //nolint:ireturn
+	typ := reflect.TypeFor[T]()
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	for _, reg := range registry.registrations {
+		if reg.typ != typ {
+			continue
+		}
+		if value := reg.target.(*atomic.Pointer[T]).Load(); value != nil { //nolint:forcetypeassert
+			return *value
+		}
+	}
+
+	var zero T
+
+	return zero
+}
+
+type (
+	registration struct {
+		path      string
+		typ       reflect.Type
+		described bool
+		caller    string
+		target    any // *atomic.Pointer[T]
+		bind      func(*Config) (*subscription, error)
+	}
+	attachment struct {
+		config *Config
+		sub    *subscription
+	}
+)
+
+//nolint:gochecknoglobals
+var registry struct {
+	registrations []*registration
+	attached      []attachment
+	mutex         sync.RWMutex
+}
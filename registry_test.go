@@ -0,0 +1,84 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+// The registry is global, so all cases run sequentially in one test.
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	type (
+		httpConfig struct {
+			Port int `validate:"min=1"`
+		}
+		grpcConfig struct {
+			Port int
+		}
+	)
+
+	assert.Equal(t, httpConfig{}, konf.Lookup[httpConfig]())
+
+	konf.Register[httpConfig]("server.http", konf.Described())
+	konf.Register[grpcConfig]("server.grpc")
+
+	config := konf.New(konf.WithTagValidation(), konf.WithLogHandler(logHandler(&buffer{})))
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{
+			"http": map[string]any{"port": 8080},
+			"grpc": map[string]any{"port": 9090},
+		}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+	assert.NoError(t, konf.Attach(config))
+	assert.Equal(t, httpConfig{Port: 8080}, konf.Lookup[httpConfig]())
+	assert.Equal(t, grpcConfig{Port: 9090}, konf.Lookup[grpcConfig]())
+	assert.Equal(t, 1, len(config.Schema()))
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { changed <- struct{}{} })
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"server": map[string]any{
+		"http": map[string]any{"port": 8443},
+		"grpc": map[string]any{"port": 9443},
+	}}
+	<-changed
+	assert.Equal(t, httpConfig{Port: 8443}, konf.Lookup[httpConfig]())
+	assert.Equal(t, grpcConfig{Port: 9443}, konf.Lookup[grpcConfig]())
+
+	// Attaching another config stops updating from the previous one.
+	another := konf.New()
+	assert.NoError(t, another.Load(mapLoader{"server": map[string]any{"http": map[string]any{"port": 80}}}))
+	konf.Register[httpConfig]("server.http")
+	err := konf.Attach(another)
+	assert.Equal(t, true, err != nil && strings.Contains(err.Error(),
+		`register konf_test.httpConfig for "server.http" at `))
+	assert.Equal(t, true, strings.Contains(err.Error(), `: already registered for "server.http" at `))
+	assert.Equal(t, httpConfig{Port: 80}, konf.Lookup[httpConfig]())
+	assert.Equal(t, grpcConfig{}, konf.Lookup[grpcConfig]())
+
+	watcher.change <- map[string]any{"server": map[string]any{"http": map[string]any{"port": 443}}}
+	<-changed
+	assert.Equal(t, httpConfig{Port: 80}, konf.Lookup[httpConfig]())
+}
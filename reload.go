@@ -0,0 +1,184 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Reload loads the values from the given loader again, and applies the changes of values,
+// e.g. for the loader which does not implement Watcher.
+// If the loader has been loaded more than once, the one with the highest precedence is reloaded.
+// It returns UnknownLoaderError if the loader has not been loaded, and keeps the last values if it fails.
+//
+// If the values have changed, the callbacks registered by Config.OnChange are executed
+// for the paths whose value has been changed if Config.Watch has been called.
+// The reloads of the same loader are serialized, and the automatic reload provided by konf.WithAutoReload
+// is postponed for an interval after it.
+//
+// This method is concurrent-safe.
+func (c *Config) Reload(loader Loader) error {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("reload: %w", errSubView)
+	}
+
+	provider := c.providers.find(loader)
+	if provider == nil {
+		return UnknownLoaderError{Loader: loader}
+	}
+	if err := c.reload(context.Background(), provider); err != nil {
+		provider.status(err)
+
+		return fmt.Errorf("reload loader %v: %w", loader, err)
+	}
+
+	return nil
+}
+
+// AutoReloadError is the error reported to the callback provided by konf.WithOnStatus
+// if the loader fails in the automatic reload provided by konf.WithAutoReload,
+// so that it can be distinguished from the errors of watchers.
+type AutoReloadError struct {
+	Err error
+}
+
+func (e AutoReloadError) Error() string {
+	return "auto reload: " + e.Err.Error()
+}
+
+func (e AutoReloadError) Unwrap() error {
+	return e.Err
+}
+
+type (
+	autoReload struct {
+		interval time.Duration
+		loaders  []Loader
+	}
+	reloading struct {
+		mutex    sync.Mutex
+		last     time.Time // The time of the last reload, including Config.Reload.
+		failures int       // The number of consecutive failures.
+	}
+)
+
+func (c *Config) reload(ctx context.Context, provider *provider) error {
+	changed, err := c.reloadValues(provider)
+	if err != nil || !changed || provider.disabled.Load() {
+		return err // The values of disabled loader take effect once enabled.
+	}
+
+	oldValues, newValues := c.providers.resync()
+	c.warnShadows(ctx)
+	if watch := c.watched.Load(); watch != nil {
+		watch.notify(provider.loader, c.changedOnChanges(oldValues, newValues))
+	}
+	if !c.quietChanges {
+		c.log(ctx, slog.LevelInfo, "Configuration has been changed.", slog.Any("loader", provider.loader))
+	}
+
+	return nil
+}
+
+// reloadValues loads and stores the values of the provider, and reports whether the values have changed.
+func (c *Config) reloadValues(provider *provider) (bool, error) {
+	provider.reloading.mutex.Lock()
+	defer provider.reloading.mutex.Unlock()
+
+	provider.reloading.last = c.timeSource().Now()
+	provider.reloading.failures++
+	values, err := provider.loader.Load()
+	if err != nil {
+		return false, fmt.Errorf("load configuration: %w", err)
+	}
+	c.transformKeys(values)
+	replaced := provider.replaced.Load()
+	c.extractReplaceMarkers(provider, values)
+	oldValues, newValues, err := c.validateAndStore(provider, values, nil)
+	if err != nil {
+		provider.replaced.Store(replaced)
+
+		return false, err
+	}
+	provider.reloading.failures = 0
+
+	return !maps.Equal(oldValues, newValues), nil
+}
+
+// autoReload reloads the loaders provided by konf.WithAutoReload on the interval until ctx is done.
+// The interval is extended with a random jitter up to 10% so that instances do not reload at the same time,
+// and doubled after each consecutive failure of the loader, up to 32 times of the interval.
+func (c *Config) autoReload(ctx context.Context, reload autoReload) {
+	for {
+		interval := reload.interval + rand.N(reload.interval/10+1) //nolint:gosec,mnd
+		timer, stop := c.timeSource().NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			stop()
+
+			return
+		case <-timer:
+		}
+
+		var providers []*provider
+		c.providers.traverse(func(provider *provider) {
+			if c.autoReloaded(reload, provider) {
+				providers = append(providers, provider)
+			}
+		})
+		for _, provider := range providers {
+			if ctx.Err() != nil {
+				return
+			}
+			provider.reloading.mutex.Lock()
+			last, failures := provider.reloading.last, provider.reloading.failures
+			provider.reloading.mutex.Unlock()
+			backoff := reload.interval << min(failures, 5)                   //nolint:mnd
+			if c.timeSource().Now().Sub(last) < backoff-reload.interval/10 { //nolint:mnd
+				continue // The loader has been reloaded recently, or it's in backoff.
+			}
+
+			err := c.reload(ctx, provider)
+			if err == nil {
+				continue
+			}
+			err = AutoReloadError{Err: fmt.Errorf("reload configuration from %v: %w", provider.loader, err)}
+			provider.status(err)
+			c.log(ctx, slog.LevelWarn,
+				"Error when auto reloading configuration.",
+				slog.Any("loader", provider.loader),
+				slog.Any("error", err),
+			)
+			if c.onStatus != nil {
+				c.onStatus(provider.loader, false, err)
+			}
+		}
+	}
+}
+
+func (c *Config) autoReloaded(reload autoReload, provider *provider) bool {
+	if provider.unloaded.Load() || provider.disabled.Load() {
+		return false
+	}
+	if len(reload.loaders) == 0 {
+		_, ok := provider.loader.(Watcher)
+
+		return !ok
+	}
+	for _, loader := range reload.loaders {
+		if sameLoader(loader, provider.loader) {
+			return true
+		}
+	}
+
+	return false
+}
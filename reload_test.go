@@ -0,0 +1,181 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestConfig_Reload(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	loader := &reloadLoader{values: map[string]any{"config": "string"}}
+	assert.NoError(t, config.Load(loader))
+
+	loader.set(map[string]any{"config": "reloaded"}, nil)
+	assert.NoError(t, config.Reload(loader))
+	var value string
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, "reloaded", value)
+
+	loader.set(nil, errors.New("load error"))
+	assert.EqualError(t, config.Reload(loader), "reload loader reload: load configuration: load error")
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, "reloaded", value)
+	assert.EqualError(t, config.Status().Loaders[0].LastError, "load configuration: load error")
+
+	var unknown konf.UnknownLoaderError
+	assert.True(t, errors.As(config.Reload(mapLoader{}), &unknown))
+}
+
+func TestConfig_Reload_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	loader := &reloadLoader{values: map[string]any{"config": "string"}}
+	assert.NoError(t, config.Load(loader))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	changes := make(chan konf.ChangeEvent, 2)
+	config.OnChange(func(config *konf.Config) { changes <- config.LastChange() })
+
+	// No change is dispatched if the values are the same.
+	assert.NoError(t, config.Reload(loader))
+	loader.set(map[string]any{"config": "reloaded"}, nil)
+	assert.NoError(t, config.Reload(loader))
+	event := <-changes
+	assert.Equal(t, konf.Loader(loader), event.Loader)
+	assert.Equal(t, []string{"config"}, event.Keys)
+	assert.Equal(t, 0, len(changes))
+}
+
+func TestConfig_AutoReload(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	loader := &reloadLoader{values: map[string]any{"config": "string"}}
+	watcher := &mapWatcher{values: map[string]any{"watcher": "string"}, change: make(chan map[string]any)}
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithClock(fake),
+		konf.WithAutoReload(time.Minute),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) { statuses <- err }),
+	)
+	assert.NoError(t, config.Load(loader))
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	// Reload on the interval, and the watcher is not reloaded.
+	loader.set(map[string]any{"config": "reloaded"}, nil)
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute * 11 / 10)
+	fake.BlockUntil(1)
+	assert.Equal(t, "reloaded", configValue(t, config))
+	assert.Equal(t, 2, loader.count())
+
+	// Backoff after failure.
+	loader.set(nil, errors.New("load error"))
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute * 11 / 10)
+	var reloadErr konf.AutoReloadError
+	assert.True(t, errors.As(<-statuses, &reloadErr))
+	assert.EqualError(t, reloadErr, "auto reload: reload configuration from reload: load configuration: load error")
+	assert.Equal(t, 3, loader.count())
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute * 11 / 10)
+	fake.BlockUntil(1)
+	assert.Equal(t, 3, loader.count())
+
+	// Postpone after manual reload, which also resets the backoff.
+	fake.Advance(30 * time.Second)
+	loader.set(map[string]any{"config": "manual"}, nil)
+	assert.NoError(t, config.Reload(loader))
+	assert.Equal(t, "manual", configValue(t, config))
+	fake.Advance(36 * time.Second)
+	fake.BlockUntil(1)
+	assert.Equal(t, 4, loader.count())
+	fake.Advance(time.Minute * 11 / 10)
+	fake.BlockUntil(1)
+	assert.Equal(t, 5, loader.count())
+}
+
+func configValue(t *testing.T, config *konf.Config) string {
+	t.Helper()
+
+	var value string
+	assert.NoError(t, config.Unmarshal("config", &value))
+
+	return value
+}
+
+type reloadLoader struct {
+	values map[string]any
+	err    error
+	loads  int
+	mutex  sync.Mutex
+}
+
+func (r *reloadLoader) Load() (map[string]any, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.loads++
+	if r.err != nil {
+		return nil, r.err
+	}
+	values := make(map[string]any, len(r.values))
+	for key, value := range r.values {
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+func (r *reloadLoader) set(values map[string]any, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.values, r.err = values, err
+}
+
+func (r *reloadLoader) count() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.loads
+}
+
+func (*reloadLoader) String() string {
+	return "reload"
+}
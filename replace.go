@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"slices"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// replaces reports whether the map at the given path from the provider
+// replaces the map from lower precedence providers instead of merging,
+// either by konf.WithReplaceKeys or the marker provided by konf.WithReplaceMarker.
+func (p *providers) replaces(provider *provider, path []string) bool {
+	equal := func(key []string) bool { return slices.Equal(key, path) }
+	if slices.ContainsFunc(p.replaceKeys, equal) {
+		return true
+	}
+	if replaced := provider.replaced.Load(); replaced != nil {
+		return slices.ContainsFunc(*replaced, equal)
+	}
+
+	return false
+}
+
+// replacedAt reports whether any map along the given path from the provider
+// replaces the values from lower precedence providers.
+func (p *providers) replacedAt(provider *provider, path []string) bool {
+	if len(p.replaceKeys) == 0 && provider.replaced.Load() == nil {
+		return false
+	}
+
+	values := *provider.values.Load()
+	for i := 1; i <= len(path); i++ {
+		if _, ok := maps.Sub(values, path[:i]).(map[string]any); ok && p.replaces(provider, path[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractReplaceMarkers removes the marker provided by konf.WithReplaceMarker from the maps in values,
+// and records the paths of the maps for replacement in the provider.
+func (c *Config) extractReplaceMarkers(provider *provider, values map[string]any) {
+	if c.replaceMarker == "" {
+		return
+	}
+
+	var replaced [][]string
+	var extract func(path []string, values map[string]any)
+	extract = func(path []string, values map[string]any) {
+		if marker, ok := values[c.replaceMarker].(bool); ok {
+			delete(values, c.replaceMarker)
+			if marker && len(path) > 0 {
+				replaced = append(replaced, path)
+			}
+		}
+		for key, value := range values {
+			if m, ok := value.(map[string]any); ok {
+				extract(append(slices.Clip(path), key), m)
+			}
+		}
+	}
+	extract(nil, values)
+	provider.replaced.Store(&replaced)
+}
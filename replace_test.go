@@ -0,0 +1,107 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_WithReplaceKeys(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithReplaceKeys("Endpoints"))
+	assert.NoError(t, config.Load(mapLoader{
+		"endpoints": map[string]any{"a": "low-a", "b": "low-b"},
+		"server":    map[string]any{"host": "low", "port": 8080},
+	}))
+	assert.NoError(t, config.Load(mapLoader{
+		"endpoints": map[string]any{"a": "high-a"},
+		"server":    map[string]any{"host": "high"},
+	}))
+
+	var endpoints map[string]string
+	assert.NoError(t, config.Unmarshal("endpoints", &endpoints))
+	assert.Equal(t, map[string]string{"a": "high-a"}, endpoints)
+	var port int
+	assert.NoError(t, config.Unmarshal("server.port", &port))
+	assert.Equal(t, 8080, port)
+
+	assert.Equal(t, "endpoints.a has value[high-a] that is loaded by loader[map].\n\n", config.Explain("endpoints"))
+	assert.Equal(t, "endpoints.b has no configuration.\n\n", config.Explain("endpoints.b"))
+	assert.Equal(t, []konf.Collision{
+		{Path: "server.host", Winner: config.Precedence()[1], Shadowed: []konf.Loader{config.Precedence()[0]}},
+	}, config.Collisions())
+}
+
+func TestConfig_WithReplaceMarker(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		expected    map[string]any
+	}{
+		{
+			description: "with marker",
+			opts:        []konf.Option{konf.WithReplaceMarker("$replace")},
+			expected:    map[string]any{"b": "high-b"},
+		},
+		{
+			description: "without marker",
+			expected:    map[string]any{"a": "low-a", "b": "high-b", "$replace": true},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader{"routes": map[string]any{"a": "low-a"}}))
+			assert.NoError(t, config.Load(mapLoader{"routes": map[string]any{"$replace": true, "b": "high-b"}}))
+
+			var routes map[string]any
+			assert.NoError(t, config.Unmarshal("routes", &routes))
+			assert.Equal(t, testcase.expected, routes)
+		})
+	}
+}
+
+func TestConfig_WithReplaceKeys_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithReplaceKeys("endpoints"))
+	assert.NoError(t, config.Load(mapLoader{"endpoints": map[string]any{"a": "low-a", "b": "low-b"}}))
+	watcher := mapWatcher{
+		values: map[string]any{"endpoints": map[string]any{"a": "high-a"}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	changes := make(chan map[string]string)
+	config.OnChange(func(config *konf.Config) {
+		var endpoints map[string]string
+		assert.NoError(t, config.Unmarshal("endpoints", &endpoints))
+		changes <- endpoints
+	}, "endpoints")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"endpoints": map[string]any{"c": "high-c"}}
+	assert.Equal(t, map[string]string{"c": "high-c"}, <-changes)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrMissingKeys is the error returned by Config.Validate if the required keys are missing.
+// The error message lists all missing paths, e.g. `missing keys: db.dsn, auth.secret`.
+var ErrMissingKeys = errors.New("missing keys")
+
+// Validate checks that the given paths exist in the Config, e.g. `config.Validate("db.dsn", "auth.secret")`
+// after all loaders are loaded so that the startup fails fast. It returns the error wrapping ErrMissingKeys
+// which lists all missing paths instead of stopping at the first one.
+// The empty values, i.e. empty string, map or slice, are missing unless konf.WithAcceptEmpty is set.
+// The paths are case-insensitive unless konf.WithCaseSensitive is set.
+//
+// It checks the current values, so it can be called repeatedly, e.g. in the callback registered by Config.OnChange.
+//
+// This method is concurrent-safe.
+func (c *Config) Validate(paths ...string) error {
+	var missing []string
+	for _, path := range paths {
+		if !c.present(path) {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrMissingKeys, strings.Join(missing, ", "))
+}
+
+func (c *Config) present(path string) bool {
+	if c == nil { // To support nil
+		return false
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.present(c.parentPath(path))
+	}
+
+	value, err := c.sub(c.splitPath(path))
+	if err != nil || value == nil {
+		return false
+	}
+	if c.acceptEmpty {
+		return true
+	}
+	switch reflected := reflect.ValueOf(value); reflected.Kind() { //nolint:exhaustive
+	case reflect.String, reflect.Map, reflect.Slice:
+		return reflected.Len() > 0
+	default:
+		return true
+	}
+}
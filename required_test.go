@@ -0,0 +1,72 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]any{
+		"db":   map[string]any{"dsn": "postgres://localhost", "pool": map[string]any{}, "hosts": []any{}},
+		"auth": map[string]any{"secret": "", "enabled": false},
+	}
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		paths       []string
+		err         string
+	}{
+		{
+			description: "present",
+			paths:       []string{"DB.DSN", "auth.enabled"},
+		},
+		{
+			description: "missing",
+			paths:       []string{"db.dsn", "db.user", "auth.secret", "db.pool", "db.hosts"},
+			err:         "missing keys: db.user, auth.secret, db.pool, db.hosts",
+		},
+		{
+			description: "accept empty",
+			opts:        []konf.Option{konf.WithAcceptEmpty()},
+			paths:       []string{"db.dsn", "db.user", "auth.secret", "db.pool", "db.hosts"},
+			err:         "missing keys: db.user",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader(values)))
+			err := config.Validate(testcase.paths...)
+			if testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testcase.err)
+				assert.True(t, errors.Is(err, konf.ErrMissingKeys))
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_sub(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"db": map[string]any{"dsn": "postgres://localhost"}}))
+	assert.NoError(t, config.Sub("db").Validate("dsn"))
+	assert.EqualError(t, config.Sub("db").Validate("dsn", "user"), "missing keys: user")
+
+	var nilConfig *konf.Config
+	assert.EqualError(t, nilConfig.Validate("db.dsn"), "missing keys: db.dsn")
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// AckRestart acknowledges the pending restart reported by Config.Status,
+// e.g. after the application has reloaded the components that cannot be changed at runtime.
+//
+// This method is concurrent-safe.
+func (c *Config) AckRestart() {
+	c.nocopy.Check()
+	if c.parent != nil {
+		c.parent.AckRestart()
+
+		return
+	}
+
+	c.restart.clear()
+}
+
+// checkRestart records the changed keys matching patterns provided by konf.WithRestartRequired,
+// and marks the given event as restart required.
+func (c *Config) checkRestart(ctx context.Context, event *ChangeEvent) {
+	if len(c.restartRequired) == 0 {
+		return
+	}
+
+	var keys []string
+	for _, key := range event.Keys {
+		if slices.ContainsFunc(c.restartRequired, func(pattern string) bool { return c.matchKey(pattern, key) }) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	event.RestartRequired = true
+	c.restart.add(keys)
+	c.log(ctx, slog.LevelWarn,
+		"Configuration change requires restart.",
+		slog.Any("keys", keys),
+	)
+}
+
+// matchKey reports whether the key is matched by the pattern or under the matched path.
+// The `*` in the pattern matches any single key, e.g. `tls.*` matches `tls.cert` and `tls.cert.file`.
+func (c *Config) matchKey(pattern, key string) bool {
+	patterns, keys := c.splitPath(pattern), c.splitPath(key)
+	if len(patterns) == 0 || len(patterns) > len(keys) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if pattern != "*" && pattern != keys[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type restart struct {
+	keys  map[string]struct{}
+	mutex sync.RWMutex
+}
+
+func (r *restart) add(keys []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.keys == nil {
+		r.keys = make(map[string]struct{})
+	}
+	for _, key := range keys {
+		r.keys[key] = struct{}{}
+	}
+}
+
+func (r *restart) clear() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.keys = nil
+}
+
+func (r *restart) pending() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if len(r.keys) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(r.keys))
+	for key := range r.keys {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, strings.Compare)
+
+	return keys
+}
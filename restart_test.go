@@ -0,0 +1,109 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Watch_restart_required(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(
+		konf.WithRestartRequired("server.port", "tls.*"),
+		konf.WithLogHandler(logHandler(buf)),
+	)
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"port": 8080, "timeout": "1s"}, "tls": "disabled"},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	events := make(chan konf.ChangeEvent)
+	config.OnChange(func(config *konf.Config) {
+		events <- config.LastChange()
+	})
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// Hot-reloadable change.
+	watcher.change <- map[string]any{"server": map[string]any{"port": 8080, "timeout": "2s"}, "tls": "disabled"}
+	event := <-events
+	assert.Equal(t, uint64(1), event.Version)
+	assert.Equal(t, "map", fmt.Sprint(event.Loader))
+	assert.Equal(t, []string{"server.timeout"}, event.Keys)
+	assert.True(t, !event.RestartRequired)
+	assert.True(t, !config.Status().RestartPending)
+
+	// Change requiring restart.
+	watcher.change <- map[string]any{
+		"server": map[string]any{"port": 9090, "timeout": "2s"},
+		"tls":    map[string]any{"cert": map[string]any{"file": "cert.pem"}},
+	}
+	event = <-events
+	assert.Equal(t, uint64(2), event.Version)
+	assert.Equal(t, []string{"server.port", "tls", "tls.cert.file"}, event.Keys)
+	assert.True(t, event.RestartRequired)
+	status := config.Status()
+	assert.True(t, status.RestartPending)
+	assert.Equal(t, []string{"server.port", "tls.cert.file"}, status.RestartKeys)
+	assert.Equal(t, []string{"server.port", "tls.cert.file"}, config.DebugState().RestartPending)
+	waitLog(t, buf, "Configuration has been changed.", 2)
+	expected := `level=INFO msg="Configuration has been changed." loader=map` + "\n" +
+		`level=INFO msg="Configuration has been changed." loader=map` + "\n" +
+		`level=WARN msg="Configuration change requires restart." keys="[server.port tls.cert.file]"` + "\n"
+	assert.Equal(t, expected, buf.String())
+
+	config.AckRestart()
+	assert.Equal(t, konf.Status{Loaders: config.Status().Loaders}, config.Status())
+}
+
+func TestConfig_LastChange_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, konf.ChangeEvent{}, config.LastChange())
+	assert.Equal(t, konf.Status{}, config.Status())
+	assert.Equal(t, konf.ChangeEvent{}, konf.New().LastChange())
+}
+
+type mapWatcher struct {
+	values map[string]any
+	change chan map[string]any
+}
+
+func (m mapWatcher) Load() (map[string]any, error) {
+	return m.values, nil
+}
+
+func (m mapWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	for {
+		select {
+		case values := <-m.change:
+			onChange(values)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (mapWatcher) String() string {
+	return "map"
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nil-go/konf/internal/credential"
+)
+
+// KeyDoc is the documentation of a configuration key derived from the struct registered by Config.Describe.
+type KeyDoc struct {
+	// Path is the path of the key. The `*` segment matches any key of a map.
+	Path string `json:"path"`
+	// Type is the Go type of the field.
+	Type string `json:"type"`
+	// Default is the value of the field in the registered struct.
+	// It's nil if the value is zero or the key is secret.
+	Default any `json:"default,omitempty"`
+	// Usage is the text in the `usage` tag of the field.
+	Usage string `json:"usage,omitempty"`
+	// Required reports whether the field has the `required` tag option, e.g. `konf:"port,required"`,
+	// or the `required` rule in the `validate` tag.
+	Required bool `json:"required,omitempty"`
+	// Secret reports whether the field (or its parent) has the `secret` tag option,
+	// or the path looks like a credential, e.g. password.
+	Secret bool `json:"secret,omitempty"`
+}
+
+// Describe registers the struct pointed to by target as the schema of the configuration under the given path.
+// The keys are derived by the same rules as Config.Unmarshal, and the current field values are taken as defaults.
+// Describing the same path again replaces the keys derived before.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// The registered keys are listed by Config.Schema, and the keys provided by loaders
+// but absent from the schema are reported by Config.UnknownKeys.
+//
+// This method is concurrent-safe.
+func (c *Config) Describe(path string, target any) error {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Describe(c.parentPath(path), target)
+	}
+
+	value := reflect.ValueOf(target)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value = reflect.Zero(value.Type().Elem())
+		} else {
+			value = value.Elem()
+		}
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("describe %T: target must be a struct or pointer to struct", target) //nolint:err113
+	}
+
+	path = strings.Join(c.splitPath(path), c.delim())
+	var docs []keyDoc
+	c.describeStruct(path, value, false, func(doc keyDoc) { docs = append(docs, doc) })
+	defaults := reflect.New(value.Type()).Elem()
+	defaults.Set(value)
+	c.schema.set(path, docs, defaults)
+
+	return nil
+}
+
+// Schema returns the documentations of all keys registered by Config.Describe, sorted by path.
+//
+// This method is concurrent-safe.
+func (c *Config) Schema() []KeyDoc {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Schema()
+	}
+
+	docs := c.schema.list()
+	if len(docs) == 0 {
+		return nil
+	}
+	keyDocs := make([]KeyDoc, 0, len(docs))
+	for _, doc := range docs {
+		keyDocs = append(keyDocs, doc.KeyDoc)
+	}
+
+	return keyDocs
+}
+
+// UnknownKeys returns all paths provided by loaders but absent from the schema registered by Config.Describe,
+// sorted by path. It returns nil if there is no schema registered.
+// The keys are checked by the same rules as konf.WithStrictUnmarshal against the struct described
+// under the deepest path which contains the key.
+//
+// This method is concurrent-safe.
+func (c *Config) UnknownKeys() []string {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.UnknownKeys()
+	}
+
+	described := c.schema.described()
+	if len(described) == 0 {
+		return nil
+	}
+
+	owner := func(path string) (string, bool) {
+		owner, found := "", false
+		for root := range described {
+			if (root == "" || c.matchKey(root, path)) &&
+				(!found || len(c.splitPath(root)) > len(c.splitPath(owner))) {
+				owner, found = root, true
+			}
+		}
+
+		return owner, found
+	}
+	var unknowns []string
+	for root, value := range described {
+		c.unknownKeys(root, c.providers.sub(c.splitPath(root)), value.Type(), func(path string, value any) {
+			c.walk(path, value, func(path string) {
+				if owner, _ := owner(path); owner == root {
+					unknowns = append(unknowns, path)
+				}
+			})
+		})
+	}
+	values, _ := c.providers.sub(nil).(map[string]any)
+	c.walk("", values, func(path string) {
+		if _, found := owner(path); !found {
+			unknowns = append(unknowns, path)
+		}
+	})
+	slices.Sort(unknowns)
+
+	return slices.Compact(unknowns)
+}
+
+// reportUnknownKeys logs the paths reported by Config.UnknownKeys.
+func (c *Config) reportUnknownKeys(ctx context.Context) {
+	for _, path := range c.UnknownKeys() {
+		attrs := []slog.Attr{slog.String("path", path)}
+		if loaders := c.provenance(path); len(loaders) > 0 {
+			attrs = append(attrs, slog.Any("loader", loaders[0].loader))
+		}
+		c.log(ctx, slog.LevelWarn, "Configuration is not described by the schema.", attrs...)
+	}
+}
+
+func (c *Config) describeStruct(path string, value reflect.Value, secret bool, add func(keyDoc)) {
+	c.visitFields(value.Type(), func(field reflect.StructField, key, _ string, tags []string) bool {
+		doc := KeyDoc{
+			Path:  c.joinPath(path, key),
+			Type:  field.Type.String(),
+			Usage: field.Tag.Get("usage"),
+			Required: slices.Contains(tags, "required") ||
+				slices.Contains(strings.Split(field.Tag.Get(validateTagName), ","), "required"),
+		}
+		doc.Secret = secret || slices.Contains(tags, "secret") || credential.Sensitive(doc.Path) || c.secret(doc.Path)
+		c.describeValue(doc, value.FieldByIndex(field.Index), add)
+
+		return true
+	})
+}
+
+func (c *Config) describeValue(doc KeyDoc, value reflect.Value, add func(keyDoc)) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value = reflect.Zero(value.Type().Elem())
+		} else {
+			value = value.Elem()
+		}
+	}
+	if !doc.Secret && !value.IsZero() {
+		doc.Default = value.Interface()
+	}
+
+	switch {
+	case isTextUnmarshaler(value.Type()):
+		add(keyDoc{KeyDoc: doc})
+	case value.Kind() == reflect.Struct:
+		c.describeStruct(doc.Path, value, doc.Secret, add)
+	case value.Kind() == reflect.Map:
+		elem := value.Type().Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct || isTextUnmarshaler(elem) {
+			add(keyDoc{KeyDoc: doc, open: true})
+
+			return
+		}
+		add(keyDoc{KeyDoc: doc})
+		c.describeStruct(c.joinPath(doc.Path, "*"), reflect.Zero(elem), doc.Secret, add)
+	case value.Kind() == reflect.Interface:
+		add(keyDoc{KeyDoc: doc, open: true})
+	default:
+		add(keyDoc{KeyDoc: doc})
+	}
+}
+
+func isTextUnmarshaler(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
+
+type (
+	schema struct {
+		// Keyed by the path passed to Config.Describe.
+		docs map[string][]keyDoc
+		// The copies of the structs passed to Config.Describe, which hold the default values.
+		defaults map[string]reflect.Value
+		mutex    sync.RWMutex
+	}
+	keyDoc struct {
+		KeyDoc
+		// open reports whether any key under the path is known, e.g. map[string]string.
+		open bool
+	}
+)
+
+func (s *schema) set(path string, docs []keyDoc, defaults reflect.Value) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.docs == nil {
+		s.docs = make(map[string][]keyDoc)
+		s.defaults = make(map[string]reflect.Value)
+	}
+	s.docs[path] = docs
+	s.defaults[path] = defaults
+}
+
+func (s *schema) described() map[string]reflect.Value {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return maps.Clone(s.defaults)
+}
+
+func (s *schema) list() []keyDoc {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var docs []keyDoc
+	for _, d := range s.docs {
+		docs = append(docs, d...)
+	}
+	slices.SortStableFunc(docs, func(a, b keyDoc) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return docs
+}
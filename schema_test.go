@@ -0,0 +1,276 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+type (
+	schemaOptions struct {
+		Common  `konf:",squash"`
+		Metrics metrics
+		Server  server
+		TLS     *tls `konf:"tls"`
+		Servers map[string]*server
+		Labels  map[string]string `usage:"Labels attached to metrics."`
+		Extra   any
+		Started time.Time
+		hidden  string
+	}
+	Common struct {
+		Name string `konf:"name,required" usage:"Name of the service."`
+	}
+	metrics struct {
+		Enabled bool
+	}
+	server struct {
+		Host    string `usage:"Host to listen on."`
+		Port    int    `konf:"port,required"`
+		Timeout time.Duration
+	}
+	tls struct {
+		Cert string
+		Key  string `konf:",secret"`
+	}
+	embedded struct {
+		Common
+		Password string
+	}
+)
+
+func TestConfig_Describe(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		path        string
+		target      any
+		opts        []konf.Option
+		expected    []konf.KeyDoc
+	}{
+		{
+			description: "nested, squashed and map fields",
+			target: &schemaOptions{
+				Common: Common{Name: "app"},
+				Server: server{Host: "localhost", Port: 8080, Timeout: time.Second},
+				TLS:    &tls{Cert: "cert.pem", Key: "key.pem"},
+				Labels: map[string]string{"env": "test"},
+				hidden: "hidden",
+			},
+			expected: []konf.KeyDoc{
+				{Path: "extra", Type: "interface {}"},
+				{Path: "labels", Type: "map[string]string", Default: map[string]string{"env": "test"}, Usage: "Labels attached to metrics."},
+				{Path: "metrics.enabled", Type: "bool"},
+				{Path: "name", Type: "string", Default: "app", Usage: "Name of the service.", Required: true},
+				{Path: "server.host", Type: "string", Default: "localhost", Usage: "Host to listen on."},
+				{Path: "server.port", Type: "int", Default: 8080, Required: true},
+				{Path: "server.timeout", Type: "time.Duration", Default: time.Second},
+				{Path: "servers", Type: "map[string]*konf_test.server"},
+				{Path: "servers.*.host", Type: "string", Usage: "Host to listen on."},
+				{Path: "servers.*.port", Type: "int", Required: true},
+				{Path: "servers.*.timeout", Type: "time.Duration"},
+				{Path: "started", Type: "time.Time"},
+				{Path: "tls.cert", Type: "string", Default: "cert.pem"},
+				{Path: "tls.key", Type: "string", Secret: true},
+			},
+		},
+		{
+			description: "embedded struct without squash",
+			path:        "App",
+			target:      embedded{Common: Common{Name: "app"}, Password: "password"},
+			expected: []konf.KeyDoc{
+				{Path: "app.common.name", Type: "string", Default: "app", Usage: "Name of the service.", Required: true},
+				{Path: "app.password", Type: "string", Secret: true},
+			},
+		},
+		{
+			description: "case sensitive with delimiter",
+			path:        "App",
+			target:      &server{},
+			opts:        []konf.Option{konf.WithCaseSensitive(), konf.WithDelimiter("/")},
+			expected: []konf.KeyDoc{
+				{Path: "App/Host", Type: "string", Usage: "Host to listen on."},
+				{Path: "App/Timeout", Type: "time.Duration"},
+				{Path: "App/port", Type: "int", Required: true},
+			},
+		},
+		{
+			description: "nil pointer",
+			target:      (*metrics)(nil),
+			expected:    []konf.KeyDoc{{Path: "enabled", Type: "bool"}},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Describe(testcase.path, testcase.target))
+			assert.Equal(t, testcase.expected, config.Schema())
+		})
+	}
+}
+
+func TestConfig_Describe_replace(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Describe("server", &server{}))
+	assert.NoError(t, config.Describe("metrics", &metrics{}))
+	assert.NoError(t, config.Describe("server", &metrics{}))
+	assert.Equal(t,
+		[]konf.KeyDoc{{Path: "metrics.enabled", Type: "bool"}, {Path: "server.enabled", Type: "bool"}},
+		config.Schema(),
+	)
+}
+
+func TestConfig_Describe_error(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	err := config.Describe("", map[string]any{})
+	assert.EqualError(t, err, "describe map[string]interface {}: target must be a struct or pointer to struct")
+}
+
+func TestConfig_Describe_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.NoError(t, config.Describe("", &server{}))
+	assert.Equal(t, nil, config.Schema())
+	assert.Equal(t, nil, config.UnknownKeys())
+}
+
+func TestConfig_UnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"name":    "app",
+		"Server":  map[string]any{"host": "localhost", "prot": 8080},
+		"servers": map[string]any{"a": map[string]any{"port": 80, "hots": "a"}},
+		"labels":  map[string]any{"env": "test", "nested": map[string]any{"key": "value"}},
+		"extra":   map[string]any{"any": "value"},
+		"tls":     map[string]any{"cert": "cert.pem"},
+		"unknown": "value",
+	}))
+	assert.Equal(t, nil, config.UnknownKeys())
+
+	assert.NoError(t, config.Describe("", &schemaOptions{}))
+	assert.Equal(t, []string{"server.prot", "servers.a.hots", "unknown"}, config.UnknownKeys())
+}
+
+func TestConfig_Watch_unknown_keys(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithLogHandler(logHandler(buf)))
+	assert.NoError(t, config.Load(mapLoader{"host": "localhost", "prot": 8080}))
+	assert.NoError(t, config.Describe("", &server{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, config.Watch(ctx))
+	expected := `level=WARN msg="Configuration is not described by the schema." path=prot loader=map` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDebugHandler(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"port": 8080}))
+	httpServer := httptest.NewServer(konf.DebugHandler(config))
+	defer httpServer.Close()
+
+	get := func(path string) (*http.Response, error) {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, httpServer.URL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return http.DefaultClient.Do(request)
+	}
+
+	resp, err := get("/debug/config/schema")
+	assert.NoError(t, err)
+	var docs []map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&docs))
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, []map[string]any{}, docs)
+
+	assert.NoError(t, config.Describe("", &tls{Cert: "cert.pem", Key: "key.pem"}))
+	resp, err = get("/debug/config/schema")
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&docs))
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t,
+		[]map[string]any{
+			{"path": "cert", "type": "string", "default": "cert.pem"},
+			{"path": "key", "type": "string", "secret": true},
+		},
+		docs,
+	)
+
+	resp, err = get("/debug/config/state")
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	resp, err = get("/debug/config/keys/port")
+	assert.NoError(t, err)
+	var info map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "port", info["path"])
+	assert.Equal[any](t, float64(8080), info["value"])
+	assert.Equal(t, "map", info["loader"])
+	assert.Equal[any](t, float64(0), info["version"])
+
+	resp, err = get("/debug/config/keys/host")
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Secrets are only revealed via POST with the reason.
+	assert.NoError(t, config.Load(mapLoader{"password": "s3cret"}))
+	reveal := func(reason string) (int, string) {
+		request, e := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			httpServer.URL+"/debug/config/secrets/password", strings.NewReader(url.Values{"reason": {reason}}.Encode()))
+		assert.NoError(t, e)
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, e := http.DefaultClient.Do(request)
+		assert.NoError(t, e)
+		body, e := io.ReadAll(resp.Body)
+		assert.NoError(t, e)
+		assert.NoError(t, resp.Body.Close())
+
+		return resp.StatusCode, string(body)
+	}
+	status, body := reveal("incident 42")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "s3cret", body)
+	status, _ = reveal("")
+	assert.Equal(t, http.StatusBadRequest, status)
+	resp, err = get("/debug/config/secrets/password")
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/nil-go/konf/internal/credential"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// RevealSecret returns the raw value of the given path which is blurred as secret,
+// e.g. by konf.DebugHandler and konf.SSEHandler, for the operator who needs to see it during an incident.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// The reason is required, and the audit record with the path, the reason, the caller of this method
+// and the freshness metadata of the value (see Config.KeyInfo) is written to the writer provided by konf.WithAuditWriter, or logged with warning level if it's not provided.
+// The value which is not secret is returned without the audit record.
+// It returns error if the reason is empty, or the path has no leaf value.
+//
+// This method is concurrent-safe.
+func (c *Config) RevealSecret(path, reason string) (string, error) {
+	var caller string
+	if _, file, line, ok := runtime.Caller(1); ok {
+		caller = file + ":" + strconv.Itoa(line)
+	}
+
+	return c.revealSecret(context.Background(), path, reason, caller)
+}
+
+var (
+	errNoReason = errors.New("reason is required")
+	errNoValue  = errors.New("no value")
+	errNotLeaf  = errors.New("not a leaf value")
+)
+
+const revealMessage = "Secret has been revealed."
+
+func (c *Config) revealSecret(ctx context.Context, path, reason, caller string) (string, error) {
+	if c == nil { // To support nil
+		return "", fmt.Errorf("reveal secret %s: %w", path, errNoValue)
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.revealSecret(ctx, c.parentPath(path), reason, caller)
+	}
+
+	keys := c.splitPath(path)
+	value, err := c.sub(keys)
+	if err != nil {
+		return "", fmt.Errorf("reveal secret %s: %w", path, err)
+	}
+	_, value = maps.Unpack(value)
+	switch value.(type) {
+	case nil:
+		return "", fmt.Errorf("reveal secret %s: %w", path, errNoValue)
+	case map[string]any:
+		return "", fmt.Errorf("reveal secret %s: %w", path, errNotLeaf)
+	}
+	path = strings.Join(keys, c.delim())
+	formatted := credential.Format(value)
+	if c.blur(path, value) == formatted {
+		return formatted, nil // It's not secret.
+	}
+	if strings.TrimSpace(reason) == "" {
+		return "", fmt.Errorf("reveal secret %s: %w", path, errNoReason)
+	}
+
+	info := c.KeyInfo(path)
+	attrs := []slog.Attr{
+		slog.String("path", path), slog.String("reason", reason), slog.String("caller", caller),
+		// The name of the loader instead of the loader itself, which may be encoded with its values.
+		slog.String("loader", fmt.Sprint(info.Loader)),
+		slog.Time("last_changed", info.LastChanged), slog.Uint64("version", info.Version),
+	}
+	if c.auditLogger != nil {
+		c.auditLogger.LogAttrs(ctx, slog.LevelInfo, revealMessage, attrs...)
+	} else {
+		c.log(ctx, slog.LevelWarn, revealMessage, attrs...)
+	}
+
+	return formatted, nil
+}
+
+// blur returns the blurred value of the given path like credential.Blur,
+// except that the value provided by any SecretLoader is always blurred.
+func (c *Config) blur(path string, value any) string {
+	if c.secret(path) {
+		return credential.Blurred
+	}
+
+	return credential.Blur(path, value)
+}
+
+// secret reports whether the given path has the value provided by the SecretLoader whose Secrets returns true,
+// even if the value is shadowed by other loaders.
+func (c *Config) secret(path string) bool {
+	if c == nil {
+		return false
+	}
+	if c.parent != nil {
+		return c.parent.secret(c.parentPath(path))
+	}
+
+	keys := c.splitPath(path)
+	secret := false
+	c.providers.traverse(func(provider *provider) {
+		if loader, ok := provider.loader.(SecretLoader); !secret && ok && loader.Secrets() {
+			if values := provider.values.Load(); values != nil {
+				secret = maps.Sub(*values, keys) != nil
+			}
+		}
+	})
+
+	return secret
+}
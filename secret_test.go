@@ -0,0 +1,221 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_RevealSecret(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		path        string
+		reason      string
+		expected    string
+		audit       bool
+		err         string
+	}{
+		{
+			description: "secret",
+			path:        "DB.Password",
+			reason:      "incident 42",
+			expected:    "s3cret",
+			audit:       true,
+		},
+		{
+			description: "not secret",
+			path:        "db.host",
+			expected:    "localhost",
+		},
+		{
+			description: "without reason",
+			path:        "db.password",
+			reason:      " ",
+			err:         "reveal secret db.password: reason is required",
+		},
+		{
+			description: "no value",
+			path:        "db.user",
+			reason:      "incident 42",
+			err:         "reveal secret db.user: no value",
+		},
+		{
+			description: "not leaf",
+			path:        "db",
+			reason:      "incident 42",
+			err:         "reveal secret db: not a leaf value",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			audit := &bytes.Buffer{}
+			config := konf.New(konf.WithAuditWriter(audit))
+			assert.NoError(t, config.Load(mapLoader{"db": map[string]any{"host": "localhost", "password": "s3cret"}}))
+
+			value, err := config.RevealSecret(testcase.path, testcase.reason)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, value)
+			}
+			if !testcase.audit {
+				assert.Equal(t, "", audit.String())
+
+				return
+			}
+			var record map[string]any
+			assert.NoError(t, json.Unmarshal(audit.Bytes(), &record))
+			assert.Equal[any](t, "Secret has been revealed.", record["msg"])
+			assert.Equal[any](t, "db.password", record["path"])
+			assert.Equal[any](t, "incident 42", record["reason"])
+			assert.True(t, strings.Contains(record["caller"].(string), "/secret_test.go:"))
+			assert.Equal[any](t, "map", record["loader"])
+			assert.Equal[any](t, float64(0), record["version"])
+			_, err = time.Parse(time.RFC3339, record["last_changed"].(string))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfig_RevealSecret_log(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithLogHandler(logHandler(buf)))
+	assert.NoError(t, config.Load(mapLoader{"token": "abc"}))
+
+	value, err := config.RevealSecret("token", "incident 42")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", value)
+	assert.True(t, strings.HasPrefix(buf.String(),
+		`level=WARN msg="Secret has been revealed." path=token reason="incident 42" caller=/`,
+	))
+	assert.True(t, strings.Contains(buf.String(), "/secret_test.go:"))
+}
+
+func TestConfig_secretNeverLeaks(t *testing.T) {
+	t.Parallel()
+
+	const secret = "s3cr3t-value"
+	buf := &buffer{}
+	var config *konf.Config
+	config = konf.New(
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithTagValidation(),
+		konf.WithLifecycleHooks(konf.Hooks{
+			OnWatchStart: func([]string) {
+				var port int
+				panic(config.Unmarshal("db.password", &port))
+			},
+		}),
+	)
+	assert.NoError(t, config.Load(mapLoader{"db": map[string]any{"password": secret}}))
+
+	var port int
+	err := config.Unmarshal("db.password", &port)
+	assert.True(t, err != nil && !strings.Contains(err.Error(), secret))
+	var db struct {
+		Password string `konf:"password,max=4" validate:"oneof=a b,url"`
+	}
+	err = config.Unmarshal("db", &db)
+	assert.True(t, err != nil && !strings.Contains(err.Error(), secret))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, config.Watch(ctx))
+	assert.True(t, strings.Contains(buf.String(), `msg="Panic in lifecycle hook."`))
+	assert.True(t, !strings.Contains(buf.String(), secret))
+}
+
+func TestConfig_Unmarshal_shortSecret(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"db": map[string]any{"password": "a", "enabled": "yes"}}))
+
+	// Only the value at the failing path is redacted, so the short secret does not corrupt the message.
+	var enabled bool
+	err := config.Unmarshal("db.enabled", &enabled)
+	assert.EqualError(t, err,
+		`decode: cannot parse 'db.enabled' as bool: strconv.ParseBool: parsing "yes": invalid syntax`)
+	var port int
+	err = config.Unmarshal("db.password", &port)
+	assert.EqualError(t, err,
+		`decode: cannot parse 'db.password' as int: strconv.ParseInt: parsing "******": invalid syntax`)
+}
+
+func TestConfig_validInputNeverPanics(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})), konf.WithTagValidation())
+	assert.NoError(t, config.Load(mapLoader{"db": map[string]any{"host": "localhost", "password": "secret"}}))
+
+	var db struct {
+		Host     string `validate:"required"`
+		Password string `konf:",secret"`
+	}
+	for _, sub := range []*konf.Config{config, config.Sub("db"), nil} {
+		_ = sub.Unmarshal("db", &db)
+		_ = sub.Describe("db", &db)
+		_ = sub.Explain("db")
+		_ = sub.Keys()
+		_ = sub.AllSettings()
+		_ = sub.Fingerprint()
+		_ = sub.Status()
+		_ = sub.KeyInfo("db.password")
+		_ = sub.Schema()
+		_ = sub.UnknownKeys()
+		_ = sub.Collisions()
+		_ = sub.DebugState()
+		_ = sub.DumpState(&bytes.Buffer{})
+		_ = sub.ExportState(&bytes.Buffer{})
+		_ = sub.Validate("db.host")
+		_, _ = sub.RevealSecret("db.password", "test")
+		_, _ = sub.UnmarshalFirst([]string{"db"}, &db)
+		sub.LogSummary()
+	}
+}
+
+func TestConfig_secretLoader(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"db": map[string]any{"host": "localhost", "dsn": "postgres://a"}}))
+	assert.NoError(t, config.Load(secretLoader{"db": map[string]any{"dsn": "postgres://b"}}))
+	assert.Equal(t, "db.dsn has value[******] that is loaded by loader[secret].\n"+
+		"Here are other value(loader)s:\n  - ******(map)\n\n", config.Explain("db.dsn"))
+	assert.Equal(t, "db.host has value[localhost] that is loaded by loader[map].\n\n", config.Explain("db.host"))
+
+	value, err := config.RevealSecret("db.dsn", "")
+	assert.EqualError(t, err, "reveal secret db.dsn: reason is required")
+	assert.Equal(t, "", value)
+}
+
+type secretLoader map[string]any
+
+func (s secretLoader) Load() (map[string]any, error) {
+	return s, nil
+}
+
+func (s secretLoader) Secrets() bool {
+	return true
+}
+
+func (s secretLoader) String() string {
+	return "secret"
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+
+	"github.com/nil-go/konf/internal/credential"
+	"github.com/nil-go/konf/internal/ipc"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+type (
+	// ServeOption configures konf.Serve with specific options.
+	ServeOption  func(*serveOptions)
+	serveOptions struct {
+		revealSecrets bool
+	}
+)
+
+// RevealSecrets sends the sensitive values as is in konf.Serve.
+// It's required if the clients need the real values, e.g. workers connecting to the database.
+func RevealSecrets() ServeOption {
+	return func(options *serveOptions) {
+		options.revealSecrets = true
+	}
+}
+
+// Serve streams the merged configuration of the given Config to each connection accepted by the listener,
+// until ctx is done or the listener fails, and closes all connections before it returns. It's designed for provider/ipc, e.g.
+// a supervisor process owns the real loaders and serves the configuration over an unix socket
+// to its worker processes.
+//
+// Each connection receives the snapshot of the whole configuration once connected,
+// and then a new snapshot after each change applied by Config.Watch.
+// The snapshots are coalesced if the connection is slower than changes, so it never blocks Config.Watch.
+// The sensitive values are blurred unless konf.RevealSecrets is set.
+// The callback registered for the changes is removed once it returns.
+//
+// The message is the 4-byte big-endian length followed by the JSON of the configuration.
+func Serve(ctx context.Context, config *Config, listener net.Listener, opts ...ServeOption) error {
+	option := &serveOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	var (
+		mutex   sync.Mutex
+		changed = make(chan struct{})
+	)
+	notified := func() <-chan struct{} {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return changed
+	}
+	onChange := config.registerOnChange(func(*Config) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		close(changed)
+		changed = make(chan struct{})
+	}, nil, 2) //nolint:mnd
+	defer config.unregisterOnChange(onChange)
+
+	stop := context.AfterFunc(ctx, func() { _ = listener.Close() })
+	defer stop()
+
+	// Close the connections before waiting for them, e.g. if the listener fails while ctx is not done.
+	ctx, cancel := context.WithCancel(ctx)
+	var waitGroup sync.WaitGroup
+	defer waitGroup.Wait()
+	defer cancel()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("accept connection: %w", err)
+		}
+
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			config.serve(ctx, conn, notified, option.revealSecrets)
+		}()
+	}
+}
+
+func (c *Config) serve(ctx context.Context, conn net.Conn, notified func() <-chan struct{}, revealSecrets bool) {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		// The client never sends anything, so it returns when the connection is closed.
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer func() {
+		stop()
+		_ = conn.Close()
+		<-closed
+	}()
+
+	for {
+		// Take the notification channel before the snapshot so that no change is missed.
+		changed := notified()
+		values, _ := c.export("", c.providers.sub(nil), revealSecrets).(map[string]any)
+		if err := ipc.Write(conn, values); err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				c.log(ctx, slog.LevelWarn,
+					"Error when serving configuration.",
+					slog.Any("addr", conn.RemoteAddr()),
+					slog.Any("error", err),
+				)
+			}
+
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-closed:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// export returns the copy of the given value with the original keys,
+// and blurs the sensitive values unless revealSecrets is true.
+func (c *Config) export(path string, value any, revealSecrets bool) any {
+	if values, ok := value.(map[string]any); ok {
+		exported := make(map[string]any, len(values))
+		for key, packed := range values {
+			originalKey, val := maps.Unpack(packed)
+			if originalKey == "" {
+				originalKey = key
+			}
+			exported[originalKey] = c.export(c.joinPath(path, key), val, revealSecrets)
+		}
+
+		return exported
+	}
+
+	if !revealSecrets {
+		if blurred := c.blur(path, value); blurred != credential.Format(value) {
+			return blurred
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/ipc"
+)
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []konf.ServeOption
+		expected    map[string]any
+	}{
+		{
+			description: "blur secrets",
+			expected: map[string]any{
+				"Server": map[string]any{"Port": json.Number("8080")}, "password": "******",
+			},
+		},
+		{
+			description: "reveal secrets",
+			opts:        []konf.ServeOption{konf.RevealSecrets()},
+			expected: map[string]any{
+				"Server": map[string]any{"Port": json.Number("8080")}, "password": "secret",
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(konf.WithMapKeyCaseSensitive())
+			assert.NoError(t, config.Load(mapLoader{"Server": map[string]any{"Port": 8080}, "password": "secret"}))
+
+			addr := filepath.Join(t.TempDir(), "konf.sock")
+			listener, err := net.Listen("unix", addr)
+			assert.NoError(t, err)
+			stopped := make(chan struct{})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer func() {
+				cancel()
+				<-stopped
+			}()
+			go func() {
+				defer close(stopped)
+				assert.NoError(t, konf.Serve(ctx, config, listener, testcase.opts...))
+			}()
+
+			conn, err := net.Dial("unix", addr)
+			assert.NoError(t, err)
+			defer func() {
+				_ = conn.Close()
+			}()
+			values, err := ipc.Read(conn)
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, values)
+		})
+	}
+}
+
+func TestServe_change(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"port": 8080}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	addr := filepath.Join(t.TempDir(), "konf.sock")
+	listener, err := net.Listen("unix", addr)
+	assert.NoError(t, err)
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		go func() {
+			assert.NoError(t, config.Watch(ctx))
+		}()
+		assert.NoError(t, konf.Serve(ctx, config, listener))
+	}()
+
+	conn, err := net.Dial("unix", addr)
+	assert.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	values, err := ipc.Read(conn)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"port": json.Number("8080")}, values)
+
+	watcher.change <- map[string]any{"port": 9090}
+	values, err = ipc.Read(conn)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"port": json.Number("9090")}, values)
+}
+
+func TestServe_unregister(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "konf.sock"))
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, konf.Serve(ctx, config, listener))
+	assert.Equal(t, []konf.SubscriptionState{}, config.DebugState().Subscriptions)
+}
+
+func TestServe_acceptError(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"k": "v"}))
+	addr := filepath.Join(t.TempDir(), "konf.sock")
+	inner, err := net.Listen("unix", addr)
+	assert.NoError(t, err)
+	listener := &failingListener{Listener: inner, fail: make(chan struct{})}
+	served := make(chan error, 1)
+	go func() {
+		served <- konf.Serve(context.Background(), config, listener)
+	}()
+
+	conn, err := net.Dial("unix", addr)
+	assert.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	values, err := ipc.Read(conn)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+
+	// It returns the error instead of waiting for the connection, which is closed.
+	close(listener.fail)
+	assert.EqualError(t, <-served, "accept connection: accept failure")
+	_, err = ipc.Read(conn)
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+// failingListener fails to accept the next connection after fail is closed.
+type failingListener struct {
+	net.Listener
+	fail     chan struct{}
+	accepted bool
+}
+
+func (l *failingListener) Accept() (net.Conn, error) {
+	if !l.accepted {
+		l.accepted = true
+
+		return l.Listener.Accept()
+	}
+	<-l.fail
+	_ = l.Listener.Close()
+
+	return nil, errors.New("accept failure")
+}
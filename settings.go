@@ -0,0 +1,24 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+// AllSettings returns the deep copy of the merged values from all loaders, including the changes
+// delivered by Config.Watch and the computed keys provided by konf.WithComputed,
+// so the caller can modify or serialize it freely, e.g. for the debug endpoint.
+// The keys are lower case unless konf.WithCaseSensitive is set, same as Config.Keys,
+// except the original keys kept by konf.WithMapKeyCaseSensitive. It returns nil if nothing has been loaded.
+//
+// The sensitive values are NOT blurred, see konf.DebugHandler for blurred values.
+//
+// This method is concurrent-safe.
+func (c *Config) AllSettings() map[string]any {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	settings, _ := original(c.values()).(map[string]any)
+
+	return settings
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_AllSettings(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	assert.NoError(t, config.Load(konf.Defaults(map[string]any{"server": map[string]any{"port": 80}})))
+	watcher := mapWatcher{
+		values: map[string]any{"Server": map[string]any{"Hosts": []string{"a", "b"}}, "password": "secret"},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	settings := config.AllSettings()
+	expected := map[string]any{
+		"server":   map[string]any{"hosts": []string{"a", "b"}, "port": 80},
+		"password": "secret",
+	}
+	assert.Equal(t, expected, settings)
+
+	// Mutating the returned settings does not change the Config.
+	settings["password"] = "changed"
+	server, _ := settings["server"].(map[string]any)
+	server["port"] = 8080
+	hosts, _ := server["hosts"].([]string)
+	hosts[0] = "changed"
+	assert.Equal(t, expected, config.AllSettings())
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) }, "timeout")
+	watcher.change <- map[string]any{"server": map[string]any{"hosts": []string{"a"}}, "timeout": "1s"}
+	<-changed
+	assert.Equal(t, map[string]any{
+		"server":  map[string]any{"hosts": []string{"a"}, "port": 80},
+		"timeout": "1s",
+	}, config.AllSettings())
+}
+
+func TestConfig_AllSettings_empty(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	assert.Equal(t, nil, config.AllSettings())
+	assert.Equal(t, nil, konf.New().AllSettings())
+}
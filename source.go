@@ -0,0 +1,125 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// SourceIdentifier is the interface that wraps the SourceID method.
+//
+// SourceID returns the canonical identifier of the source which the Watcher watches, e.g. the absolute path of file.
+// Config.Watch shares a single watch for the Watchers with the same non-empty SourceID,
+// and delivers its changes to the layer of each Watcher, so the Watchers with the same SourceID
+// must load the same values from the source.
+type SourceIdentifier interface {
+	SourceID() string
+}
+
+type (
+	// sources tracks the watches shared by the Watchers with the same SourceID.
+	sources struct {
+		sources map[string]*source
+		mutex   sync.Mutex
+	}
+	// source is the single watch of a source, which fans out its changes to the subscribers.
+	source struct {
+		subscribers []*func(map[string]any)
+		cancel      context.CancelFunc
+		done        chan struct{}
+		err         error
+	}
+)
+
+// watchSource watches the watcher until ctx is done, or its Watch returns.
+// If the watcher is a SourceIdentifier, it shares the watch started under the parent context
+// with the other Watchers with the same SourceID, which keeps running until all of them stop watching.
+func (c *Config) watchSource(ctx, parent context.Context, watcher Watcher, onChange func(map[string]any)) error {
+	identifier, ok := watcher.(SourceIdentifier)
+	if !ok || identifier.SourceID() == "" {
+		return watcher.Watch(ctx, onChange) //nolint:wrapcheck
+	}
+	id := identifier.SourceID()
+
+	c.sources.mutex.Lock()
+	if c.sources.sources == nil {
+		c.sources.sources = make(map[string]*source)
+	}
+	src, shared := c.sources.sources[id]
+	if !shared {
+		sourceCtx, cancel := context.WithCancel(parent)
+		src = &source{cancel: cancel, done: make(chan struct{})}
+		c.sources.sources[id] = src
+		go func() {
+			defer close(src.done)
+			defer cancel()
+
+			src.err = watcher.Watch(sourceCtx, func(values map[string]any) { c.fanOut(src, values) })
+			c.sources.mutex.Lock()
+			if c.sources.sources[id] == src {
+				delete(c.sources.sources, id) // So that the next Watcher starts a new watch.
+			}
+			c.sources.mutex.Unlock()
+		}()
+	}
+	subscriber := &onChange
+	src.subscribers = append(src.subscribers, subscriber)
+	c.sources.mutex.Unlock()
+	if shared {
+		c.log(ctx, slog.LevelInfo,
+			"Share watching with other loaders of the same source.",
+			slog.Any("loader", watcher),
+			slog.String("source", id),
+		)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-src.done:
+	}
+
+	c.sources.mutex.Lock()
+	src.subscribers = slices.DeleteFunc(src.subscribers, func(s *func(map[string]any)) bool { return s == subscriber })
+	last := len(src.subscribers) == 0
+	if last && c.sources.sources[id] == src {
+		delete(c.sources.sources, id)
+	}
+	c.sources.mutex.Unlock()
+	if !last {
+		select {
+		case <-src.done:
+			return src.err
+		default:
+			return nil // Keep the watch for the other subscribers.
+		}
+	}
+	src.cancel()
+	<-src.done
+
+	return src.err
+}
+
+// fanOut delivers the values to all subscribers of the source.
+// Each subscriber gets its own copy since the values are transformed in place by the layer.
+func (c *Config) fanOut(src *source, values map[string]any) {
+	c.sources.mutex.Lock()
+	subscribers := slices.Clone(src.subscribers)
+	c.sources.mutex.Unlock()
+
+	for i, subscriber := range subscribers {
+		if i == len(subscribers)-1 || values == nil {
+			(*subscriber)(values)
+
+			continue
+		}
+		copied := make(map[string]any, len(values))
+		maps.Merge(copied, values)
+		(*subscriber)(copied)
+	}
+}
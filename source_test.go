@@ -0,0 +1,99 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Watch_sharedSource(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithLogHandler(logHandler(buf)))
+	change, delivered := make(chan map[string]any), make(chan struct{})
+	first := &sourceWatcher{name: "first", id: "file:///etc/app.json", change: change, delivered: delivered}
+	second := &sourceWatcher{name: "second", id: "file:///etc/app.json", change: change, delivered: delivered}
+	assert.NoError(t, config.Load(first))
+	assert.NoError(t, config.Load(second))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+	// Wait for the watch started by one loader, and joined by the other.
+	waitLog(t, buf, "Share watching with other loaders of the same source.", 1)
+	for first.watches.Load()+second.watches.Load() == 0 {
+		runtime.Gosched()
+	}
+
+	assert.Equal(t, int32(1), first.watches.Load()+second.watches.Load())
+	owner, sharer := first, second
+	if second.watches.Load() == 1 {
+		owner, sharer = second, first
+	}
+	assert.True(t, strings.Contains(buf.String(),
+		`level=INFO msg="Share watching with other loaders of the same source." loader=`+sharer.name+
+			" source=file:///etc/app.json",
+	))
+
+	change <- map[string]any{"k": "v1"}
+	<-delivered
+	assert.Equal(t, "k has value[v1] that is loaded by loader[second].\n"+
+		"Here are other value(loader)s:\n  - v1(first)\n\n", config.Explain("k"))
+
+	// Unloading the watcher which started the watch keeps it running for the other.
+	assert.NoError(t, config.Unload(owner))
+	change <- map[string]any{"k": "v2"}
+	<-delivered
+	assert.Equal(t, "k has value[v2] that is loaded by loader["+sharer.name+"].\n\n", config.Explain("k"))
+	assert.Equal(t, int32(1), first.watches.Load()+second.watches.Load())
+}
+
+type sourceWatcher struct {
+	name      string
+	id        string
+	watches   atomic.Int32
+	change    chan map[string]any
+	delivered chan struct{}
+}
+
+func (s *sourceWatcher) Load() (map[string]any, error) {
+	return map[string]any{"k": "v0"}, nil
+}
+
+func (s *sourceWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	s.watches.Add(1)
+	for {
+		select {
+		case values := <-s.change:
+			onChange(values)
+			s.delivered <- struct{}{}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *sourceWatcher) SourceID() string {
+	return s.id
+}
+
+func (s *sourceWatcher) String() string {
+	return s.name
+}
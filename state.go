@@ -0,0 +1,363 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StateVersion is the version of the state format written by Config.ExportState.
+// It's increased whenever the format changes incompatibly, and konf.ImportState
+// rejects the state with a different version, e.g. version 1 whose fingerprints are not tagged with the kind of value,
+// or version 2 which exports []byte as the string with "base64:" prefix.
+const StateVersion = 3
+
+const stateFormat = "konf.state"
+
+type (
+	// state is the format written by Config.ExportState.
+	state struct {
+		Format string `json:"format"`
+		// Version is the version of the format, see StateVersion.
+		Version int `json:"version"`
+		// ConfigVersion is the number of changes that have been applied by Config.Watch.
+		ConfigVersion uint64 `json:"config_version"`
+		// Fingerprint is the same as Config.Fingerprint of the merged values.
+		Fingerprint string `json:"fingerprint"`
+		// Values are the merged values with the original keys.
+		Values map[string]any `json:"values"`
+		// Layers are the values of loaders, from the lowest to the highest precedence.
+		Layers []stateLayer `json:"layers"`
+		// Temporaries are the values set by Config.Set and Config.SetTemporary, sorted by path.
+		// The values set by Config.Set have zero expiry time.
+		Temporaries []stateTemporary `json:"temporaries,omitempty"`
+	}
+	stateLayer struct {
+		Loader      string         `json:"loader"`
+		Defaults    bool           `json:"defaults,omitempty"`
+		Fingerprint string         `json:"fingerprint"`
+		Values      map[string]any `json:"values"`
+	}
+	stateTemporary struct {
+		Path    string    `json:"path"`
+		Expires time.Time `json:"expires"`
+		Value   any       `json:"value"`
+	}
+
+	// imported is the loader of the layer imported by konf.ImportState.
+	imported struct {
+		name   string
+		values map[string]any
+	}
+)
+
+// ExportState writes the state of the Config as JSON into the given writer, e.g. for handing off
+// the effective configuration to the new process in zero-downtime restarts, which reads it with konf.ImportState.
+// The state includes the merged values, the values of each loader, the number of changes and the fingerprints.
+// The disabled loaders are not included since they do not take effect.
+// The values set by Config.Set are included, and the temporary values set by Config.SetTemporary
+// are included with their expiry time.
+//
+// The sensitive values are NOT blurred, so the state must be passed via the trusted channel.
+// The values which are not JSON types are exported as the JSON types which Config.Unmarshal decodes
+// into the same values, i.e. the named numbers as numbers, e.g. time.Duration as nanoseconds,
+// []byte as the object {"$bytes": "<base64>"}, and the typed slices as arrays. The map whose only key
+// starts with "$" is exported wrapped as {"$map": {...}}, so that it never is confused with the tagged object.
+// It returns error if any value has other types, e.g. time.Time, since it cannot be imported as it is.
+//
+// This method is concurrent-safe.
+func (c *Config) ExportState(w io.Writer) error {
+	if c == nil { // To support nil
+		c = &Config{}
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.ExportState(w)
+	}
+
+	values, err := stateValues(c.providers.sub(nil))
+	if err != nil {
+		return fmt.Errorf("export state: %w", err)
+	}
+	exported := state{
+		Format:        stateFormat,
+		Version:       StateVersion,
+		ConfigVersion: c.version.Load(),
+		Fingerprint:   c.Fingerprint(),
+		Values:        values,
+	}
+	var errs []error
+	c.providers.traverse(func(provider *provider) {
+		if provider.disabled.Load() {
+			return
+		}
+		values := *provider.values.Load()
+		layer, e := stateValues(values)
+		if e != nil {
+			errs = append(errs, fmt.Errorf("layer %s: %w", provider.loader, e))
+
+			return
+		}
+		exported.Layers = append(exported.Layers, stateLayer{
+			Loader:      fmt.Sprint(provider.loader),
+			Defaults:    isDefaults(provider.loader),
+			Fingerprint: fingerprint(values, c.delim()),
+			Values:      layer,
+		})
+	})
+	c.temporaries.mutex.Lock()
+	for _, tmp := range c.temporaries.temporaries {
+		value, e := stateValue(tmp.path, original(tmp.value))
+		if e != nil {
+			errs = append(errs, e)
+
+			continue
+		}
+		exported.Temporaries = append(exported.Temporaries, stateTemporary{
+			Path:    tmp.path,
+			Expires: tmp.expires,
+			Value:   value,
+		})
+	}
+	c.temporaries.mutex.Unlock()
+	if e := errors.Join(errs...); e != nil {
+		return fmt.Errorf("export state: %w", e)
+	}
+	slices.SortFunc(exported.Temporaries, func(a, b stateTemporary) int { return strings.Compare(a.Path, b.Path) })
+
+	if err := json.NewEncoder(w).Encode(exported); err != nil {
+		return fmt.Errorf("export state: %w", err)
+	}
+
+	return nil
+}
+
+// ImportState creates a new Config with the given Option(s) from the state written by Config.ExportState,
+// which has the same merged values and the same number of changes as the exporting Config.
+// It returns error if the state is malformed, its version is newer than StateVersion,
+// or the imported values do not have the same fingerprints as the exporting Config,
+// e.g. the exporting Config has konf.WithValueNormalizer which is not provided.
+//
+// The values set by Config.Set are set again, and the temporary values are set again with the remaining ttl,
+// while the expired ones are dropped.
+//
+// Each imported layer takes the precedence of its loader, and Config.Explain shows loader[<loader>] for its values.
+// Once a loader with the same string representation is loaded by Config.Load, it replaces the imported layer
+// in place, and the callbacks registered by Config.OnChange are executed for the paths whose value has been changed
+// by the fresh values if Config.Watch has been called.
+func ImportState(r io.Reader, opts ...Option) (*Config, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var decoded state
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("import state: %w", err)
+	}
+	switch {
+	case decoded.Format != stateFormat:
+		return nil, fmt.Errorf("import state: unknown format %q", decoded.Format) //nolint:err113
+	case decoded.Version < 1:
+		return nil, fmt.Errorf("import state: invalid version %d", decoded.Version) //nolint:err113
+	case decoded.Version < StateVersion:
+		return nil, fmt.Errorf( //nolint:err113
+			"import state: version %d is older than the supported version %d", decoded.Version, StateVersion,
+		)
+	case decoded.Version > StateVersion:
+		return nil, fmt.Errorf( //nolint:err113
+			"import state: version %d is newer than the supported version %d", decoded.Version, StateVersion,
+		)
+	}
+
+	config := New(opts...)
+	var errs []error
+	for _, layer := range decoded.Layers {
+		values, _ := importValue(layer.Values).(map[string]any)
+		if values == nil {
+			values = make(map[string]any)
+		}
+		var loader Loader = imported{name: layer.Loader, values: values}
+		if layer.Defaults {
+			loader = Defaults(values)
+		}
+		provider := config.newProvider(loader)
+		provider.imported = true
+		if err := config.apply(provider, values); err != nil {
+			return nil, fmt.Errorf("import state: import layer %s: %w", layer.Loader, err)
+		}
+		if actual := fingerprint(*provider.values.Load(), config.delim()); actual != layer.Fingerprint {
+			errs = append(errs, fmt.Errorf( //nolint:err113
+				"layer %s has fingerprint %s, expected %s", layer.Loader, actual, layer.Fingerprint,
+			))
+		}
+	}
+	expired := false
+	for _, tmp := range decoded.Temporaries {
+		if tmp.Expires.IsZero() {
+			if err := config.Set(tmp.Path, importValue(tmp.Value)); err != nil {
+				return nil, fmt.Errorf("import state: %w", err)
+			}
+
+			continue
+		}
+		ttl := tmp.Expires.Sub(config.timeSource().Now())
+		if ttl <= 0 {
+			expired = true // The merged values are different from the exporting Config without the expired value.
+
+			continue
+		}
+		if err := config.SetTemporary(tmp.Path, importValue(tmp.Value), ttl); err != nil {
+			return nil, fmt.Errorf("import state: %w", err)
+		}
+	}
+	if actual := config.Fingerprint(); !expired && actual != decoded.Fingerprint {
+		errs = append(errs, fmt.Errorf( //nolint:err113
+			"merged values have fingerprint %s, expected %s", actual, decoded.Fingerprint,
+		))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("import state: %w", err)
+	}
+	config.version.Store(decoded.ConfigVersion)
+
+	return config, nil
+}
+
+func (i imported) Load() (map[string]any, error) {
+	return i.values, nil
+}
+
+func (i imported) String() string {
+	return i.name
+}
+
+// stateValues returns the copy of the values with the original keys, where the values which are not JSON types
+// are converted into the JSON types which konf.ImportState restores into the values with the same fingerprint,
+// e.g. []byte into the tagged object {"$bytes": "<base64>"}. It returns error for the value which cannot be converted.
+func stateValues(values any) (map[string]any, error) {
+	exported, err := stateValue("", original(values))
+	if err != nil {
+		return nil, err
+	}
+	exportedValues, _ := exported.(map[string]any)
+
+	return exportedValues, nil
+}
+
+func stateValue(path string, value any) (any, error) { //nolint:cyclop
+	switch value := value.(type) {
+	case nil, string, bool, json.Number:
+		return value, nil
+	case map[string]any:
+		values := make(map[string]any, len(value))
+		for key, val := range value {
+			exported, err := stateValue(joinStatePath(path, key), val)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = exported
+		}
+		if len(values) == 1 {
+			for key := range values {
+				if strings.HasPrefix(key, "$") {
+					return map[string]any{mapTag: values}, nil // Escape the map which looks like the tagged object.
+				}
+			}
+		}
+
+		return values, nil
+	}
+
+	switch val := reflect.ValueOf(value); val.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return val.String(), nil
+	case reflect.Bool:
+		return val.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), nil // Including the named number, e.g. time.Duration.
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), nil
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, val.Len())
+			reflect.Copy(reflect.ValueOf(bytes), val)
+
+			return map[string]any{bytesTag: base64.StdEncoding.EncodeToString(bytes)}, nil
+		}
+		values := make([]any, 0, val.Len())
+		for i := range val.Len() {
+			exported, err := stateValue(fmt.Sprintf("%s[%d]", path, i), val.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, exported)
+		}
+
+		return values, nil
+	default:
+		return nil, fmt.Errorf("value of %s has unsupported type %T", path, value) //nolint:err113
+	}
+}
+
+func joinStatePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// The keys of the tagged objects in the exported values, see Config.ExportState.
+const (
+	bytesTag = "$bytes"
+	mapTag   = "$map"
+)
+
+// importValue returns the value with json.Number converted into int64 if possible, otherwise float64,
+// the tagged object {"$bytes": "<base64>"} converted into []byte, and the map wrapped as {"$map": {...}} unwrapped.
+func importValue(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if number, err := value.Int64(); err == nil {
+			return number
+		}
+		if number, err := value.Float64(); err == nil {
+			return number
+		}
+
+		return value.String()
+	case map[string]any:
+		if len(value) == 1 {
+			if encoded, ok := value[bytesTag].(string); ok {
+				if bytes, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+					return bytes
+				}
+			}
+			if wrapped, ok := value[mapTag].(map[string]any); ok {
+				value = wrapped
+			}
+		}
+		for key, val := range value {
+			value[key] = importValue(val)
+		}
+
+		return value
+	case []any:
+		for i, val := range value {
+			value[i] = importValue(val)
+		}
+
+		return value
+	default:
+		return value
+	}
+}
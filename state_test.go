@@ -0,0 +1,219 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestImportState(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Host    string
+		Port    int
+		Timeout time.Duration
+		Tags    []string
+		Ratio   float64
+	}
+
+	exporting := konf.New()
+	assert.NoError(t, exporting.Load(konf.Defaults(map[string]any{"server": map[string]any{"port": 80, "ratio": 0.5}})))
+	assert.NoError(t, exporting.Load(mapLoader{
+		"server": map[string]any{"Host": "localhost", "timeout": "1s", "tags": []string{"a", "b"}},
+	}))
+	var buf bytes.Buffer
+	assert.NoError(t, exporting.ExportState(&buf))
+
+	config, err := konf.ImportState(&buf, konf.WithLogHandler(logHandler(&buffer{})))
+	assert.NoError(t, err)
+	assert.Equal(t, exporting.Fingerprint(), config.Fingerprint())
+	assert.True(t, konf.Equal(exporting, config))
+	assert.Equal(t, "[defaults map]", fmt.Sprint(config.Precedence()))
+	var value Server
+	assert.NoError(t, config.Unmarshal("server", &value))
+	expected := Server{Host: "localhost", Port: 80, Timeout: time.Second, Tags: []string{"a", "b"}, Ratio: 0.5}
+	assert.Equal(t, expected, value)
+	assert.Equal(t, "server.host has value[localhost] that is loaded by loader[map].\n\n", config.Explain("server.host"))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The live loader replaces the imported layer with the same string representation.
+	var changes []string
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) {
+		changes = append(changes, "host")
+		changed <- struct{}{}
+	}, "server.host")
+	config.OnChange(func(*konf.Config) { changes = append(changes, "timeout") }, "server.timeout")
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"host": "example.com", "timeout": "1s", "tags": "a,b"}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+	<-changed
+	assert.Equal(t, []string{"host"}, changes)
+	assert.Equal(t, "[defaults map]", fmt.Sprint(config.Precedence()))
+	assert.NoError(t, config.Unmarshal("server", &value))
+	expected.Host = "example.com"
+	assert.Equal(t, expected, value)
+
+	watcher.change <- map[string]any{"server": map[string]any{"host": "konf.dev"}}
+	<-changed
+	assert.Equal(t, "server.host has value[konf.dev] that is loaded by loader[map].\n\n", config.Explain("server.host"))
+}
+
+func TestImportState_version(t *testing.T) {
+	t.Parallel()
+
+	exporting := konf.New()
+	assert.NoError(t, exporting.Load(mapLoader{"k": "v"}))
+	var buf bytes.Buffer
+	assert.NoError(t, exporting.ExportState(&buf))
+	var state map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &state))
+	assert.Equal[any](t, float64(konf.StateVersion), state["version"])
+
+	state["config_version"] = 3
+	encoded, err := json.Marshal(state)
+	assert.NoError(t, err)
+	config, err := konf.ImportState(bytes.NewReader(encoded))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), config.DebugState().Version)
+}
+
+func TestImportState_error(t *testing.T) {
+	t.Parallel()
+
+	exporting := konf.New()
+	assert.NoError(t, exporting.Load(mapLoader{"k": "v"}))
+	var buf bytes.Buffer
+	assert.NoError(t, exporting.ExportState(&buf))
+	exported := buf.String()
+
+	testcases := []struct {
+		description string
+		state       string
+		err         string
+	}{
+		{
+			description: "malformed",
+			state:       "{",
+			err:         "import state: unexpected EOF",
+		},
+		{
+			description: "unknown format",
+			state:       `{"format": "other", "version": 1}`,
+			err:         `import state: unknown format "other"`,
+		},
+		{
+			description: "invalid version",
+			state:       `{"format": "konf.state"}`,
+			err:         "import state: invalid version 0",
+		},
+		{
+			description: "newer version",
+			state:       strings.Replace(exported, `"version":3`, `"version":4`, 1),
+			err:         "import state: version 4 is newer than the supported version 3",
+		},
+		{
+			description: "older version",
+			state:       strings.Replace(exported, `"version":3`, `"version":2`, 1),
+			err:         "import state: version 2 is older than the supported version 3",
+		},
+		{
+			description: "tampered values",
+			state:       strings.Replace(exported, `"values":{"k":"v"}}]`, `"values":{"k":"x"}}]`, 1),
+			err: "import state: layer map has fingerprint 6a2bd810fbddc493, expected 54a38a39e5f37e5d\n" +
+				"merged values have fingerprint 6a2bd810fbddc493, expected 54a38a39e5f37e5d",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := konf.ImportState(strings.NewReader(testcase.state))
+			assert.EqualError(t, err, testcase.err)
+		})
+	}
+}
+
+func TestImportState_types(t *testing.T) {
+	t.Parallel()
+
+	exporting := konf.New()
+	assert.NoError(t, exporting.Load(mapLoader{
+		"key":     []byte("hi"),
+		"timeout": 2 * time.Second,
+		"ports":   []int{80, 443},
+	}))
+	var buf bytes.Buffer
+	assert.NoError(t, exporting.ExportState(&buf))
+	assert.True(t, strings.Contains(buf.String(), `"key":{"$bytes":"aGk="}`))
+	assert.True(t, strings.Contains(buf.String(), `"timeout":2000000000`))
+
+	config, err := konf.ImportState(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, exporting.Fingerprint(), config.Fingerprint())
+	key, err := konf.GetValue[[]byte](config, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hi"), key)
+	assert.Equal(t, 2*time.Second, config.GetDuration("timeout"))
+	ports, err := konf.GetValue[[]int](config, "ports")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{80, 443}, ports)
+}
+
+func TestImportState_tagLike(t *testing.T) {
+	t.Parallel()
+
+	// The values which look like the encoded []byte keep their types on the round trip.
+	values := map[string]any{
+		"a":      "base64:aGVsbG8=",
+		"bytes":  map[string]any{"$bytes": "aGk="},
+		"map":    map[string]any{"$map": map[string]any{"k": "v"}},
+		"nested": map[string]any{"$map": map[string]any{"$bytes": "aGk="}},
+	}
+	exporting := konf.New()
+	assert.NoError(t, exporting.Load(mapLoader(values)))
+	var buf bytes.Buffer
+	assert.NoError(t, exporting.ExportState(&buf))
+
+	config, err := konf.ImportState(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, exporting.Fingerprint(), config.Fingerprint())
+	var imported map[string]any
+	assert.NoError(t, config.Unmarshal("", &imported))
+	assert.Equal(t, values, imported)
+}
+
+func TestImportState_unsupported(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	unsupported := konf.New()
+	assert.NoError(t, unsupported.Load(mapLoader{"server": map[string]any{"started": time.Time{}}}))
+	assert.EqualError(t, unsupported.ExportState(&buf),
+		"export state: value of server.started has unsupported type time.Time")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+// Status is the health snapshot of a Config.
+// It never contains any configuration value.
+type Status struct {
+	// Loaders are the states of loaders, from the lowest to the highest precedence.
+	Loaders []LoaderState
+	// RestartPending reports whether there are changes requiring restart which have not been acknowledged
+	// by Config.AckRestart. It requires konf.WithRestartRequired.
+	RestartPending bool
+	// RestartKeys are the changed keys requiring restart, sorted.
+	RestartKeys []string
+	// Flapping are the keys whose values are flapping, sorted. It requires konf.WithFlapDetection.
+	Flapping []string
+}
+
+// Status returns the health snapshot of the Config.
+//
+// This method is concurrent-safe.
+func (c *Config) Status() Status {
+	if c == nil { // To support nil
+		return Status{}
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Status()
+	}
+
+	status := Status{Loaders: c.loaderStates()}
+	if keys := c.restart.pending(); len(keys) > 0 {
+		status.RestartPending = true
+		status.RestartKeys = keys
+	}
+	if c.flapDetection != nil {
+		status.Flapping = c.flaps.flapping(*c.flapDetection, c.timeSource().Now())
+	}
+
+	return status
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Strict makes Config.Unmarshal return ErrUnknownKeys if the configuration has keys
+// which do not match any field of the target struct, the same as konf.WithStrictUnmarshal but for a single call.
+func Strict() UnmarshalOption {
+	return func(options *unmarshalOptions) {
+		options.strict = true
+	}
+}
+
+// ErrUnknownKeys is the error returned by Config.Unmarshal in strict mode
+// if the configuration has keys which do not match any field of the target struct.
+// The error message lists the full paths of the keys, e.g. `unknown keys: server.tiemout, db.hosts[0].prot`.
+var ErrUnknownKeys = errors.New("unknown keys")
+
+// checkUnknownKeys returns ErrUnknownKeys with the full paths of the keys in the value
+// which do not match any field of the struct pointed to by target.
+// The keys under maps with non-struct values and interfaces without registered implementations
+// are always accepted, and the keys for registered interfaces are checked against the chosen implementation.
+func (c *Config) checkUnknownKeys(path string, value any, target any) error {
+	var unknown []string
+	c.unknownKeys(strings.Join(c.splitPath(path), c.delim()), value, reflect.TypeOf(target),
+		func(path string, _ any) { unknown = append(unknown, path) },
+	)
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+
+	return fmt.Errorf("%w: %s", ErrUnknownKeys, strings.Join(unknown, ", "))
+}
+
+// unknownKeys calls unknown with the path and value of the keys in the value
+// which do not match any field of the given type.
+func (c *Config) unknownKeys(path string, value any, typ reflect.Type, unknown func(path string, value any)) {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || isTextUnmarshaler(typ) || typ == reflect.TypeFor[Raw]() {
+		return // The value is decoded as a whole.
+	}
+
+	_, value = maps.Unpack(value)
+	switch typ.Kind() {
+	case reflect.Struct:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return // Let the decoder report the mismatched type.
+		}
+		fields := c.structKeys(typ)
+		for key, val := range values {
+			if fieldType, exist := fields[key]; exist {
+				c.unknownKeys(c.joinPath(path, key), val, fieldType, unknown)
+			} else {
+				unknown(c.joinPath(path, key), val)
+			}
+		}
+	case reflect.Map:
+		if values, ok := value.(map[string]any); ok {
+			for key, val := range values {
+				c.unknownKeys(c.joinPath(path, key), val, typ.Elem(), unknown)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if values, ok := value.([]any); ok {
+			for i, val := range values {
+				c.unknownKeys(path+"["+strconv.Itoa(i)+"]", val, typ.Elem(), unknown)
+			}
+		}
+	case reflect.Interface:
+		values, ok := value.(map[string]any)
+		if !ok || typ.NumMethod() == 0 {
+			return // Same as Config.Unmarshal, only non-empty interfaces have registered implementations.
+		}
+		impl, rest, err := c.implement(path, typ, values)
+		if err != nil || impl == nil {
+			return // Interface without implementation accepts any keys, and let the decoder report the error.
+		}
+		c.unknownKeys(path, rest, reflect.TypeOf(impl), unknown)
+	default:
+		// Other kinds have no keys.
+	}
+}
+
+// structKeys returns the key and type of the fields of the struct type,
+// including the fields of the squashed structs.
+func (c *Config) structKeys(typ reflect.Type) map[string]reflect.Type {
+	keys := make(map[string]reflect.Type)
+	c.visitFields(typ, func(field reflect.StructField, key, _ string, _ []string) bool {
+		if _, exist := keys[key]; !exist {
+			keys[key] = field.Type
+		}
+
+		return true
+	})
+
+	return keys
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Unmarshal_strict(t *testing.T) {
+	t.Parallel()
+
+	type (
+		Base struct {
+			Name string
+		}
+		Host struct {
+			Host string
+			Port int
+		}
+		Server struct {
+			Base    `konf:",squash"`
+			Timeout time.Duration `konf:"read_timeout"`
+			Labels  map[string]any
+			Any     any
+		}
+		Config struct {
+			Server Server
+			DB     struct {
+				Hosts []Host
+				Pools map[string]Host
+			}
+		}
+	)
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		path        string
+		unmarshal   []konf.UnmarshalOption
+		values      map[string]any
+		err         string
+	}{
+		{
+			description: "known keys",
+			opts:        []konf.Option{konf.WithStrictUnmarshal()},
+			values: map[string]any{
+				"server": map[string]any{
+					"name":         "konf",
+					"read_timeout": "5s",
+					"labels":       map[string]any{"team": "platform", "nested": map[string]any{"k": "v"}},
+					"any":          map[string]any{"anything": true},
+				},
+				"db": map[string]any{
+					"hosts": []any{map[string]any{"host": "localhost", "port": 5432}},
+					"pools": map[string]any{"main": map[string]any{"host": "localhost"}},
+				},
+			},
+		},
+		{
+			description: "unknown keys",
+			opts:        []konf.Option{konf.WithStrictUnmarshal()},
+			values: map[string]any{
+				"server": map[string]any{"tiemout": "5s", "timeout": "5s"},
+				"db": map[string]any{
+					"hosts": []any{map[string]any{"host": "localhost", "prot": 5432}},
+					"pools": map[string]any{"main": map[string]any{"hots": "localhost"}},
+				},
+			},
+			err: "decode: unknown keys: db.hosts[0].prot, db.pools.main.hots, server.tiemout, server.timeout",
+		},
+		{
+			description: "unknown keys under path",
+			opts:        []konf.Option{konf.WithStrictUnmarshal()},
+			path:        "server",
+			values:      map[string]any{"server": map[string]any{"base": map[string]any{"name": "konf"}}},
+			err:         "decode: unknown keys: server.base",
+		},
+		{
+			description: "per call",
+			unmarshal:   []konf.UnmarshalOption{konf.Strict()},
+			values:      map[string]any{"server": map[string]any{"tiemout": "5s"}},
+			err:         "decode: unknown keys: server.tiemout",
+		},
+		{
+			description: "not strict",
+			values:      map[string]any{"server": map[string]any{"tiemout": "5s"}},
+		},
+		{
+			description: "with fields",
+			opts:        []konf.Option{konf.WithStrictUnmarshal()},
+			unmarshal:   []konf.UnmarshalOption{konf.Fields("Server.Name")},
+			values:      map[string]any{"server": map[string]any{"name": "konf", "tiemout": "5s"}},
+		},
+		{
+			description: "case sensitive",
+			opts:        []konf.Option{konf.WithStrictUnmarshal(), konf.WithCaseSensitive()},
+			values:      map[string]any{"Server": map[string]any{"Name": "konf", "name": "konf"}},
+			err:         "decode: unknown keys: Server.name",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader(testcase.values)))
+
+			var value Config
+			target := any(&value)
+			if testcase.path == "server" {
+				target = &value.Server
+			}
+			err := config.Unmarshal(testcase.path, target, testcase.unmarshal...)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.True(t, errors.Is(err, konf.ErrUnknownKeys))
+				assert.Equal(t, Config{}, value)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_strictMap(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithStrictUnmarshal())
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"tiemout": "5s"}}))
+
+	var value map[string]any
+	assert.NoError(t, config.Unmarshal("", &value))
+	assert.Equal(t, map[string]any{"server": map[string]any{"tiemout": "5s"}}, value)
+}
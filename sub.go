@@ -0,0 +1,109 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+)
+
+// Sub returns the view of the Config rooted at the given path, e.g. `config.Sub("db")` for the database package,
+// so that `sub.Unmarshal("host", &host)` reads `db.host` of the Config.
+// It returns the empty view rather than nil if the path does not exist,
+// and the view reads the values once the path is loaded later.
+//
+// The view reads the values of the Config directly without copying, and the callbacks registered
+// by Config.OnChange and its variants on the view are executed with the view by Config.Watch of the Config,
+// so the view never needs its own Config.Watch. The methods reading values, e.g. Config.Unmarshal,
+// Config.Keys and Config.Fingerprint, are scoped to the path, while the methods reporting the Config
+// as a whole, e.g. Config.Status and Config.DumpState, report the Config.
+// The methods changing the Config, e.g. Config.Load and Config.Set, return error on the view,
+// and must be called on the Config.
+//
+// This method is concurrent-safe.
+func (c *Config) Sub(path string) *Config {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	if c.parent != nil {
+		return &Config{parent: c.parent, prefix: c.parentPath(path)}
+	}
+
+	return &Config{parent: c, prefix: path}
+}
+
+// parentPath returns the path in the parent Config of the given path in the view created by Config.Sub.
+func (c *Config) parentPath(path string) string {
+	if path == "" {
+		return c.prefix
+	}
+
+	return c.parent.joinPath(c.prefix, path)
+}
+
+// parentPaths returns the paths in the parent Config of the given paths in the view created by Config.Sub.
+// The empty paths mean any path under the root of the view.
+func (c *Config) parentPaths(paths []string) []string {
+	if len(paths) == 0 {
+		if c.prefix == "" {
+			return nil
+		}
+
+		return []string{c.prefix}
+	}
+
+	parentPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		parentPaths = append(parentPaths, c.parentPath(path))
+	}
+
+	return parentPaths
+}
+
+// root returns the Config which holds the values, i.e. the parent of the view created by Config.Sub,
+// or the Config itself.
+func (c *Config) root() *Config {
+	if c.parent != nil {
+		return c.parent
+	}
+
+	return c
+}
+
+// values returns the merged values under the root of the Config or the view created by Config.Sub,
+// including the computed keys which compute successfully.
+func (c *Config) values() any {
+	root := c.root()
+	var keys []string
+	if c.parent != nil {
+		keys = root.splitPath(c.prefix)
+	}
+	value, err := root.sub(keys)
+	if err != nil {
+		value = root.providers.sub(keys) // Skip the computed keys which fail to compute.
+	}
+
+	return value
+}
+
+// viewOnChange wraps the callback registered on the view created by Config.Sub,
+// so that it's executed with the view instead of the parent Config.
+func (c *Config) viewOnChange(onChange func(context.Context, *Config)) func(context.Context, *Config) {
+	if onChange == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, _ *Config) { onChange(ctx, c) }
+}
+
+// unregisterOnChange removes the subscription returned by Config.registerOnChangeIn,
+// which is registered in the parent Config if c is the view created by Config.Sub.
+func (c *Config) unregisterOnChange(sub *subscription) {
+	c.root().onChanges.unregister(sub)
+}
+
+// errSubView is returned by the methods changing the Config if they are called on the view created by Config.Sub.
+var errSubView = errors.New("not supported on the view created by Config.Sub")
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_Sub(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"db": map[string]any{"host": "localhost", "port": 5432, "pool": map[string]any{"size": 10}},
+	}))
+
+	sub := config.Sub("DB")
+	var host string
+	assert.NoError(t, sub.Unmarshal("host", &host))
+	assert.Equal(t, "localhost", host)
+	assert.Equal(t, 5432, sub.GetInt("port"))
+	assert.Equal(t, 10, sub.Sub("pool").GetInt("size"))
+	assert.Equal(t, 10, config.Sub("db.pool").GetInt("size"))
+	path, ok := sub.ExistsAny("user", "host")
+	assert.True(t, ok)
+	assert.Equal(t, "host", path)
+	assert.Equal(t, "DB.host has value[localhost] that is loaded by loader[map].\n\n", sub.Explain("host"))
+
+	missing := config.Sub("cache")
+	assert.Equal(t, "", missing.GetString("host"))
+	_, ok = missing.ExistsAny("host")
+	assert.True(t, !ok)
+
+	var nilConfig *konf.Config
+	assert.Equal(t, "", nilConfig.Sub("db").GetString("host"))
+}
+
+func TestConfig_Sub_forward(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"db":      map[string]any{"host": "localhost", "password": "secret"},
+		"tenants": map[string]any{"acme": map[string]any{"db": map[string]any{"host": "acme"}}},
+	}))
+	sub := config.Sub("db")
+
+	assert.Equal(t, []string{"host", "password"}, sub.Keys())
+	assert.Equal(t, map[string]any{"host": "localhost", "password": "secret"}, sub.AllSettings())
+	other := konf.New()
+	assert.NoError(t, other.Load(mapLoader{"host": "localhost", "password": "secret"}))
+	assert.Equal(t, other.Fingerprint(), sub.Fingerprint())
+	assert.Equal(t, len(config.Status().Loaders), len(sub.Status().Loaders))
+	assert.Equal(t, "localhost", sub.KeyInfo("host").Value)
+
+	var host string
+	path, err := sub.UnmarshalFirst([]string{"addr", "host"}, &host)
+	assert.NoError(t, err)
+	assert.Equal(t, "host", path)
+	assert.Equal(t, "localhost", host)
+	assert.NoError(t, sub.UnmarshalFor("acme", "host", &host))
+	assert.Equal(t, "acme", host)
+	password, err := sub.RevealSecret("password", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", password)
+
+	// The methods changing the Config are not supported on the view.
+	assert.EqualError(t, sub.Load(mapLoader{"host": "remote"}),
+		"load configuration: not supported on the view created by Config.Sub")
+	assert.EqualError(t, sub.Set("host", "remote"), "set host: not supported on the view created by Config.Sub")
+	assert.EqualError(t, sub.SetTemporary("host", "remote", time.Minute),
+		"set temporary host: not supported on the view created by Config.Sub")
+	assert.Equal(t, "localhost", config.GetString("db.host"))
+
+	delivered := make(chan *konf.Config, 1)
+	sub.OnChangeWith(func(config *konf.Config) { delivered <- config }, konf.DeliverCurrent())
+	assert.Equal(t, sub, <-delivered)
+}
+
+func TestConfig_Sub_OnChange(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"db": map[string]any{"host": "localhost"}}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	sub := config.Sub("db")
+	hosts, changes := make(chan string, 1), make(chan struct{}, 2)
+	sub.OnChange(func(config *konf.Config) { hosts <- config.GetString("host") }, "host")
+	sub.OnChange(func(*konf.Config) { changes <- struct{}{} })
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"db": map[string]any{"host": "remote"}}
+	assert.Equal(t, "remote", <-hosts)
+	<-changes
+
+	// The change outside the view does not execute the callbacks of the view.
+	watcher.change <- map[string]any{"db": map[string]any{"host": "remote"}, "cache": "redis"}
+	// The changes are dispatched in order, so the unexpected callback if any is executed before this one.
+	watcher.change <- map[string]any{"db": map[string]any{"host": "local"}, "cache": "redis"}
+	assert.Equal(t, "local", <-hosts)
+	<-changes
+	assert.Equal(t, 0, len(changes))
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// LogSummary logs the summary of the configuration sources at INFO level.
+// It logs one line per loader, from the lowest to the highest precedence, with the number of keys it provides,
+// the number of its keys shadowed by the loaders with higher precedence, and the duration of loading.
+// Then it logs one line with the number of keys in the merged configuration and its Config.Fingerprint.
+// It never contains any configuration value.
+//
+// This method is concurrent-safe.
+func (c *Config) LogSummary() {
+	if c == nil { // To support nil
+		return
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		c.parent.LogSummary()
+
+		return
+	}
+
+	c.logSummary(context.Background())
+}
+
+func (c *Config) logSummary(ctx context.Context) {
+	values, _ := c.providers.sub(nil).(map[string]any)
+
+	var providers []*provider
+	c.providers.traverse(func(provider *provider) {
+		if !provider.disabled.Load() {
+			providers = append(providers, provider)
+		}
+	})
+
+	// The key is provided by the loader with the highest precedence which has value for it.
+	keys, wins := 0, make(map[*provider]int, len(providers))
+	c.walk("", values, func(path string) {
+		if path == "" {
+			return // No configuration.
+		}
+		keys++
+		segments := c.splitPath(path)
+		for i := len(providers) - 1; i >= 0; i-- {
+			if maps.Sub(*providers[i].values.Load(), segments) != nil {
+				wins[providers[i]]++
+
+				break
+			}
+		}
+	})
+
+	for _, provider := range providers {
+		provided := 0
+		c.walk("", *provider.values.Load(), func(string) { provided++ })
+		c.log(ctx, slog.LevelInfo,
+			"Configuration is loaded.",
+			slog.Any("loader", provider.loader),
+			slog.Int("keys", provided),
+			slog.Int("shadowed", provided-wins[provider]),
+			slog.Duration("duration", provider.duration),
+		)
+	}
+	c.log(ctx, slog.LevelInfo,
+		"Configuration is merged.",
+		slog.Int("keys", keys),
+		slog.String("fingerprint", c.Fingerprint()),
+	)
+}
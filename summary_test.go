@@ -0,0 +1,67 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestConfig_LogSummary(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	config.LogSummary()
+
+	buf := &buffer{}
+	config = konf.New(konf.WithLogHandler(logHandler(buf)), konf.WithClock(clock.NewFake(time.Time{})))
+	config.LogSummary()
+	assert.Equal(t, `level=INFO msg="Configuration is merged." keys=0 fingerprint=e3b0c44298fc1c14`+"\n", buf.String())
+
+	buf = &buffer{}
+	config = konf.New(konf.WithLogHandler(logHandler(buf)), konf.WithClock(clock.NewFake(time.Time{})))
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "first", "port": 8080}, "password": "secret"}))
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "second"}}))
+	config.LogSummary()
+	expected := `level=INFO msg="Configuration is loaded." loader=map keys=3 shadowed=1 duration=0s
+level=INFO msg="Configuration is loaded." loader=map keys=1 shadowed=0 duration=0s
+level=INFO msg="Configuration is merged." keys=3 fingerprint=e379feb65a364a25
+`
+	assert.Equal(t, expected, buf.String())
+	assert.True(t, !strings.Contains(buf.String(), "secret"))
+}
+
+func TestConfig_Watch_startup_summary(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(
+		konf.WithStartupSummary(),
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithClock(clock.NewFake(time.Time{})),
+	)
+	assert.NoError(t, config.Load(mapLoader{"config": "value"}))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	assert.Equal(t, `level=INFO msg="Configuration is loaded." loader=map keys=1 shadowed=0 duration=0s
+level=INFO msg="Configuration is merged." keys=1 fingerprint=9b99d9d6d4d4231d
+`, buf.String())
+}
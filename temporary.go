@@ -0,0 +1,308 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// TemporaryState is the state of a temporary value set by Config.SetTemporary in DebugState.
+// It never contains the value.
+type TemporaryState struct {
+	Path string
+	// Expires is the time when the temporary value expires.
+	Expires time.Time
+	// Remaining is the duration until the temporary value expires.
+	Remaining time.Duration
+}
+
+type (
+	// temporaries tracks the values set by Config.Set and Config.SetTemporary, by the split path.
+	temporaries struct {
+		temporaries map[string]*temporary
+		mutex       sync.Mutex
+	}
+	temporary struct {
+		path    string
+		keys    []string
+		value   any
+		expires time.Time // Zero for the value set by Config.Set, which never expires.
+		timer   <-chan time.Time
+		stop    func() bool
+		cancel  chan struct{}
+	}
+)
+
+// temporaryLoader is the Loader shown in Config.Explain for the temporary values.
+type temporaryLoader struct{}
+
+func (temporaryLoader) Load() (map[string]any, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (temporaryLoader) String() string {
+	return "temporary"
+}
+
+// setLoader is the Loader shown in Config.Explain for the values set by Config.Set.
+type setLoader struct{}
+
+func (setLoader) Load() (map[string]any, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (setLoader) String() string {
+	return "set"
+}
+
+// Set sets the value for the given path which takes precedence over all loaders until it's removed
+// by Config.Unset, e.g. for tests and admin tooling. It creates the intermediate maps for the nested path,
+// and the value survives the reloads of all loaders. Setting the path again replaces the value,
+// including the one set by Config.SetTemporary.
+// It returns error if the path is empty or the value is nil.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// The callbacks registered by Config.OnChange are executed for the path
+// whenever its value changes if Config.Watch has been called.
+//
+// This method is concurrent-safe.
+func (c *Config) Set(path string, value any) error {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("set %s: %w", path, errSubView)
+	}
+
+	if strings.Trim(path, c.delim()) == "" {
+		return errors.New("set: empty path") //nolint:err113
+	}
+	if value == nil {
+		return fmt.Errorf("set %s: nil value", path) //nolint:err113
+	}
+	c.override(&temporary{path: path, keys: c.splitPath(path), value: c.overrideValue(value)})
+
+	return nil
+}
+
+// SetTemporary sets the value for the given path which takes precedence over all loaders,
+// and expires after the given ttl, e.g. for the emergency override during the incident.
+// Setting the path again replaces the value and its ttl.
+// It returns error if the path is empty, the value is nil or ttl is not positive.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// Once the value expires or is removed by Config.Unset, the loaded value takes effect again.
+// The expiry is driven by the Clock provided by konf.WithClock, independent of Config.Watch.
+// The callbacks registered by Config.OnChange are executed for the path
+// whenever its value changes if Config.Watch has been called.
+//
+// This method is concurrent-safe.
+func (c *Config) SetTemporary(path string, value any, ttl time.Duration) error {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("set temporary %s: %w", path, errSubView)
+	}
+
+	if strings.Trim(path, c.delim()) == "" {
+		return errors.New("set temporary: empty path") //nolint:err113
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("set temporary %s: ttl %s is not positive", path, ttl) //nolint:err113
+	}
+	if value == nil {
+		return fmt.Errorf("set temporary %s: nil value", path) //nolint:err113
+	}
+
+	timer, stop := c.timeSource().NewTimer(ttl)
+	tmp := &temporary{
+		path:    path,
+		keys:    c.splitPath(path),
+		value:   c.overrideValue(value),
+		expires: c.timeSource().Now().Add(ttl),
+		timer:   timer,
+		stop:    stop,
+		cancel:  make(chan struct{}),
+	}
+	c.override(tmp)
+
+	return nil
+}
+
+// overrideValue returns the copy of the map value with transformed keys, since the caller may modify it.
+func (c *Config) overrideValue(value any) any {
+	values, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	copied := make(map[string]any, len(values))
+	maps.Merge(copied, values)
+	c.transformKeys(copied)
+
+	return copied
+}
+
+// override installs the value set by Config.Set or Config.SetTemporary, which replaces the one with the same path.
+func (c *Config) override(tmp *temporary) {
+	key := strings.Join(tmp.keys, c.delim())
+	c.temporaries.mutex.Lock()
+	if c.temporaries.temporaries == nil {
+		c.temporaries.temporaries = make(map[string]*temporary)
+	}
+	if old := c.temporaries.temporaries[key]; old != nil {
+		old.release()
+	}
+	c.temporaries.temporaries[key] = tmp
+	if tmp.timer != nil {
+		// It starts waiting for the expiry only after tmp is stored,
+		// so that the expiry with short ttl can always find and remove it.
+		go func() {
+			select {
+			case <-tmp.timer:
+				c.expireTemporary(tmp)
+			case <-tmp.cancel:
+			}
+		}()
+	}
+	oldValues, newValues := c.providers.override(c.temporaries.values())
+	c.temporaries.mutex.Unlock()
+	c.remerge(oldValues, newValues)
+}
+
+// Unset removes the value set by Config.Set or Config.SetTemporary for the given path,
+// and the loaded value takes effect again. It has no effect if no value is set for the path.
+//
+// This method is concurrent-safe.
+func (c *Config) Unset(path string) {
+	c.nocopy.Check()
+	if c.parent != nil {
+		return // Nothing is set on the view.
+	}
+
+	key := strings.Join(c.splitPath(path), c.delim())
+	c.temporaries.mutex.Lock()
+	tmp := c.temporaries.temporaries[key]
+	if tmp == nil {
+		c.temporaries.mutex.Unlock()
+
+		return
+	}
+	tmp.release()
+	delete(c.temporaries.temporaries, key)
+	oldValues, newValues := c.providers.override(c.temporaries.values())
+	c.temporaries.mutex.Unlock()
+	c.remerge(oldValues, newValues)
+}
+
+func (c *Config) expireTemporary(tmp *temporary) {
+	key := strings.Join(tmp.keys, c.delim())
+	c.temporaries.mutex.Lock()
+	if c.temporaries.temporaries[key] != tmp {
+		c.temporaries.mutex.Unlock()
+
+		return // It has been replaced or unset.
+	}
+	delete(c.temporaries.temporaries, key)
+	oldValues, newValues := c.providers.override(c.temporaries.values())
+	c.temporaries.mutex.Unlock()
+
+	c.log(context.Background(), slog.LevelInfo,
+		"Temporary configuration has expired.",
+		slog.String("path", tmp.path),
+	)
+	c.remerge(oldValues, newValues)
+}
+
+func (t *temporary) release() {
+	if t.stop == nil {
+		return // Set by Config.Set, which never expires.
+	}
+	t.stop()
+	close(t.cancel)
+}
+
+// loaderAt returns the Loader shown in Config.Explain for the value at the given path, which is setLoader
+// only if all values set for the path or under it are set by Config.Set.
+func (t *temporaries) loaderAt(keys []string) Loader {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, tmp := range t.temporaries {
+		overlap := min(len(keys), len(tmp.keys))
+		if !tmp.expires.IsZero() && slices.Equal(keys[:overlap], tmp.keys[:overlap]) {
+			return temporaryLoader{}
+		}
+	}
+
+	return setLoader{}
+}
+
+// values returns the temporary values as the nested map, where the value of the deeper path
+// takes precedence over the map value of its parent path.
+func (t *temporaries) values() map[string]any {
+	sorted := make([]*temporary, 0, len(t.temporaries))
+	for _, tmp := range t.temporaries {
+		sorted = append(sorted, tmp)
+	}
+	slices.SortFunc(sorted, func(a, b *temporary) int { return slices.Compare(a.keys, b.keys) })
+
+	values := make(map[string]any)
+	for _, tmp := range sorted {
+		value := make(map[string]any)
+		maps.Insert(value, tmp.keys, tmp.value)
+		maps.Merge(values, value)
+	}
+
+	return values
+}
+
+func (c *Config) temporaryStates() []TemporaryState {
+	c.temporaries.mutex.Lock()
+	defer c.temporaries.mutex.Unlock()
+
+	if len(c.temporaries.temporaries) == 0 {
+		return nil
+	}
+	now := c.timeSource().Now()
+	states := make([]TemporaryState, 0, len(c.temporaries.temporaries))
+	for _, tmp := range c.temporaries.temporaries {
+		if tmp.expires.IsZero() {
+			continue // Set by Config.Set, which is not temporary.
+		}
+		states = append(states, TemporaryState{Path: tmp.path, Expires: tmp.expires, Remaining: tmp.expires.Sub(now)})
+	}
+	slices.SortFunc(states, func(a, b TemporaryState) int { return strings.Compare(a.Path, b.Path) })
+
+	return states
+}
+
+// override replaces the temporary values which take precedence over all providers,
+// and returns the old and new merged values.
+func (p *providers) override(values map[string]any) (map[string]any, map[string]any) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var oldValues map[string]any
+	if current := p.values.Load(); current != nil {
+		oldValues = *current
+	}
+	p.overrides = values
+	p.sync()
+
+	return oldValues, *p.values.Load()
+}
+
+// overridden returns the temporary value at the given path, or nil if it's not overridden.
+func (p *providers) overridden(keys []string) any {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return maps.Sub(p.overrides, keys)
+}
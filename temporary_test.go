@@ -0,0 +1,200 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+)
+
+func TestConfig_SetTemporary(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	config := konf.New(konf.WithLogHandler(logHandler(buf)), konf.WithClock(fake))
+	assert.NoError(t, config.Load(mapLoader{"feature": map[string]any{"x": true, "y": true}}))
+
+	values := make(chan bool, 3)
+	config.OnChange(func(config *konf.Config) { values <- config.GetBool("feature.x") }, "feature.x")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	assert.NoError(t, config.SetTemporary("Feature.X", false, time.Minute))
+	assert.Equal(t, false, <-values)
+	assert.Equal(t, "feature.x has value[false] that is loaded by loader[temporary].\n"+
+		"Here are other value(loader)s:\n  - true(map)\n\n", config.Explain("feature.x"))
+	assert.Equal(t, true, config.GetBool("feature.y"))
+
+	fake.Advance(20 * time.Second)
+	expires := now.Add(time.Minute)
+	assert.Equal(t,
+		[]konf.TemporaryState{{Path: "Feature.X", Expires: expires, Remaining: 40 * time.Second}},
+		config.DebugState().Temporaries,
+	)
+	builder := &strings.Builder{}
+	assert.NoError(t, config.DumpState(builder))
+	assert.True(t, strings.Contains(builder.String(),
+		"Temporaries:\n  - Feature.X: expires in 40s at 2025-01-01T00:01:00Z\n",
+	))
+
+	fake.Advance(40 * time.Second)
+	assert.Equal(t, true, <-values)
+	assert.Equal(t, 0, len(config.DebugState().Temporaries))
+	assert.True(t, strings.Contains(buf.String(), `level=INFO msg="Temporary configuration has expired." path=Feature.X`))
+}
+
+func TestConfig_SetTemporary_replace(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	config := konf.New(konf.WithClock(fake))
+	assert.NoError(t, config.Load(mapLoader{"k": "loaded"}))
+
+	assert.NoError(t, config.SetTemporary("k", "first", time.Minute))
+	assert.NoError(t, config.SetTemporary("k", "second", time.Hour))
+	assert.Equal(t, "second", config.GetString("k"))
+	fake.Advance(time.Minute) // The replaced value does not expire the new one.
+	assert.Equal(t, "second", config.GetString("k"))
+
+	config.Unset("K")
+	assert.Equal(t, "loaded", config.GetString("k"))
+	assert.Equal(t, 0, len(config.DebugState().Temporaries))
+	config.Unset("k") // No effect if it's not set.
+}
+
+func TestConfig_SetTemporary_shortTTL(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"k": "loaded"}))
+
+	// The timer may fire before the value is stored, and the value still expires.
+	assert.NoError(t, config.SetTemporary("k", "temporary", time.Nanosecond))
+	deadline := time.Now().Add(time.Second)
+	for config.GetString("k") != "loaded" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, "loaded", config.GetString("k"))
+	assert.Equal(t, 0, len(config.DebugState().Temporaries))
+}
+
+func TestConfig_SetTemporary_error(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.EqualError(t, config.SetTemporary(".", "v", time.Minute), "set temporary: empty path")
+	assert.EqualError(t, config.SetTemporary("k", nil, time.Minute), "set temporary k: nil value")
+	assert.EqualError(t, config.SetTemporary("k", "v", 0), "set temporary k: ttl 0s is not positive")
+}
+
+func TestImportState_temporary(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	exporting := konf.New(konf.WithClock(fake))
+	assert.NoError(t, exporting.Load(mapLoader{"k": "loaded"}))
+	assert.NoError(t, exporting.SetTemporary("k", "temporary", time.Minute))
+	var buf bytes.Buffer
+	assert.NoError(t, exporting.ExportState(&buf))
+
+	config, err := konf.ImportState(bytes.NewReader(buf.Bytes()), konf.WithClock(fake))
+	assert.NoError(t, err)
+	assert.Equal(t, "temporary", config.GetString("k"))
+	assert.Equal(t, time.Minute, config.DebugState().Temporaries[0].Remaining)
+
+	// The expired value is dropped.
+	fake.Advance(time.Minute)
+	config, err = konf.ImportState(bytes.NewReader(buf.Bytes()), konf.WithClock(fake))
+	assert.NoError(t, err)
+	assert.Equal(t, "loaded", config.GetString("k"))
+}
+
+func TestConfig_Set(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{
+		values: map[string]any{"server": map[string]any{"host": "localhost"}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	ports := make(chan int, 3)
+	config.OnChange(func(config *konf.Config) { ports <- config.GetInt("server.http.port") }, "server.http.port")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	assert.NoError(t, config.Set("Server.HTTP.Port", 8080))
+	assert.Equal(t, 8080, <-ports)
+	assert.Equal(t, "localhost", config.GetString("server.host"))
+	assert.Equal(t, "server.http.port has value[8080] that is loaded by loader[set].\n\n", config.Explain("server.http.port"))
+	assert.Equal(t, 0, len(config.DebugState().Temporaries))
+
+	// The value survives the reload of the loader, even if the loader changes the value at the path.
+	watcher.change <- map[string]any{"server": map[string]any{"host": "remote", "http": map[string]any{"port": 80}}}
+	assert.Equal(t, 8080, <-ports)
+	assert.Equal(t, "remote", config.GetString("server.host"))
+
+	config.Unset("server.http.port")
+	assert.Equal(t, 80, <-ports)
+}
+
+func TestConfig_Set_explain(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"k": "loaded"}))
+	assert.NoError(t, config.Set("a.b", "set"))
+	assert.NoError(t, config.SetTemporary("a.c", "temporary", time.Minute))
+	assert.Equal(t, "a.b has value[set] that is loaded by loader[set].\n\n", config.Explain("a.b"))
+	assert.Equal(t, "a.c has value[temporary] that is loaded by loader[temporary].\n\n", config.Explain("a.c"))
+
+	// Set replaces the temporary value.
+	assert.NoError(t, config.Set("a.c", "set"))
+	assert.Equal(t, "a.c has value[set] that is loaded by loader[set].\n\n", config.Explain("a.c"))
+	assert.Equal(t, 0, len(config.DebugState().Temporaries))
+
+	var buf bytes.Buffer
+	assert.NoError(t, config.ExportState(&buf))
+	imported, err := konf.ImportState(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, "set", imported.GetString("a.b"))
+}
+
+func TestConfig_Set_error(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.EqualError(t, config.Set("", "v"), "set: empty path")
+	assert.EqualError(t, config.Set("k", nil), "set k: nil value")
+}
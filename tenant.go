@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import "github.com/nil-go/konf/internal/maps"
+
+// UnmarshalFor reads configuration under the given path for the given tenant
+// and decodes it into the given object pointed to by target.
+// The value under `tenants.<tenant>.<path>` overlays the value under `<path>`:
+// it's deep merged if both are maps, or takes precedence otherwise.
+// On the view created by Config.Sub, e.g. `config.Sub("db")`, they are `db.<path>` and `tenants.<tenant>.db.<path>`.
+// The tenant and path are case-insensitive unless konf.WithCaseSensitive is set.
+//
+// This method is concurrent-safe.
+func (c *Config) UnmarshalFor(tenant, path string, target any) error {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.UnmarshalFor(tenant, c.parentPath(path), target)
+	}
+
+	value, err := c.sub(c.splitPath(path))
+	if err != nil {
+		return err
+	}
+	override, err := c.sub(c.splitPath(c.tenantPath(tenant, path)))
+	if err != nil {
+		return err
+	}
+
+	return c.decode(path, overlay(value, override), target)
+}
+
+// overlay returns the override deep merged into the value if both are maps,
+// or the override if it's not nil, otherwise the value.
+func overlay(value, override any) any {
+	if override == nil {
+		return value
+	}
+	base, baseOK := value.(map[string]any)
+	overrides, overridesOK := override.(map[string]any)
+	if !baseOK || !overridesOK {
+		return override
+	}
+
+	// The values in providers are immutable, so it merges into a new map.
+	merged := make(map[string]any, len(base))
+	maps.Merge(merged, base)
+	maps.Merge(merged, overrides)
+
+	return merged
+}
+
+// OnChangeFor registers a callback function that is executed
+// when the value of any given path for the given tenant changes,
+// either the global value under `<path>` or the tenant's value under `tenants.<tenant>.<path>`.
+// The empty paths mean any path, same as Config.OnChange.
+//
+// The validation of onChange and paths is the same as Config.OnChange.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangeFor(tenant string, onChange func(*Config), paths ...string) {
+	tenantPaths := make([]string, 0, 2*len(paths)) //nolint:mnd
+	for _, path := range paths {
+		tenantPaths = append(tenantPaths, path, c.tenantPath(tenant, path))
+	}
+	c.registerOnChange(onChange, tenantPaths, 2) //nolint:mnd
+}
+
+func (c *Config) tenantPath(tenant, path string) string {
+	return c.joinPath(c.joinPath(tenantsKey, tenant), path)
+}
+
+const tenantsKey = "tenants"
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_UnmarshalFor(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"server": map[string]any{"host": "localhost", "port": 8080, "tls": map[string]any{"enabled": false, "cert": "cert.pem"}},
+		"limit":  10,
+		"tenants": map[string]any{
+			"Acme": map[string]any{
+				"server": map[string]any{"port": 9090, "tls": map[string]any{"enabled": true}},
+				"limit":  100,
+			},
+			"empty": map[string]any{"limit": map[string]any{}},
+		},
+	}))
+
+	testcases := []struct {
+		description string
+		tenant      string
+		path        string
+		expected    any
+	}{
+		{
+			description: "deep merged",
+			tenant:      "acme",
+			path:        "server",
+			expected: map[string]any{
+				"host": "localhost",
+				"port": 9090,
+				"tls":  map[string]any{"enabled": true, "cert": "cert.pem"},
+			},
+		},
+		{
+			description: "overridden",
+			tenant:      "ACME",
+			path:        "limit",
+			expected:    100,
+		},
+		{
+			description: "overridden by different type",
+			tenant:      "empty",
+			path:        "limit",
+			expected:    map[string]any{},
+		},
+		{
+			description: "unknown tenant",
+			tenant:      "unknown",
+			path:        "server.host",
+			expected:    "localhost",
+		},
+		{
+			description: "path only for tenant",
+			tenant:      "acme",
+			path:        "server.tls.enabled",
+			expected:    true,
+		},
+		{
+			description: "non-existing path",
+			tenant:      "acme",
+			path:        "non-existing",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var value any
+			assert.NoError(t, config.UnmarshalFor(testcase.tenant, testcase.path, &value))
+			assert.Equal(t, testcase.expected, value)
+		})
+	}
+}
+
+func TestConfig_UnmarshalFor_immutable(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{
+		"server":  map[string]any{"tls": map[string]any{"cert": "cert.pem"}},
+		"tenants": map[string]any{"acme": map[string]any{"server": map[string]any{"tls": map[string]any{"key": "key.pem"}}}},
+	}))
+
+	var value map[string]any
+	assert.NoError(t, config.UnmarshalFor("acme", "server", &value))
+	assert.Equal(t, map[string]any{"tls": map[string]any{"cert": "cert.pem", "key": "key.pem"}}, value)
+
+	var global map[string]any
+	assert.NoError(t, config.Unmarshal("server", &global))
+	assert.Equal(t, map[string]any{"tls": map[string]any{"cert": "cert.pem"}}, global)
+}
+
+func TestConfig_UnmarshalFor_nil(t *testing.T) {
+	t.Parallel()
+
+	var config *konf.Config
+	var value string
+	assert.NoError(t, config.UnmarshalFor("acme", "server", &value))
+	assert.Equal(t, "", value)
+}
+
+func TestConfig_OnChangeFor(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{
+		values: map[string]any{"limit": 10, "tenants": map[string]any{"acme": map[string]any{"limit": 100}}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	changed := make(chan int)
+	config.OnChangeFor("acme", func(config *konf.Config) {
+		var limit int
+		assert.NoError(t, config.UnmarshalFor("acme", "limit", &limit))
+		changed <- limit
+	}, "limit")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// Change of tenant's value.
+	watcher.change <- map[string]any{"limit": 10, "tenants": map[string]any{"acme": map[string]any{"limit": 200}}}
+	assert.Equal(t, 200, <-changed)
+	// Change of global value.
+	watcher.change <- map[string]any{"limit": 20}
+	assert.Equal(t, 20, <-changed)
+}
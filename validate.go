@@ -0,0 +1,240 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nil-go/konf/internal/convert"
+	"github.com/nil-go/konf/internal/credential"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// validateTags validates the fields of the struct pointed to by target against the `validate` tags,
+// if konf.WithTagValidation is set. The violations of all fields are joined with their paths.
+func (c *Config) validateTags(path string, target any) error {
+	if !c.tagValidation {
+		return nil
+	}
+
+	var errs []error
+	c.validateValue(strings.Join(c.splitPath(path), c.delim()), reflect.ValueOf(target), "", &errs)
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) validateValue(path string, value reflect.Value, tag string, errs *[]error) { //nolint:cyclop
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			break
+		}
+		value = value.Elem()
+	}
+
+	if tag != "" {
+		if err := c.validateRules(path, value, tag); err != nil {
+			*errs = append(*errs, err)
+		}
+	}
+	if !value.IsValid() || value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		return // Nil pointer or interface.
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if !isTextUnmarshaler(value.Type()) {
+			c.validateStruct(path, value, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			c.validateValue(path+"["+strconv.Itoa(i)+"]", value.Index(i), "", errs)
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() == reflect.String {
+			keys := value.MapKeys()
+			slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+			for _, key := range keys {
+				c.validateValue(c.joinPath(path, key.String()), value.MapIndex(key), "", errs)
+			}
+		}
+	default:
+	}
+}
+
+func (c *Config) validateStruct(path string, value reflect.Value, errs *[]error) {
+	c.visitFields(value.Type(), func(field reflect.StructField, key, _ string, _ []string) bool {
+		c.validateValue(c.joinPath(path, key), value.FieldByIndex(field.Index), field.Tag.Get(validateTagName), errs)
+
+		return true
+	})
+}
+
+// validateRules validates the value against the comma separated rules in the tag.
+func (c *Config) validateRules(path string, value reflect.Value, tag string) error {
+	zero := !value.IsValid() || value.IsZero()
+	rules := strings.Split(tag, ",")
+	if zero && slices.Contains(rules, "omitempty") {
+		return nil
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		var err error
+		switch validator, ok := c.validators[name]; {
+		case ok:
+			var val any
+			if value.IsValid() {
+				val = value.Interface()
+			}
+			err = validator(val, param)
+		case name == "" || name == "omitempty":
+		case name == "required":
+			if zero {
+				err = errRequired
+			}
+		case !value.IsValid():
+			continue // Skip other rules for nil pointer.
+		case name == "min" || name == "max":
+			err = convert.CheckBound(value, name, param)
+		case name == "oneof":
+			if formatted := fmt.Sprint(value.Interface()); !slices.Contains(strings.Fields(param), formatted) {
+				err = fmt.Errorf("value %q is not one of [%s]", formatted, param) //nolint:err113
+			}
+		case (name == "url" || name == "ip") && value.Kind() != reflect.String:
+			err = fmt.Errorf("%s only supports string, got %s", name, value.Type()) //nolint:err113
+		case name == "url":
+			if u, e := url.Parse(value.String()); e != nil || u.Scheme == "" || u.Host == "" {
+				err = fmt.Errorf("value %q is not an absolute url", value.String()) //nolint:err113
+			}
+		case name == "ip":
+			if net.ParseIP(value.String()) == nil {
+				err = fmt.Errorf("value %q is not an ip address", value.String()) //nolint:err113
+			}
+		default:
+			err = fmt.Errorf("unknown validator %q", name) //nolint:err113
+		}
+		if err != nil {
+			var val any
+			if value.IsValid() {
+				val = value.Interface()
+			}
+			errs = append(errs, credential.RedactAs(c.blur(path, val), val, fmt.Errorf("'%s' %w", path, err)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateChange validates the configuration with the given values of the provider applied
+// against the groups of konf.WithMutuallyExclusive
+// and the structs registered by Config.Describe with their default values if konf.WithTagValidation is set.
+func (c *Config) validateChange(provider *provider, values map[string]any) error {
+	var defaults map[string]reflect.Value
+	if c.tagValidation {
+		defaults = c.schema.described()
+	}
+	if len(defaults) == 0 && len(c.exclusives) == 0 {
+		return nil
+	}
+
+	if len(c.normalizers) > 0 {
+		values = c.normalizeMap("", values)
+	}
+	merged := c.providers.merged(provider, values)
+	errs := []error{c.checkExclusive(merged)}
+	paths := make([]string, 0, len(defaults))
+	for path := range defaults {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		// Decode into a copy of the registered struct so that the fields absent from the values
+		// keep the defaults as Config.Unmarshal does for the caller.
+		target := reflect.New(defaults[path].Type())
+		target.Elem().Set(defaults[path])
+		if err := c.decode(path, maps.Sub(merged, c.splitPath(path)), target.Interface()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateAndStore stores the values into the provider if they are valid, see Config.validateChange.
+// It holds the lock across the validation and the store, so that the concurrent changes
+// can not pass the validation against the values before each other, e.g. set exclusive paths together.
+//
+// If stale is not nil, the values are discarded with errStaleWatcher if it returns true under the lock,
+// e.g. the values from the watcher stopped by Config.Disable.
+func (c *Config) validateAndStore(
+	provider *provider, values map[string]any, stale func() bool,
+) (map[string]any, map[string]any, error) {
+	c.applyMutex.Lock()
+	defer c.applyMutex.Unlock()
+
+	if stale != nil && stale() {
+		return nil, nil, errStaleWatcher
+	}
+	if err := c.validateChange(provider, values); err != nil {
+		return nil, nil, err
+	}
+	oldValues, newValues := c.store(provider, values)
+
+	return oldValues, newValues, nil
+}
+
+var errStaleWatcher = errors.New("watcher has been stopped")
+
+// checkExclusive checks that at most one path of each group in konf.WithMutuallyExclusive is set
+// in the given values, see isSet.
+func (c *Config) checkExclusive(values map[string]any) error {
+	var errs []error
+	for _, group := range c.exclusives {
+		var set []string
+		for _, path := range group {
+			if isSet(maps.Sub(values, c.splitPath(path))) {
+				set = append(set, path)
+			}
+		}
+		if len(set) > 1 {
+			errs = append(errs, fmt.Errorf( //nolint:err113
+				"mutually exclusive keys are set together: %s", strings.Join(set, ", "),
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isSet reports whether the value is not zero. The string is zero if it's empty,
+// or the zero value of bool or number, e.g. "false" or "0" from environment variables.
+func isSet(value any) bool {
+	if value == nil {
+		return false
+	}
+	if str, ok := value.(string); ok {
+		if b, err := strconv.ParseBool(str); err == nil {
+			return b
+		}
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f != 0
+		}
+
+		return str != ""
+	}
+
+	return !reflect.ValueOf(value).IsZero()
+}
+
+const validateTagName = "validate"
+
+var errRequired = errors.New("is required")
@@ -0,0 +1,237 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+type (
+	validatedOptions struct {
+		ValidatedCommon `konf:",squash"`
+		Port            int           `validate:"min=1,max=65535"`
+		Timeout         time.Duration `validate:"min=1s"`
+		Mode            string        `validate:"oneof=dev prod"`
+		Endpoint        string        `validate:"omitempty,url"`
+		Hosts           []validatedHost
+		Labels          map[string]string `validate:"max=2"`
+		TLS             *validatedTLS
+		Password        string `validate:"min=8"`
+	}
+	ValidatedCommon struct {
+		Name string `validate:"required"`
+	}
+	validatedHost struct {
+		IP string `validate:"ip"`
+	}
+	validatedTLS struct {
+		Cert string `validate:"required"`
+	}
+)
+
+func TestConfig_Unmarshal_validate(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		values      map[string]any
+		opts        []konf.Option
+		err         string
+	}{
+		{
+			description: "valid",
+			values: map[string]any{
+				"name": "app", "port": 8080, "timeout": "2s", "mode": "prod",
+				"hosts": []any{map[string]any{"ip": "127.0.0.1"}}, "password": "password",
+			},
+			opts: []konf.Option{konf.WithTagValidation()},
+		},
+		{
+			description: "invalid",
+			values: map[string]any{
+				"port": 0, "timeout": "10ms", "mode": "test", "endpoint": "localhost",
+				"hosts":    []any{map[string]any{"ip": "127.0.0.1"}, map[string]any{"ip": "localhost"}},
+				"labels":   map[string]any{"a": "a", "b": "b", "c": "c"},
+				"tls":      map[string]any{},
+				"password": "secret",
+			},
+			opts: []konf.Option{konf.WithTagValidation()},
+			err: `validate: 'name' is required
+'port' value 0 is less than min 1
+'timeout' value 10ms is less than min 1s
+'mode' value "test" is not one of [dev prod]
+'endpoint' value "localhost" is not an absolute url
+'hosts[1].ip' value "localhost" is not an ip address
+'labels' value length 3 is greater than max 2
+'tls.cert' is required
+'password' value length 6 is less than min 8`,
+		},
+		{
+			description: "custom validator",
+			values: map[string]any{
+				"name": "app", "port": 8080, "timeout": "2s", "mode": "dev", "password": "password",
+			},
+			opts: []konf.Option{
+				konf.WithTagValidation(),
+				konf.WithValidator("oneof", func(value any, param string) error {
+					if value != "dev" {
+						return nil
+					}
+
+					return errors.New("dev is not allowed, expected " + strings.ReplaceAll(param, " ", "|"))
+				}),
+			},
+			err: `validate: 'mode' dev is not allowed, expected dev|prod`,
+		},
+		{
+			description: "disabled",
+			values:      map[string]any{"port": 0},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(testcase.opts...)
+			assert.NoError(t, config.Load(mapLoader(testcase.values)))
+			var value validatedOptions
+			err := config.Unmarshal("", &value)
+			if testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testcase.err)
+			}
+		})
+	}
+}
+
+func TestConfig_Unmarshal_validate_path(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithTagValidation())
+	assert.NoError(t, config.Load(mapLoader{"servers": map[string]any{"a": map[string]any{"cert": "cert.pem"}, "b": map[string]any{}}}))
+
+	var value map[string]validatedTLS
+	err := config.Unmarshal("Servers", &value)
+	assert.EqualError(t, err, "validate: 'servers.b.cert' is required")
+
+	var missing validatedTLS
+	err = config.Unmarshal("tls", &missing)
+	assert.EqualError(t, err, "validate: 'tls.cert' is required")
+}
+
+func TestConfig_Watch_validate(t *testing.T) {
+	t.Parallel()
+
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithTagValidation(),
+		konf.WithLogHandler(logHandler(&buffer{})),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) {
+			statuses <- err
+		}),
+	)
+	watcher := mapWatcher{values: map[string]any{"server": map[string]any{"port": 8080}}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+	// The workers is absent from the watcher, so the validation must take the default from the registered struct.
+	assert.NoError(t, config.Describe("server", &struct {
+		Port    int `validate:"min=1"`
+		Workers int `validate:"min=1"`
+	}{Workers: 4}))
+
+	changed := make(chan int)
+	config.OnChange(func(config *konf.Config) {
+		var port int
+		assert.NoError(t, config.Unmarshal("server.port", &port))
+		changed <- port
+	})
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"server": map[string]any{"port": 0}}
+	assert.EqualError(t, <-statuses, "validate: 'server.port' value 0 is less than min 1")
+	var port int
+	assert.NoError(t, config.Unmarshal("server.port", &port))
+	assert.Equal(t, 8080, port)
+
+	watcher.change <- map[string]any{"server": map[string]any{"port": 9090}}
+	assert.Equal(t, 9090, <-changed)
+}
+
+func TestConfig_Load_mutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(
+		konf.WithMutuallyExclusive("cache.memory", "cache.redis", "cache.file"),
+		konf.WithMutuallyExclusive("single"),
+	)
+	assert.NoError(t, config.Load(mapLoader{"cache": map[string]any{"memory": true, "redis": false}}))
+	assert.NoError(t, config.Load(mapLoader{"cache": map[string]any{"redis": "false", "file": "0"}}))
+	err := config.Load(mapLoader{"Cache": map[string]any{"Redis": map[string]any{"addr": "localhost:6379"}}})
+	assert.EqualError(t, err, "load configuration: mutually exclusive keys are set together: cache.memory, cache.redis")
+
+	var redis any
+	assert.NoError(t, config.Unmarshal("cache.redis", &redis))
+	assert.Equal(t, "false", redis)
+}
+
+func TestConfig_Watch_mutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	statuses := make(chan error, 1)
+	config := konf.New(
+		konf.WithMutuallyExclusive("cache.memory", "cache.redis"),
+		konf.WithLogHandler(logHandler(&buffer{})),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) {
+			statuses <- err
+		}),
+	)
+	watcher := mapWatcher{values: map[string]any{"cache": map[string]any{"memory": true}}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { changed <- struct{}{} })
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"cache": map[string]any{"memory": true, "redis": true}}
+	assert.EqualError(t, <-statuses, "mutually exclusive keys are set together: cache.memory, cache.redis")
+	var cache map[string]any
+	assert.NoError(t, config.Unmarshal("cache", &cache))
+	assert.Equal(t, map[string]any{"memory": true}, cache)
+
+	watcher.change <- map[string]any{"cache": map[string]any{"redis": true}}
+	<-changed
+	cache = nil
+	assert.NoError(t, config.Unmarshal("cache", &cache))
+	assert.Equal(t, map[string]any{"redis": true}, cache)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Pair is the path and the destination of the value for Config.UnmarshalMany.
+type Pair struct {
+	Path string
+	Out  any
+}
+
+// UnmarshalMany decodes the values under the paths of the given pairs into their destinations, the same as
+// Config.Unmarshal, but all values are decoded from the same snapshot of the configuration,
+// so that the related keys are never observed from different changes.
+// It decodes all pairs even if any of them fails, and returns the errors annotated with their paths.
+//
+// This method is concurrent-safe.
+func (c *Config) UnmarshalMany(pairs ...Pair) error {
+	return c.View(func(reader Reader) error {
+		var errs []error
+		for _, pair := range pairs {
+			if err := reader.Unmarshal(pair.Path, pair.Out); err != nil {
+				errs = append(errs, fmt.Errorf("unmarshal %s: %w", pair.Path, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// Reader reads the pinned snapshot of the configuration in Config.View.
+type Reader interface {
+	// Unmarshal is the same as Config.Unmarshal, but reads the pinned snapshot.
+	Unmarshal(path string, target any) error
+	// Exists reports whether the given path exists in the pinned snapshot.
+	Exists(path string) bool
+}
+
+// View executes the given function with the Reader of the pinned snapshot of the configuration,
+// so that all reads in the function are consistent even if the configuration changes concurrently.
+// The Reader must not be used after the function returns.
+//
+// This method is concurrent-safe.
+func (c *Config) View(view func(Reader) error) error {
+	if c == nil { // To support nil
+		return view(snapshot{})
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.View(func(reader Reader) error {
+			return view(subSnapshot{reader: reader, config: c})
+		})
+	}
+
+	return view(snapshot{config: c, values: c.providers.snapshot()})
+}
+
+type snapshot struct {
+	config *Config
+	values map[string]any
+}
+
+func (s snapshot) Unmarshal(path string, target any) error {
+	if s.config == nil {
+		return nil
+	}
+
+	value, err := s.config.subIn(s.values, s.config.splitPath(path))
+	if err != nil {
+		return err
+	}
+
+	return s.config.decode(path, value, target)
+}
+
+func (s snapshot) Exists(path string) bool {
+	if s.config == nil {
+		return false
+	}
+	value, _ := s.config.subIn(s.values, s.config.splitPath(path))
+
+	return value != nil
+}
+
+// subSnapshot is the Reader of the view created by Config.Sub,
+// which reads the pinned snapshot of the parent Config under the prefix of the view.
+type subSnapshot struct {
+	reader Reader
+	config *Config
+}
+
+func (s subSnapshot) Unmarshal(path string, target any) error {
+	return s.reader.Unmarshal(s.config.parentPath(path), target)
+}
+
+func (s subSnapshot) Exists(path string) bool {
+	return s.reader.Exists(s.config.parentPath(path))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestConfig_UnmarshalMany(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"host": "localhost", "port": 8080, "timeout": "1x"}))
+
+	var (
+		host    string
+		port    int
+		timeout time.Duration
+	)
+	err := config.UnmarshalMany(
+		konf.Pair{Path: "host", Out: &host},
+		konf.Pair{Path: "timeout", Out: &timeout},
+		konf.Pair{Path: "port", Out: &port},
+	)
+	assert.EqualError(t, err, `unmarshal timeout: decode: time: unknown unit "x" in duration "1x"`)
+	assert.Equal(t, "localhost", host)
+	assert.Equal(t, 8080, port)
+}
+
+func TestConfig_View(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	watcher := mapWatcher{values: map[string]any{"host": "blue", "port": 8080}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) })
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	assert.NoError(t, config.View(func(reader konf.Reader) error {
+		var host string
+		assert.NoError(t, reader.Unmarshal("host", &host))
+		assert.Equal(t, "blue", host)
+
+		// The change lands between the reads.
+		watcher.change <- map[string]any{"host": "green", "port": 9090}
+		<-changed
+		var port int
+		assert.NoError(t, config.Unmarshal("port", &port))
+		assert.Equal(t, 9090, port)
+
+		assert.NoError(t, reader.Unmarshal("port", &port))
+		assert.Equal(t, 8080, port)
+		assert.True(t, reader.Exists("host"))
+		assert.True(t, !reader.Exists("timeout"))
+
+		return nil
+	}))
+
+	var zero konf.Config
+	assert.NoError(t, zero.View(func(reader konf.Reader) error {
+		assert.True(t, !reader.Exists(""))
+
+		return nil
+	}))
+}
+
+func TestConfig_View_sub(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithComputed("server.port", []string{"server.base"}, func(config *konf.Config) (any, error) {
+		var base int
+		if err := config.Unmarshal("server.base", &base); err != nil {
+			return nil, err
+		}
+
+		return base + 80, nil
+	}))
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "localhost", "base": 8000}}))
+
+	for _, reader := range []*konf.Config{config, config.Sub("")} {
+		var port int
+		assert.NoError(t, reader.UnmarshalMany(konf.Pair{Path: "server.port", Out: &port}))
+		assert.Equal(t, 8080, port)
+	}
+
+	var (
+		host string
+		port int
+	)
+	sub := config.Sub("server")
+	assert.NoError(t, sub.UnmarshalMany(konf.Pair{Path: "host", Out: &host}, konf.Pair{Path: "port", Out: &port}))
+	assert.Equal(t, "localhost", host)
+	assert.Equal(t, 8080, port)
+	assert.NoError(t, sub.View(func(reader konf.Reader) error {
+		assert.True(t, reader.Exists("port"))
+		assert.True(t, !reader.Exists("host.port"))
+
+		return nil
+	}))
+}
@@ -8,8 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nil-go/konf/internal/maps"
@@ -21,108 +25,324 @@ import (
 // It only can be called once. Call after first has no effects.
 func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocognit
 	c.nocopy.Check()
+	if c.parent != nil {
+		return fmt.Errorf("watch: %w", errSubView)
+	}
 
 	ctx, cancel := context.WithCancelCause(ctx)
 	defer cancel(nil)
 	// Start a goroutine to update the configuration while it has changes from watchers.
-	onChangesChannel := make(chan []func(*Config), 1)
-	defer close(onChangesChannel)
+	queue := &changeQueue{size: c.changeQueueSize, signal: make(chan struct{}, 1)}
+	if queue.size == 0 {
+		queue.size = defaultChangeQueueSize
+	}
+	notify := func(loader Loader, onChanges []*subscription) {
+		if !queue.push(change{loader: loader, onChanges: onChanges}) {
+			return
+		}
+
+		err := fmt.Errorf("dispatch to onChanges: %w", ErrChangeCoalesced)
+		c.log(ctx, slog.LevelWarn,
+			"Configuration change is coalesced since the dispatching falls behind.",
+			slog.Any("loader", loader),
+		)
+		if c.onStatus != nil {
+			c.onStatus(loader, false, err)
+		}
+	}
+	deliverChannel := make(chan *subscription)
+	deliver := func(sub *subscription) {
+		go func() {
+			select {
+			case deliverChannel <- sub:
+			case <-ctx.Done():
+				sub.call(ctx, c, c.currentEvent()) // Deliver directly since the dispatching has stopped.
+			}
+		}()
+	}
+	resumeChannel := make(chan suppression)
+	resume := func(event *ChangeEvent, onChanges []*subscription) {
+		select {
+		case resumeChannel <- suppression{event: event, onChanges: onChanges}:
+		case <-ctx.Done():
+		}
+	}
 	var waitGroup sync.WaitGroup
 	watchProvider := func(provider *provider) {
-		if !provider.watched.CompareAndSwap(false, true) {
-			return // Skip if the provider has been watched.
+		if provider.unloaded.Load() || provider.disabled.Load() || !provider.watched.CompareAndSwap(false, true) {
+			return // Skip if the provider has been unloaded, disabled or watched.
 		}
 		if watcher, ok := provider.loader.(Watcher); ok {
+			watchCtx, watchCancel := context.WithCancel(ctx)
+			// Config.Unload stops waiting for the watcher in case it ignores the canceled context.
+			detach := sync.OnceFunc(waitGroup.Done)
+			stop := sync.OnceFunc(func() {
+				watchCancel()
+				detach()
+			})
+			// The changes from this watcher are discarded once the generation changes, see Config.Disable.
+			generation := provider.generation.Load()
+			stale := func() bool {
+				return provider.unloaded.Load() || provider.disabled.Load() || provider.generation.Load() != generation
+			}
+			provider.refs.Add(1)
+			c.watchers.running.Add(1)
 			waitGroup.Add(1)
-			go func(ctx context.Context) {
-				defer waitGroup.Done()
+			provider.stop.Store(&stop)
+			if provider.unloaded.Load() {
+				stop() // The provider is unloaded concurrently.
+			}
+			go func(ctx, parent context.Context) {
+				defer detach()
+				defer c.watchers.running.Add(-1)
+				defer c.releaseWatcher(provider)
+				defer watchCancel()
 
 				onChange := func(values map[string]any) {
+					if stale() {
+						return // Ignore the changes after the loader is unloaded or disabled.
+					}
 					c.transformKeys(values)
-					oldValues := *provider.values.Swap(&values)
-					onChangesChannel <- c.onChanges.get(
-						func(path string) bool {
-							paths := c.splitPath(path)
+					if c.holdAtomic(parent, provider, values) {
+						return
+					}
+					replaced := provider.replaced.Load()
+					c.extractReplaceMarkers(provider, values)
+					oldValues, newValues, err := c.validateAndStore(provider, values, stale)
+					if errors.Is(err, errStaleWatcher) {
+						provider.replaced.Store(replaced)
 
-							return !reflect.DeepEqual(maps.Sub(oldValues, paths), maps.Sub(values, paths))
-						},
-					)
+						return
+					}
+					if err != nil {
+						provider.replaced.Store(replaced)
+						provider.status(err)
+						c.log(ctx, slog.LevelWarn,
+							"Configuration change is rejected since it's invalid, keep the last good values.",
+							slog.Any("loader", watcher),
+							slog.Any("error", err),
+						)
+						if c.onStatus != nil {
+							c.onStatus(provider.loader, false, err)
+						}
 
-					c.log(ctx, slog.LevelInfo,
-						"Configuration has been changed.",
-						slog.Any("loader", watcher),
-					)
+						return
+					}
+					notify(provider.loader, c.changedOnChanges(oldValues, newValues))
+
+					if !c.quietChanges {
+						c.log(ctx, slog.LevelInfo,
+							"Configuration has been changed.",
+							slog.Any("loader", watcher),
+						)
+					}
 				}
 
 				c.log(ctx, slog.LevelDebug, "Watching configuration change.", slog.Any("loader", watcher))
-				if err := watcher.Watch(ctx, onChange); err != nil {
-					cancel(fmt.Errorf("watch configuration change on %v: %w", watcher, err))
+				if err := c.watchSource(ctx, parent, watcher, onChange); err != nil && !provider.unloaded.Load() {
+					err = fmt.Errorf("watch configuration change on %v: %w", watcher, err)
+					if provider.optional {
+						c.degrade(provider, err) // The failure of optional loader does not stop watching others.
+
+						return
+					}
+					provider.status(err)
+					cancel(err)
 				}
-			}(ctx)
+			}(watchCtx, ctx)
 		}
 	}
 
 	// Set c.watched so that the loader loaded after Watch can register the watch callback.
 	// It's also used for marker that Watch has been called.
-	if !c.watched.CompareAndSwap(nil, &watchProvider) {
+	watch := &watching{
+		provider: watchProvider,
+		notify:   notify,
+		deliver:  deliver,
+		resume:   resume,
+		queue:    queue,
+	}
+	if c.strictLifecycle {
+		if _, file, line, ok := runtime.Caller(1); ok {
+			watch.caller = file + ":" + strconv.Itoa(line)
+		}
+	}
+	if !c.watched.CompareAndSwap(nil, watch) {
 		c.log(ctx, slog.LevelWarn, "Config has been watched, call Watch more than once has no effects.")
 
 		return nil
 	}
+	c.callHook(ctx, "OnWatchStart", func(hooks Hooks) {
+		if hooks.OnWatchStart != nil {
+			var loaders []string
+			c.providers.traverse(func(provider *provider) { loaders = append(loaders, fmt.Sprint(provider.loader)) })
+			hooks.OnWatchStart(loaders)
+		}
+	})
+
+	if c.startupSummary {
+		c.logSummary(ctx)
+	}
+	c.reportCollisions(ctx)
+	c.reportUnknownKeys(ctx)
+
+	// Start a dispatcher for each group with policy, which runs until ctx is done.
+	groups := make(map[string]*groupDispatcher, len(c.groupPolicies))
+	for name, policy := range c.groupPolicies {
+		groups[name] = &groupDispatcher{config: c, name: name, policy: policy, signal: make(chan struct{}, 1)}
+		go groups[name].run(ctx)
+	}
 
 	waitGroup.Add(1)
 	go func() {
 		defer waitGroup.Done()
 
+		// The values that have been applied, for finding the changed keys.
+		applied, _ := c.providers.sub(nil).(map[string]any)
 		for {
 			select {
 			case <-ctx.Done():
 				return
 
-			case onChanges := <-onChangesChannel:
-				c.providers.changed()
-				c.log(ctx, slog.LevelDebug, "Configuration has been updated with change.")
-
-				if len(onChanges) > 0 {
-					func() {
-						done := make(chan struct{})
-						go func() {
-							defer close(done)
-
-							for _, onChange := range onChanges {
-								onChange(c)
-							}
-						}()
-
-						tctx, tcancel := context.WithTimeout(ctx, time.Minute)
-						defer tcancel()
-						select {
-						case <-done:
-							c.log(ctx, slog.LevelDebug, "Configuration has been applied to onChanges.")
-						case <-tctx.Done():
-							if errors.Is(tctx.Err(), context.DeadlineExceeded) {
-								c.log(
-									ctx, slog.LevelWarn,
-									"Configuration has not been fully applied to onChanges in one minute."+
-										" Please check if the onChanges is blocking or takes too long to complete.",
-								)
-							}
+			case sub := <-deliverChannel:
+				// Deliver the current configuration in order with the changes, see konf.DeliverCurrent,
+				// by the same dispatching as the changes.
+				event := c.currentEvent()
+				if group, ok := groups[sub.group]; ok {
+					group.enqueue(ctx, event.Loader, event, []*subscription{sub})
+				} else {
+					_ = c.dispatch(ctx, event, []*subscription{sub})
+				}
+
+			case resumed := <-resumeChannel:
+				// The callbacks suppressed by konf.SuppressFlapping are executed with the changes they missed.
+				_ = c.dispatchGroups(ctx, groups, resumed.event, resumed.onChanges)
+
+			case <-queue.signal:
+				for change, ok := queue.pop(); ok && ctx.Err() == nil; change, ok = queue.pop() {
+					c.providers.changed()
+					values, _ := c.providers.sub(nil).(map[string]any)
+					event := &ChangeEvent{
+						Version: c.version.Add(1),
+						Time:    c.timeSource().Now(),
+						Loader:  change.loader,
+						Keys:    c.changedKeys(applied, values),
+					}
+					for _, key := range event.Keys {
+						if maps.Sub(values, c.splitPath(key)) == nil {
+							event.Removed = append(event.Removed, key)
 						}
-					}()
+					}
+					applied = values
+					c.lastChange.Store(event)
+					if c.keyInfo {
+						c.keyChanges.record(event)
+					}
+					c.log(ctx, slog.LevelDebug, "Configuration has been updated with change.",
+						slog.Uint64("version", event.Version),
+						slog.Any("keys", event.Keys),
+					)
+					c.checkRestart(ctx, event)
+					c.warnShadows(ctx)
+					subs := c.detectFlaps(ctx, event, change.onChanges)
+					c.callHook(ctx, "BeforeApply", func(hooks Hooks) {
+						if hooks.BeforeApply != nil {
+							hooks.BeforeApply(*event)
+						}
+					})
+
+					err := c.dispatchGroups(ctx, groups, event, subs)
+					c.callHook(ctx, "AfterApply", func(hooks Hooks) {
+						if hooks.AfterApply != nil {
+							hooks.AfterApply(*event, err)
+						}
+					})
 				}
 			}
 		}
 	}()
 
+	// Start a reloading goroutine for each konf.WithAutoReload.
+	for _, reload := range c.autoReloads {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			c.autoReload(ctx, reload)
+		}()
+	}
+
 	// Start a watching goroutine for each watcher registered.
 	c.providers.traverse(watchProvider)
 	waitGroup.Wait()
+	c.writeFinalSnapshot(ctx)
+
+	err := context.Cause(ctx)
+	if errors.Is(err, ctx.Err()) {
+		err = nil
+	}
+	c.callHook(ctx, "OnWatchStop", func(hooks Hooks) {
+		if hooks.OnWatchStop != nil {
+			hooks.OnWatchStop(err)
+		}
+	})
+
+	return err
+}
 
-	if err := context.Cause(ctx); err != nil && !errors.Is(err, ctx.Err()) {
-		return err //nolint:wrapcheck
+// dispatchGroups executes the callbacks in the default group with the event like Config.dispatch,
+// and queues the callbacks of the groups with policy to their own dispatchers.
+func (c *Config) dispatchGroups(
+	ctx context.Context, groups map[string]*groupDispatcher, event *ChangeEvent, subs []*subscription,
+) error {
+	var onChanges []*subscription
+	grouped := make(map[string][]*subscription)
+	for _, sub := range subs {
+		if _, ok := groups[sub.group]; ok {
+			grouped[sub.group] = append(grouped[sub.group], sub)
+		} else {
+			onChanges = append(onChanges, sub)
+		}
+	}
+	for name, subs := range grouped {
+		groups[name].enqueue(ctx, event.Loader, event, subs)
+	}
+	if len(onChanges) == 0 {
+		return nil
 	}
 
-	return nil
+	return c.dispatch(ctx, event, onChanges)
+}
+
+// dispatch executes the callbacks with the event, and waits until they complete in one minute.
+func (c *Config) dispatch(ctx context.Context, event *ChangeEvent, onChanges []*subscription) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for _, onChange := range onChanges {
+			onChange.call(ctx, c, event)
+		}
+	}()
+
+	timeout, stop := c.timeSource().NewTimer(time.Minute)
+	defer stop()
+	select {
+	case <-done:
+		c.log(ctx, slog.LevelDebug, "Configuration has been applied to onChanges.")
+
+		return nil
+	case <-timeout:
+		c.log(
+			ctx, slog.LevelWarn,
+			"Configuration has not been fully applied to onChanges in one minute."+
+				" Please check if the onChanges is blocking or takes too long to complete.",
+		)
+
+		return errApplyTimeout
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
 }
 
 // OnChange registers a callback function that is executed
@@ -130,30 +350,378 @@ func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocog
 // It requires Config.Watch has been called first.
 // The paths are case-insensitive unless konf.WithCaseSensitive is set.
 //
+// The registration is ignored with a warning log if onChange is nil.
+// The empty path or path only contains delimiters is invalid, and is ignored with a warning log.
+// If all given paths are invalid, the registration is ignored as well.
+//
 // The register function must be non-blocking and usually completes instantly.
 // If it requires a long time to complete, it should be executed in a separate goroutine.
 //
 // This method is concurrent-safe.
 func (c *Config) OnChange(onChange func(*Config), paths ...string) {
+	c.registerOnChange(onChange, paths, 2) //nolint:mnd
+}
+
+// OnChangeUntil registers a callback function the same as Config.OnChange,
+// but the registration is removed once ctx is done.
+// The callback is not executed after ctx is done, even if the change is being dispatched.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangeUntil(ctx context.Context, onChange func(*Config), paths ...string) {
+	var callback func(*Config)
+	if onChange != nil {
+		callback = func(config *Config) {
+			if ctx.Err() == nil {
+				onChange(config)
+			}
+		}
+	}
+	if sub := c.registerOnChange(callback, paths, 2); sub != nil { //nolint:mnd
+		context.AfterFunc(ctx, func() { c.unregisterOnChange(sub) })
+	}
+}
+
+// Subscribe returns a channel which receives the Config when the value of any given path changes,
+// and the function to unsubscribe, which closes the channel.
+// It's the alternative of Config.OnChange for the consumers with select loop.
+//
+// The channel is buffered, and the oldest notification is dropped if the consumer falls behind,
+// so it never blocks dispatching changes. The validation of paths is the same as Config.OnChange.
+//
+// This method is concurrent-safe.
+func (c *Config) Subscribe(paths ...string) (<-chan *Config, func()) {
+	if c == nil { // To support nil
+		return nil, func() {}
+	}
+
+	var (
+		channel = make(chan *Config, 1)
+		mutex   sync.Mutex
+		closed  bool
+	)
+	sub := c.registerOnChange(func(config *Config) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if closed {
+			return
+		}
+		for {
+			select {
+			case channel <- config:
+				return
+			default:
+				// Drop the oldest notification for the slow consumer.
+				select {
+				case <-channel:
+				default:
+				}
+			}
+		}
+	}, paths, 2) //nolint:mnd
+
+	return channel, func() {
+		if sub != nil {
+			c.unregisterOnChange(sub)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if !closed {
+			closed = true
+			close(channel)
+		}
+	}
+}
+
+// registerOnChange registers the onChange with the given paths in the default group.
+// The skip is the number of stack frames to skip for reporting the caller of registration.
+// It returns nil if the registration is ignored.
+func (c *Config) registerOnChange(onChange func(*Config), paths []string, skip int) *subscription {
+	return c.registerOnChangeIn("", withoutContext(onChange), paths, skip+1)
+}
+
+// withoutContext adapts the onChange without context for the subscription, or returns nil if it's nil.
+func withoutContext(onChange func(*Config)) func(context.Context, *Config) {
 	if onChange == nil {
-		return // Do nothing is onchange is nil.
+		return nil
+	}
+
+	return func(_ context.Context, config *Config) { onChange(config) }
+}
+
+// registerOnChangeIn registers the onChange with the given paths in the given group.
+// The onChange registered on the view created by Config.Sub is registered in the parent Config
+// with the paths under the view.
+func (c *Config) registerOnChangeIn(
+	group string, onChange func(context.Context, *Config), paths []string, skip int,
+) *subscription {
+	if c != nil && c.parent != nil {
+		return c.parent.registerOnChangeIn(group, c.viewOnChange(onChange), c.parentPaths(paths), skip+1)
+	}
+	caller := func() string {
+		// Skip one more frame for this closure.
+		if _, file, line, ok := runtime.Caller(skip + 1); ok {
+			return file + ":" + strconv.Itoa(line)
+		}
+
+		return ""
+	}
+
+	if onChange == nil {
+		c.log(context.Background(), slog.LevelWarn,
+			"OnChange is nil, the registration has no effects.",
+			slog.String("caller", caller()),
+		)
+
+		return nil
 	}
 	c.nocopy.Check()
 
-	if !c.caseSensitive {
-		for i := range paths {
-			paths[i] = defaultKeyMap(paths[i])
+	validPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if strings.Trim(path, c.delim()) == "" {
+			c.log(context.Background(), slog.LevelWarn,
+				"Path of onChange is empty, the path has been ignored.",
+				slog.String("path", path),
+				slog.String("caller", caller()),
+			)
+
+			continue
+		}
+		if !c.caseSensitive {
+			path = defaultKeyMap(path)
+		}
+		validPaths = append(validPaths, path)
+	}
+	if len(paths) > 0 && len(validPaths) == 0 {
+		return nil // Do not register for any path while all given paths are invalid.
+	}
+
+	var registeredAt string
+	if c.strictLifecycle || c.captureCaller {
+		registeredAt = caller()
+	}
+	if c.strictLifecycle && c.version.Load() > 0 {
+		c.log(context.Background(), slog.LevelWarn,
+			"Register onChange after configuration has been changed, it may miss the previous changes.",
+			slog.Any("paths", validPaths),
+			slog.String("caller", registeredAt),
+		)
+	}
+	if !c.captureCaller {
+		registeredAt = ""
+	}
+
+	return c.onChanges.register(&subscription{onChange: onChange, caller: registeredAt, group: group}, validPaths)
+}
+
+// ChangeEvent is the change of configuration applied by Config.Watch.
+// It never contains any configuration value.
+type ChangeEvent struct {
+	// Version is the version of configuration after the change, which increases by 1 for each change.
+	Version uint64
+	// Time is the time when the change is applied.
+	Time time.Time
+	// Loader is the loader which triggers the change, or nil if it's triggered by Config itself,
+	// e.g. Config.Reorder.
+	Loader Loader
+	// Keys are the paths of changed values, sorted.
+	Keys []string
+	// Removed are the paths in Keys which have no value after the change, sorted.
+	// The paths whose values fall back to the loaders with lower precedence are not removed.
+	Removed []string
+	// RestartRequired reports whether any changed key requires restart,
+	// which is configured by konf.WithRestartRequired.
+	RestartRequired bool
+	// Synthetic reports whether it's not a change but the initial delivery of the current configuration
+	// by konf.DeliverCurrent, which has no Loader and Keys.
+	Synthetic bool
+}
+
+// LastChange returns the last change applied by Config.Watch.
+// It returns zero ChangeEvent if there is no change yet.
+//
+// Within the callbacks registered by Config.OnChange, it's the change triggers the callbacks.
+//
+// This method is concurrent-safe.
+func (c *Config) LastChange() ChangeEvent {
+	if c == nil { // To support nil
+		return ChangeEvent{}
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.LastChange()
+	}
+
+	if event := c.lastChange.Load(); event != nil {
+		return *event
+	}
+
+	return ChangeEvent{}
+}
+
+// changedKeys returns the paths of leaf values which are different between the given values.
+func (c *Config) changedKeys(oldValues, newValues map[string]any) []string {
+	diff := maps.Diff(oldValues, newValues)
+	keys := make([]string, 0, len(diff))
+	for _, path := range diff {
+		keys = append(keys, strings.Join(path, c.delim()))
+	}
+
+	return keys
+}
+
+// changedOnChanges returns onChanges whose paths have different values between the given values.
+func (c *Config) changedOnChanges(oldValues, newValues map[string]any) []*subscription {
+	computeds := c.changedComputeds(oldValues, newValues)
+
+	return c.onChanges.get(
+		func(path string) bool {
+			paths := c.splitPath(path)
+
+			return !maps.Equal(maps.Sub(oldValues, paths), maps.Sub(newValues, paths)) ||
+				slices.ContainsFunc(computeds, func(keys []string) bool { return overlaps(keys, paths) })
+		},
+	)
+}
+
+type change struct {
+	loader    Loader // nil if the change is not from a loader, e.g. Config.Reorder.
+	onChanges []*subscription
+}
+
+// ErrChangeCoalesced is the error reported to the callback provided by konf.WithOnStatus
+// if the change is coalesced into the latest pending change since the queue provided by konf.WithChangeQueue is full.
+var ErrChangeCoalesced = errors.New("change is coalesced since the queue of changes is full")
+
+// defaultChangeQueueSize is the size of the queue of changes if konf.WithChangeQueue is not provided.
+const defaultChangeQueueSize = 16
+
+// changeQueue is the bounded queue of the changes waiting for dispatching by Config.Watch.
+type changeQueue struct {
+	changes   []change
+	size      int
+	mutex     sync.Mutex
+	signal    chan struct{}
+	coalesced atomic.Uint64
+}
+
+// push appends the change to the queue, or coalesces it into the latest pending change if the queue is full.
+// It reports whether the change has been coalesced.
+//
+// The coalesced change takes the loader of the latest change and the union of the callbacks,
+// and its changed keys are still found against the values last applied, so no changed key is lost.
+func (q *changeQueue) push(change change) bool {
+	q.mutex.Lock()
+	coalesced := len(q.changes) >= q.size
+	if coalesced {
+		latest := &q.changes[len(q.changes)-1]
+		latest.loader = change.loader
+		merged := slices.Clone(latest.onChanges)
+		for _, sub := range change.onChanges {
+			if !slices.Contains(merged, sub) {
+				merged = append(merged, sub)
+			}
 		}
+		latest.onChanges = merged
+		q.coalesced.Add(1)
+	} else {
+		q.changes = append(q.changes, change)
+	}
+	q.mutex.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
 	}
-	c.onChanges.register(onChange, paths)
+
+	return coalesced
+}
+
+// pop removes and returns the oldest pending change, or false if the queue is empty.
+func (q *changeQueue) pop() (change, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.changes) == 0 {
+		return change{}, false
+	}
+	first := q.changes[0]
+	q.changes = q.changes[1:]
+
+	return first, true
 }
 
-type onChanges struct {
-	subscribers map[string][]func(*Config)
-	mutex       sync.RWMutex
+func (q *changeQueue) len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.changes)
+}
+
+// ErrTooManyWatchers is the error returned by Config.Load
+// if the number of loaders being watched reaches the limit provided by konf.WithMaxWatchers.
+var ErrTooManyWatchers = errors.New("too many loaders are being watched")
+
+// watchers tracks the loaders being watched, which are reported by Config.DebugState.
+type watchers struct {
+	// The number of loaded Watchers, and unloaded Watchers whose watching has not returned yet.
+	slots atomic.Int64
+	// The number of goroutines watching loaders.
+	running atomic.Int64
+}
+
+// acquireWatcher acquires the slot of watcher for the provider,
+// and returns error if the number of slots reaches the limit provided by konf.WithMaxWatchers.
+// The slot is held until the provider is unloaded and its watching has returned.
+func (c *Config) acquireWatcher(provider *provider) error {
+	for {
+		slots := c.watchers.slots.Load()
+		if c.maxWatchers > 0 && slots >= int64(c.maxWatchers) {
+			return fmt.Errorf("watch %v: %w (limit %d)", provider.loader, ErrTooManyWatchers, c.maxWatchers)
+		}
+		if c.watchers.slots.CompareAndSwap(slots, slots+1) {
+			provider.refs.Store(1)
+
+			return nil
+		}
+	}
 }
 
-func (o *onChanges) register(onChange func(*Config), paths []string) {
+func (c *Config) releaseWatcher(provider *provider) {
+	if provider.refs.Add(-1) == 0 {
+		c.watchers.slots.Add(-1)
+	}
+}
+
+type watching struct {
+	provider func(*provider)
+	notify   func(Loader, []*subscription)
+	deliver  func(*subscription)
+	resume   func(*ChangeEvent, []*subscription) // Only for konf.SuppressFlapping.
+	queue    *changeQueue
+	caller   string // The location where Config.Watch is called, only for strict lifecycle.
+}
+
+type (
+	onChanges struct {
+		subscribers map[string][]*subscription
+		mutex       sync.RWMutex
+	}
+	subscription struct {
+		onChange func(context.Context, *Config)
+		caller   string // Only for konf.WithCallerCapture.
+		group    string // The default group is empty.
+		removed  atomic.Bool
+
+		mutex     sync.Mutex // Serializes the executions of the callback.
+		delivered uint64     // The version of the last delivered configuration plus one.
+	}
+)
+
+func (o *onChanges) register(sub *subscription, paths []string) *subscription {
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
@@ -162,23 +730,68 @@ func (o *onChanges) register(onChange func(*Config), paths []string) {
 	}
 
 	if o.subscribers == nil {
-		o.subscribers = make(map[string][]func(*Config))
+		o.subscribers = make(map[string][]*subscription)
 	}
 	for _, path := range paths {
-		o.subscribers[path] = append(o.subscribers[path], onChange)
+		o.subscribers[path] = append(o.subscribers[path], sub)
 	}
+
+	return sub
 }
 
-func (o *onChanges) get(filter func(string) bool) []func(*Config) {
+func (o *onChanges) unregister(sub *subscription) {
+	// Mark it as removed first so that the dispatching in progress skips it.
+	sub.removed.Store(true)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for path, subscribers := range o.subscribers {
+		// Copy on write since the slice may be traversed by get.
+		subscribers = slices.DeleteFunc(slices.Clone(subscribers), func(s *subscription) bool { return s == sub })
+		if len(subscribers) == 0 {
+			delete(o.subscribers, path)
+		} else {
+			o.subscribers[path] = subscribers
+		}
+	}
+}
+
+// get returns the subscriptions whose paths match the filter.
+// The subscription registered for multiple matched paths is only returned once.
+func (o *onChanges) get(filter func(string) bool) []*subscription {
 	o.mutex.RLock()
 	defer o.mutex.RUnlock()
 
-	var callbacks []func(*Config)
-	for path, subscriber := range o.subscribers {
-		if filter(path) {
-			callbacks = append(callbacks, subscriber...)
+	var (
+		subs []*subscription
+		seen = make(map[*subscription]struct{})
+	)
+	for path, subscribers := range o.subscribers {
+		if !filter(path) {
+			continue
+		}
+		for _, sub := range subscribers {
+			if _, ok := seen[sub]; ok {
+				continue
+			}
+			seen[sub] = struct{}{}
+			subs = append(subs, sub)
 		}
 	}
 
-	return callbacks
+	return subs
+}
+
+// call executes the callback with the context carrying the given event, see konf.ChangeFromContext,
+// unless the subscription has been removed, or the change of the same or newer version has been delivered to it.
+func (s *subscription) call(ctx context.Context, config *Config, event *ChangeEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.removed.Load() || event.Version < s.delivered {
+		return
+	}
+	s.delivered = event.Version + 1
+	s.onChange(context.WithValue(ctx, changeContextKey{}, event), config)
 }
@@ -6,9 +6,20 @@ package konf_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/big"
+	"regexp"
+	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -16,6 +27,7 @@ import (
 
 	"github.com/nil-go/konf"
 	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
 )
 
 func TestOnChange_nil(*testing.T) {
@@ -23,6 +35,61 @@ func TestOnChange_nil(*testing.T) {
 	config.OnChange(nil) // It should not block
 }
 
+func TestConfig_OnChange_invalid(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		onChange    func(*konf.Config)
+		paths       []string
+		expected    string
+	}{
+		{
+			description: "nil onChange",
+			expected:    `level=WARN msg="OnChange is nil, the registration has no effects." caller=watch_test.go` + "\n",
+		},
+		{
+			description: "empty path",
+			onChange:    func(*konf.Config) {},
+			paths:       []string{""},
+			expected: `level=WARN msg="Path of onChange is empty, the path has been ignored." path="" caller=watch_test.go` +
+				"\n",
+		},
+		{
+			description: "delimiter only path",
+			onChange:    func(*konf.Config) {},
+			paths:       []string{"..", "config"},
+			expected: `level=WARN msg="Path of onChange is empty, the path has been ignored." path=.. caller=watch_test.go` +
+				"\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &buffer{}
+			config := konf.New(konf.WithLogHandler(logHandler(buf)))
+			config.OnChange(testcase.onChange, testcase.paths...)
+			assert.Equal(t, testcase.expected, watchCallerPattern.ReplaceAllString(buf.String(), "watch_test.go"))
+		})
+	}
+}
+
+func TestConfig_OnChange_invalid_paths(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})))
+	config.OnChange(func(*konf.Config) {}, "", ".")
+	config.OnChange(func(*konf.Config) {}, ".", "config")
+	assert.Equal(t,
+		[]konf.SubscriptionState{{Path: "config", Count: 1}},
+		config.DebugState().Subscriptions,
+	)
+}
+
+var watchCallerPattern = regexp.MustCompile(`/\S*/watch_test\.go:\d+`)
+
 func TestConfig_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -100,32 +167,274 @@ func TestConfig_Watch_race(t *testing.T) {
 	assert.Equal(t, "changed", <-newValue)
 }
 
+func TestConfig_OnChange_concurrent(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"a": 0, "b": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	// Registered for multiple changed paths, but only called once for each change.
+	applied := make(chan int)
+	config.OnChange(func(config *konf.Config) {
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		applied <- a
+	}, "a", "b")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// Register and unregister onChanges while changes are dispatching.
+	var waitGroup sync.WaitGroup
+	for range 8 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for range 100 {
+				registerCtx, unregister := context.WithCancel(ctx)
+				config.OnChangeUntil(registerCtx, func(*konf.Config) {}, "a")
+				config.OnChange(func(*konf.Config) {})
+				unregister()
+			}
+		}()
+	}
+
+	for i := 1; i <= 100; i++ {
+		watcher.change <- map[string]any{"a": i, "b": i}
+		assert.Equal(t, i, <-applied)
+	}
+	waitGroup.Wait()
+}
+
+func TestConfig_OnChangeUntil(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"a": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	applied := make(chan struct{})
+	config.OnChange(func(*konf.Config) { applied <- struct{}{} })
+	var called atomic.Int32
+	registerCtx, unregister := context.WithCancel(context.Background())
+	config.OnChangeUntil(registerCtx, func(*konf.Config) { called.Add(1) }, "a")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"a": 1}
+	<-applied
+	unregister()
+	watcher.change <- map[string]any{"a": 2}
+	<-applied
+	watcher.change <- map[string]any{"a": 3}
+	<-applied // The dispatching of previous change has completed.
+	assert.Equal(t, int32(1), called.Load())
+	assert.Equal(t, []konf.SubscriptionState{{Count: 1}}, config.DebugState().Subscriptions)
+}
+
+func TestConfig_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: map[string]any{"a": 0, "b": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	applied := make(chan struct{})
+	config.OnChange(func(*konf.Config) { applied <- struct{}{} })
+	changes, unsubscribe := config.Subscribe("a")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The slow consumer does not block dispatching.
+	watcher.change <- map[string]any{"a": 1, "b": 0}
+	<-applied
+	watcher.change <- map[string]any{"a": 2, "b": 0}
+	<-applied
+	watcher.change <- map[string]any{"a": 2, "b": 1}
+	<-applied
+	assert.Equal(t, config, <-changes)
+	select {
+	case <-changes:
+		t.Fatal("expected the notifications to be coalesced")
+	default:
+	}
+
+	unsubscribe()
+	unsubscribe() // Unsubscribe twice has no effects.
+	_, ok := <-changes
+	assert.Equal(t, false, ok)
+	watcher.change <- map[string]any{"a": 3, "b": 1}
+	<-applied
+	assert.Equal(t, []konf.SubscriptionState{{Count: 1}}, config.DebugState().Subscriptions)
+}
+
 func TestConfig_Watch_onchange_block(t *testing.T) {
 	t.Parallel()
 
 	buf := &buffer{}
-	config := konf.New(konf.WithLogHandler(logHandler(buf)))
+	warned := make(chan struct{})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	config := konf.New(
+		konf.WithLogHandler(warnHandler{Handler: logHandler(buf), warned: warned}),
+		konf.WithClock(fake),
+	)
 	watcher := stringWatcher{key: "Config", value: make(chan string)}
 	err := config.Load(watcher)
 	assert.NoError(t, err)
 
+	release := make(chan struct{})
+	defer close(release)
 	config.OnChange(func(*konf.Config) {
-		time.Sleep(time.Second)
+		<-release
 	})
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
-	defer cancel()
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
+		defer close(stopped)
 		assert.NoError(t, config.Watch(ctx))
 	}()
 	watcher.change()
 
-	<-ctx.Done()
-	time.Sleep(10 * time.Millisecond) // Wait for log to be written
+	fake.BlockUntil(1) // Wait for onChanges to be applied.
+	fake.Advance(time.Minute)
+	<-warned
+	cancel()
+	<-stopped
+
 	expected := `level=INFO msg="Configuration has been changed." loader=stringWatcher
 level=WARN msg="Configuration has not been fully applied to onChanges in one minute. Please check if the onChanges is blocking or takes too long to complete."
 `
 	assert.Equal(t, expected, buf.String())
+	assert.Equal(t, now, config.LastChange().Time)
+}
+
+func TestConfig_Watch_changeQueue(t *testing.T) {
+	t.Parallel()
+
+	statuses := make(chan error, 10)
+	config := konf.New(
+		konf.WithLogHandler(logHandler(&buffer{})),
+		konf.WithChangeQueue(1),
+		konf.WithOnStatus(func(_ konf.Loader, _ bool, err error) { statuses <- err }),
+	)
+	watcher := mapWatcher{values: map[string]any{"a": 0, "b": 0, "c": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	events := make(chan konf.ChangeEvent, 10)
+	config.OnChange(func(config *konf.Config) {
+		started <- struct{}{}
+		<-release
+		events <- config.LastChange()
+	})
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	watcher.change <- map[string]any{"a": 1, "b": 0, "c": 0}
+	<-started
+	watcher.change <- map[string]any{"a": 1, "b": 1, "c": 0}
+	watcher.change <- map[string]any{"a": 1, "b": 1, "c": 1}
+	err := <-statuses
+	assert.True(t, errors.Is(err, konf.ErrChangeCoalesced))
+	assert.EqualError(t, err, "dispatch to onChanges: "+konf.ErrChangeCoalesced.Error())
+	state := config.DebugState()
+	assert.Equal(t, 1, state.PendingChanges)
+	assert.Equal(t, uint64(1), state.CoalescedChanges)
+
+	close(release)
+	assert.Equal(t, []string{"a"}, (<-events).Keys)
+	// The keys of the coalesced change are the union against the values last applied.
+	event := <-events
+	assert.Equal(t, uint64(2), event.Version)
+	assert.Equal(t, []string{"b", "c"}, event.Keys)
+}
+
+func TestConfig_Watch_changeQueue_stress(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithLogHandler(logHandler(&buffer{})), konf.WithChangeQueue(4))
+	watcher := mapWatcher{values: map[string]any{"a": 0}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	var calls atomic.Int32
+	applied := make(chan int, 1000)
+	config.OnChange(func(config *konf.Config) {
+		time.Sleep(time.Millisecond) // Deliberately slower than the changes.
+		calls.Add(1)
+		var a int
+		assert.NoError(t, config.Unmarshal("a", &a))
+		applied <- a
+	}, "a")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	const changes = 500
+	for i := 1; i <= changes; i++ {
+		watcher.change <- map[string]any{"a": i}
+	}
+	for a := range applied {
+		if a == changes {
+			break
+		}
+	}
+	state := config.DebugState()
+	assert.True(t, state.PendingChanges <= 4)
+	assert.True(t, state.CoalescedChanges > 0)
+	assert.True(t, state.Version < changes)
+	assert.True(t, int(calls.Load()) < changes)
 }
 
 func TestConfig_Watch_twice(t *testing.T) {
@@ -145,7 +454,7 @@ func TestConfig_Watch_twice(t *testing.T) {
 		defer close(stopped)
 		assert.NoError(t, config.Watch(ctx))
 	}()
-	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+	waitWatch(t, config)
 
 	assert.NoError(t, config.Watch(ctx))
 	expected := "level=WARN msg=\"Config has been watched, call Watch more than once has no effects.\"\n"
@@ -167,7 +476,7 @@ func TestConfig_Watch_with_later_load(t *testing.T) {
 		defer close(stopped)
 		assert.NoError(t, config.Watch(ctx))
 	}()
-	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+	waitWatch(t, config)
 
 	watcher := stringWatcher{key: "Config", value: make(chan string)}
 	assert.NoError(t, config.Load(watcher))
@@ -181,6 +490,211 @@ func TestConfig_Watch_with_later_load(t *testing.T) {
 	assert.Equal(t, "changed", <-newValue)
 }
 
+func TestConfig_Watch_with_later_watcher(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"a": 0, "b": 0}))
+	changes := make(chan int)
+	config.OnChange(func(config *konf.Config) {
+		var value int
+		assert.NoError(t, config.Unmarshal("b", &value))
+		changes <- value
+	}, "b")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The values of the loader added while watching are merged and dispatched immediately.
+	watcher := mapWatcher{values: map[string]any{"b": 1}, change: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+	assert.Equal(t, 1, <-changes)
+	assert.Equal(t, []string{"b"}, config.LastChange().Keys)
+
+	// The loader added while watching is watched as well.
+	watcher.change <- map[string]any{"b": 2}
+	assert.Equal(t, 2, <-changes)
+}
+
+func TestConfig_Watch_bytes(t *testing.T) {
+	t.Parallel()
+
+	type TLS struct {
+		Cert []byte
+		Key  []byte
+	}
+	loadCertificate := func(config *konf.Config) (cryptotls.Certificate, error) {
+		var value TLS
+		if err := config.Unmarshal("tls", &value); err != nil {
+			return cryptotls.Certificate{}, err
+		}
+
+		return cryptotls.X509KeyPair(value.Cert, value.Key)
+	}
+
+	cert, key := generateKeyPair(t)
+	watcher := mapWatcher{
+		values: map[string]any{"tls": map[string]any{"cert": cert, "key": key}},
+		change: make(chan map[string]any),
+	}
+	config := konf.New()
+	assert.NoError(t, config.Load(watcher))
+	certificate, err := loadCertificate(config)
+	assert.NoError(t, err)
+	block, _ := pem.Decode(cert)
+	assert.Equal(t, block.Bytes, certificate.Certificate[0])
+
+	certificates := make(chan cryptotls.Certificate)
+	config.OnChange(func(config *konf.Config) {
+		certificate, e := loadCertificate(config)
+		assert.NoError(t, e)
+		certificates <- certificate
+	}, "tls")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	cert, key = generateKeyPair(t)
+	watcher.change <- map[string]any{"tls": map[string]any{"cert": cert, "key": key}}
+	block, _ = pem.Decode(cert)
+	assert.Equal(t, block.Bytes, (<-certificates).Certificate[0])
+	assert.Equal(t, "tls.key has value[SSH (EC) private key] that is loaded by loader[map].\n\n", config.Explain("tls.key"))
+}
+
+func generateKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "konf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestConfig_Watch_removed(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"port": 80}}))
+	watcher := mapWatcher{
+		values: map[string]any{
+			"server": map[string]any{"port": 8080, "host": "localhost"},
+			"db":     map[string]any{"host": "db", "pool": map[string]any{"size": 10}},
+		},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+
+	type change struct {
+		path  string
+		value any
+	}
+	changes := make(chan change, 4)
+	for _, path := range []string{"server.port", "server.host", "db.pool.size"} {
+		config.OnChange(func(config *konf.Config) {
+			var value any
+			assert.NoError(t, config.Unmarshal(path, &value))
+			changes <- change{path: path, value: value}
+		}, path)
+	}
+	events := make(chan konf.ChangeEvent, 1)
+	config.OnChange(func(config *konf.Config) { events <- config.LastChange() })
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	// The new delivery fully replaces the previous one, and the subtree db disappears wholesale.
+	watcher.change <- map[string]any{"server": map[string]any{}}
+	event := <-events
+	assert.Equal(t, []string{"db.host", "db.pool.size", "server.host", "server.port"}, event.Keys)
+	assert.Equal(t, []string{"db.host", "db.pool.size", "server.host"}, event.Removed)
+	received := make(map[string]any)
+	for range 3 {
+		change := <-changes
+		received[change.path] = change.value
+	}
+	assert.Equal(t, map[string]any{
+		"server.port":  80, // Fall back to the lower loader.
+		"server.host":  nil,
+		"db.pool.size": nil,
+	}, received)
+	assert.True(t, config.Exists([]string{"server", "port"}))
+	assert.True(t, !config.Exists([]string{"server", "host"}))
+	assert.True(t, !config.Exists([]string{"db"}))
+}
+
+func TestConfig_Watch_strict_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithStrictLifecycle(), konf.WithLogHandler(logHandler(buf)))
+	watcher := stringWatcher{key: "Config", value: make(chan string)}
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	waitWatch(t, config)
+
+	err := config.Load(mapLoader{})
+	var lifecycleErr konf.LifecycleError
+	assert.True(t, errors.As(err, &lifecycleErr))
+	assert.True(t, strings.Contains(lifecycleErr.WatchedAt, "watch_test.go:"))
+	assert.True(t, strings.HasPrefix(err.Error(), "load configuration from map after Config.Watch has been started at "))
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) })
+	watcher.change()
+	<-changed
+	config.OnChange(func(*konf.Config) {})
+	assert.True(t, strings.Contains(buf.String(),
+		`level=WARN msg="Register onChange after configuration has been changed, it may miss the previous changes."`,
+	))
+}
+
 func TestConfig_Watch_status(t *testing.T) {
 	t.Parallel()
 
@@ -205,13 +719,46 @@ func TestConfig_Watch_status(t *testing.T) {
 		defer close(stopped)
 		assert.NoError(t, config.Watch(ctx))
 	}()
-	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+	waitWatch(t, config)
 
 	expected := "level=WARN msg=\"Error when loading configuration.\" loader=status error=\"watch error\"\n"
 	assert.Equal(t, expected, buf.String())
 	assert.EqualError(t, *err.Load(), "watch error")
 }
 
+func TestConfig_Watch_quiet(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	statuses := make(chan bool, 1)
+	config := konf.New(
+		konf.WithQuietChanges(),
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithOnStatus(func(_ konf.Loader, changed bool, err error) {
+			assert.NoError(t, err)
+			statuses <- changed
+		}),
+	)
+	watcher := &statusChangeWatcher{stringWatcher: stringWatcher{key: "Config", value: make(chan string)}}
+	assert.NoError(t, config.Load(watcher))
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) }, "config")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	watcher.change()
+
+	assert.True(t, <-statuses)
+	<-changed
+	cancel()
+	<-stopped
+	assert.Equal(t, "", buf.String())
+}
+
 func TestConfig_Watch_panic(t *testing.T) {
 	t.Parallel()
 
@@ -322,6 +869,62 @@ func (*statusWatcher) String() string {
 	return "status"
 }
 
+type statusChangeWatcher struct {
+	stringWatcher
+
+	onStatus func(bool, error)
+}
+
+func (s *statusChangeWatcher) Watch(ctx context.Context, fn func(map[string]any)) error {
+	return s.stringWatcher.Watch(ctx, func(values map[string]any) {
+		s.onStatus(true, nil)
+		fn(values)
+	})
+}
+
+func (s *statusChangeWatcher) Status(onStatus func(bool, error)) {
+	s.onStatus = onStatus
+}
+
+// waitWatch waits until Config.Watch has started watching all loaders.
+func waitWatch(t *testing.T, config *konf.Config) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !watchStarted(config.DebugState()) {
+		if time.Now().After(deadline) {
+			t.Fatal("watch has not started in one second")
+		}
+		runtime.Gosched()
+	}
+}
+
+// waitLog waits until the given text has been logged for the given times.
+func waitLog(t *testing.T, buf *buffer, text string, times int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for strings.Count(buf.String(), text) < times {
+		if time.Now().After(deadline) {
+			t.Fatalf("%q has not been logged %d times in one second", text, times)
+		}
+		runtime.Gosched()
+	}
+}
+
+func watchStarted(state konf.DebugState) bool {
+	if !state.Watching {
+		return false
+	}
+	for _, loader := range state.Loaders {
+		if loader.Watcher && !loader.Disabled && !loader.Watched {
+			return false
+		}
+	}
+
+	return true
+}
+
 func logHandler(buf *buffer) *slog.TextHandler {
 	return slog.NewTextHandler(buf, &slog.HandlerOptions{
 		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
@@ -334,6 +937,22 @@ func logHandler(buf *buffer) *slog.TextHandler {
 	})
 }
 
+// warnHandler closes the warned channel after handling the first WARN log.
+type warnHandler struct {
+	slog.Handler
+
+	warned chan struct{}
+}
+
+func (h warnHandler) Handle(ctx context.Context, record slog.Record) error {
+	err := h.Handler.Handle(ctx, record)
+	if record.Level == slog.LevelWarn {
+		close(h.warned)
+	}
+
+	return err
+}
+
 type buffer struct {
 	b bytes.Buffer
 	m sync.RWMutex
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package webhook
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nil-go/konf"
+)
+
+// WithHTTPClient provides the HTTP client for posting to the webhook.
+//
+// By default, it uses http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(options *options) {
+		options.client = client
+	}
+}
+
+// WithTimeout provides the timeout of each attempt of posting to the webhook.
+//
+// By default, it's 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.timeout = timeout
+	}
+}
+
+// WithRetry provides the max number of retries after the first attempt,
+// and the initial backoff between retries which doubles after each retry.
+//
+// By default, it retries 3 times with initial backoff of 1 second.
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(options *options) {
+		options.retries = retries
+		options.backoff = backoff
+	}
+}
+
+// WithInstance provides the id of the instance in the payload.
+//
+// By default, it uses the host name.
+func WithInstance(instance string) Option {
+	return func(options *options) {
+		options.instance = instance
+	}
+}
+
+// WithPayload provides the function to build the payload from the Event,
+// which is marshaled as JSON.
+//
+// By default, it uses the Event as the payload.
+func WithPayload(payload func(Event) any) Option {
+	return func(options *options) {
+		options.payload = payload
+	}
+}
+
+// WithClock provides the konf.Clock for the timestamp of events and the backoff between retries.
+//
+// By default, it uses the wall clock.
+func WithClock(clock konf.Clock) Option {
+	return func(options *options) {
+		options.clock = clock
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from webhook.
+//
+// By default, it uses handler from slog.Default().
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the Webhook with specific options.
+	Option  func(options *options)
+	options Webhook
+)
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package webhook provides a helper that posts the changes of configuration to a webhook.
+//
+// It registers the callback via konf.Config.OnChange, so it requires konf.Config.Watch has been called.
+// Whenever the configuration changes, it posts a JSON payload with the changed keys,
+// the timestamp and the instance id to the webhook URL. The payload never contains configuration values.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Webhook posts the changes of configuration to the webhook URL.
+//
+// To create a new Webhook, call [New].
+type Webhook struct {
+	url      string
+	client   *http.Client
+	timeout  time.Duration
+	retries  int
+	backoff  time.Duration
+	instance string
+	payload  func(Event) any
+	logger   *slog.Logger
+	clock    konf.Clock
+}
+
+// Event is the change of configuration posted to the webhook.
+type Event struct {
+	// Keys are the paths of changed configuration, sorted and joined by ".".
+	Keys []string `json:"keys"`
+	// Timestamp is the time when the change is observed.
+	Timestamp time.Time `json:"timestamp"`
+	// Instance is the id of the instance where the change is observed.
+	Instance string `json:"instance"`
+}
+
+// New creates a Webhook with the given URL and Option(s).
+func New(url string, opts ...Option) *Webhook {
+	option := &options{
+		url:     url,
+		timeout: 10 * time.Second, //nolint:mnd
+		retries: 3,                //nolint:mnd
+		backoff: time.Second,
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.client == nil {
+		option.client = http.DefaultClient
+	}
+	if option.instance == "" {
+		// Ignore error: It uses whatever returned.
+		option.instance, _ = os.Hostname()
+	}
+	if option.clock == nil {
+		option.clock = clock.Real{}
+	}
+	if option.logger == nil {
+		option.logger = slog.Default()
+	}
+
+	return (*Webhook)(option)
+}
+
+// Register registers the Webhook to the given Config for changes on the given paths.
+// If no path is given, it observes changes on all paths.
+func (w *Webhook) Register(config *konf.Config, paths ...string) {
+	if w == nil || config == nil {
+		return
+	}
+
+	var (
+		snapshot map[string]any
+		mutex    sync.Mutex
+	)
+	// Ignore error: It compares with empty values if it fails.
+	_ = config.Unmarshal("", &snapshot)
+	config.OnChange(func(config *konf.Config) {
+		var values map[string]any
+		if err := config.Unmarshal("", &values); err != nil {
+			w.logger.LogAttrs(context.Background(), slog.LevelWarn,
+				"Fail to read configuration for webhook.",
+				slog.String("url", w.url),
+				slog.Any("error", err),
+			)
+
+			return
+		}
+
+		mutex.Lock()
+		oldValues := snapshot
+		snapshot = values
+		mutex.Unlock()
+
+		diff := maps.Diff(oldValues, values)
+		if len(diff) == 0 {
+			return
+		}
+		event := Event{
+			Keys:      make([]string, 0, len(diff)),
+			Timestamp: w.clock.Now(),
+			Instance:  w.instance,
+		}
+		for _, path := range diff {
+			event.Keys = append(event.Keys, strings.Join(path, "."))
+		}
+		go func() {
+			if err := w.Post(context.Background(), event); err != nil {
+				w.logger.LogAttrs(context.Background(), slog.LevelWarn,
+					"Fail to post configuration change to webhook.",
+					slog.String("url", w.url),
+					slog.Any("error", err),
+				)
+			}
+		}()
+	}, paths...)
+}
+
+// Post posts the given event to the webhook URL.
+// It retries with exponential backoff if the request fails or the response status is not 2xx.
+func (w *Webhook) Post(ctx context.Context, event Event) error {
+	if w == nil {
+		return errNil
+	}
+
+	var payload any = event
+	if w.payload != nil {
+		payload = w.payload(event)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	var errs []error
+	backoff := w.backoff
+	for attempt := 0; ; attempt++ {
+		err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if attempt >= w.retries {
+			return errors.Join(errs...)
+		}
+
+		if err := w.wait(ctx, backoff); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) wait(ctx context.Context, backoff time.Duration) error {
+	timer, stop := w.clock.NewTimer(backoff)
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer:
+		return nil
+	}
+}
+
+func (w *Webhook) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := w.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("post webhook: unexpected status %s", response.Status) //nolint:err113
+	}
+
+	return nil
+}
+
+var errNil = errors.New("nil Webhook")
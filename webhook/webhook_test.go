@@ -0,0 +1,201 @@
+// Copyright (c) 2025 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/internal/clock"
+	"github.com/nil-go/konf/webhook"
+)
+
+func TestWebhook_Register(t *testing.T) {
+	t.Parallel()
+
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		bodies <- body
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := konf.New()
+	watcher := mapWatcher{
+		values: map[string]any{"db": map[string]any{"password": "old", "host": "localhost"}},
+		change: make(chan map[string]any),
+	}
+	assert.NoError(t, config.Load(watcher))
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	webhook.New(server.URL, webhook.WithInstance("instance"), webhook.WithClock(clock.NewFake(now))).Register(config)
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	watcher.change <- map[string]any{"db": map[string]any{"password": "new", "host": "localhost"}}
+	var event webhook.Event
+	assert.NoError(t, json.Unmarshal(<-bodies, &event))
+	assert.Equal(t, []string{"db.password"}, event.Keys)
+	assert.Equal(t, "instance", event.Instance)
+	assert.Equal(t, now, event.Timestamp)
+}
+
+func TestWebhook_Post(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []webhook.Option
+		statuses    []int
+		expected    string
+		err         string
+	}{
+		{
+			description: "success",
+			statuses:    []int{http.StatusOK},
+			expected:    `{"keys":["a"],"timestamp":"0001-01-01T00:00:00Z","instance":"instance"}`,
+		},
+		{
+			description: "retry",
+			opts:        []webhook.Option{webhook.WithRetry(2, time.Millisecond)},
+			statuses:    []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			expected:    `{"keys":["a"],"timestamp":"0001-01-01T00:00:00Z","instance":"instance"}`,
+		},
+		{
+			description: "retry exhausted",
+			opts:        []webhook.Option{webhook.WithRetry(1, time.Millisecond)},
+			statuses:    []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			err: "post webhook: unexpected status 500 Internal Server Error\n" +
+				"post webhook: unexpected status 502 Bad Gateway",
+		},
+		{
+			description: "payload",
+			opts: []webhook.Option{
+				webhook.WithPayload(func(event webhook.Event) any {
+					return map[string]any{"text": event.Keys}
+				}),
+			},
+			statuses: []int{http.StatusOK},
+			expected: `{"text":["a"]}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				attempts atomic.Int32
+				body     atomic.Value
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				b, _ := io.ReadAll(request.Body)
+				body.Store(string(b))
+				writer.WriteHeader(testcase.statuses[attempts.Add(1)-1])
+			}))
+			defer server.Close()
+
+			opts := append([]webhook.Option{webhook.WithInstance("instance")}, testcase.opts...)
+			err := webhook.New(server.URL, opts...).Post(context.Background(), webhook.Event{
+				Keys:     []string{"a"},
+				Instance: "instance",
+			})
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, body.Load().(string))
+			}
+		})
+	}
+}
+
+func TestWebhook_Post_backoff(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	fake := clock.NewFake(time.Time{})
+	errs := make(chan error, 1)
+	go func() {
+		errs <- webhook.New(server.URL, webhook.WithRetry(2, time.Hour), webhook.WithClock(fake)).
+			Post(context.Background(), webhook.Event{})
+	}()
+	for _, backoff := range []time.Duration{time.Hour, 2 * time.Hour} {
+		fake.BlockUntil(1)
+		fake.Advance(backoff)
+	}
+	assert.NoError(t, <-errs)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestWebhook_Post_timeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release // Block until the client gives up.
+	}))
+	defer server.Close()
+	defer close(release)
+
+	err := webhook.New(server.URL, webhook.WithTimeout(time.Millisecond), webhook.WithRetry(0, 0)).
+		Post(context.Background(), webhook.Event{})
+	assert.EqualError(t, err, `post webhook: Post "`+server.URL+`": context deadline exceeded`)
+}
+
+func TestWebhook_nil(t *testing.T) {
+	t.Parallel()
+
+	var hook *webhook.Webhook
+	hook.Register(konf.New())
+	assert.EqualError(t, hook.Post(context.Background(), webhook.Event{}), "nil Webhook")
+}
+
+type mapWatcher struct {
+	values map[string]any
+	change chan map[string]any
+}
+
+func (m mapWatcher) Load() (map[string]any, error) {
+	return m.values, nil
+}
+
+func (m mapWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	for {
+		select {
+		case values := <-m.change:
+			onChange(values)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}